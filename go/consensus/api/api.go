@@ -125,6 +125,10 @@ type ClientBackend interface {
 	// included in a block and returns a proof of inclusion.
 	SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error)
 
+	// SubmitMultiSignedTx submits a multisig consensus transaction and waits for the transaction
+	// to be included in a block.
+	SubmitMultiSignedTx(ctx context.Context, tx *transaction.MultiSignedTransaction) error
+
 	// StateToGenesis returns the genesis state at the specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error)
 
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+// MethodBatch is the method name for the batch transaction which atomically executes multiple
+// method calls.
+var MethodBatch = transaction.NewMethodName(ModuleName, "Batch", Batch{})
+
+var _ prettyprint.PrettyPrinter = (*Batch)(nil)
+
+// BatchCall is a single method call in a batch transaction.
+type BatchCall struct {
+	// Method is the method that should be called.
+	Method transaction.MethodName `json:"method"`
+	// Body is the method call body.
+	Body cbor.RawMessage `json:"body,omitempty"`
+}
+
+// Batch is the body of a batch transaction.
+//
+// All calls are executed in order on behalf of the transaction signer. In case any of the calls
+// fails, the whole batch fails and none of the state changes are applied.
+type Batch struct {
+	// Calls are the method calls to execute.
+	Calls []BatchCall `json:"calls"`
+}
+
+// ValidateBasic performs basic batch validity checks.
+func (b *Batch) ValidateBasic(maxCalls uint16) error {
+	if len(b.Calls) == 0 {
+		return fmt.Errorf("batch has no calls")
+	}
+	if len(b.Calls) > int(maxCalls) {
+		return fmt.Errorf("too many calls in batch (max: %d)", maxCalls)
+	}
+	for i, call := range b.Calls {
+		if err := call.Method.SanityCheck(); err != nil {
+			return fmt.Errorf("call %d: %w", i, err)
+		}
+		switch {
+		case call.Method == MethodBatch:
+			return fmt.Errorf("call %d: nested batches are not allowed", i)
+		case call.Method.IsCritical():
+			return fmt.Errorf("call %d: critical methods are not allowed", i)
+		}
+		if _, isSystem := SystemMethods[call.Method]; isSystem {
+			return fmt.Errorf("call %d: system methods are not allowed", i)
+		}
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of the batch to the given writer.
+func (b Batch) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	for i, call := range b.Calls {
+		fmt.Fprintf(w, "%sCall %d:\n", prefix, i)
+		fmt.Fprintf(w, "%s  Method: %s\n", prefix, call.Method)
+		fmt.Fprintf(w, "%s  Body:\n", prefix)
+		tx := transaction.Transaction{Method: call.Method, Body: call.Body}
+		tx.PrettyPrintBody(ctx, prefix+"    ", w)
+	}
+}
+
+// PrettyType returns a representation of Batch that can be used for pretty printing.
+func (b Batch) PrettyType() (interface{}, error) {
+	type prettyCall struct {
+		Method transaction.MethodName `json:"method"`
+		Body   interface{}            `json:"body,omitempty"`
+	}
+
+	calls := make([]prettyCall, 0, len(b.Calls))
+	for i, call := range b.Calls {
+		tx := transaction.Transaction{Method: call.Method, Body: call.Body}
+		pt, err := tx.PrettyType()
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		calls = append(calls, prettyCall{
+			Method: call.Method,
+			Body:   pt.(*transaction.PrettyTransaction).Body,
+		})
+	}
+	return struct {
+		Calls []prettyCall `json:"calls"`
+	}{Calls: calls}, nil
+}
+
+// NewBatchCall creates a new batch call.
+func NewBatchCall(method transaction.MethodName, body interface{}) BatchCall {
+	var rawBody []byte
+	if body != nil {
+		rawBody = cbor.Marshal(body)
+	}
+	return BatchCall{
+		Method: method,
+		Body:   cbor.RawMessage(rawBody),
+	}
+}
+
+// NewBatchTx creates a new batch transaction.
+func NewBatchTx(nonce uint64, fee *transaction.Fee, calls []BatchCall) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodBatch, &Batch{Calls: calls})
+}
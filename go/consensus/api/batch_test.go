@@ -0,0 +1,46 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+type testBatchBodyCritical struct{}
+
+func (testBatchBodyCritical) MethodMetadata() transaction.MethodMetadata {
+	return transaction.MethodMetadata{Priority: transaction.MethodPriorityCritical}
+}
+
+func TestBatchValidateBasic(t *testing.T) {
+	require := require.New(t)
+
+	methodNormal := transaction.NewMethodName("test", "BatchNormal", struct{}{})
+	methodCritical := transaction.NewMethodName("test", "BatchCritical", testBatchBodyCritical{})
+
+	for _, tc := range []struct {
+		calls []BatchCall
+		ok    bool
+		msg   string
+	}{
+		{nil, false, "empty batch should be rejected"},
+		{[]BatchCall{NewBatchCall(methodNormal, nil)}, true, "single call should be accepted"},
+		{[]BatchCall{NewBatchCall(methodNormal, nil), NewBatchCall(methodNormal, nil)}, true, "multiple calls should be accepted"},
+		{[]BatchCall{NewBatchCall(methodNormal, nil), NewBatchCall(methodNormal, nil), NewBatchCall(methodNormal, nil)}, false, "too many calls should be rejected"},
+		{[]BatchCall{NewBatchCall("", nil)}, false, "empty method should be rejected"},
+		{[]BatchCall{NewBatchCall(MethodBatch, &Batch{})}, false, "nested batch should be rejected"},
+		{[]BatchCall{NewBatchCall(MethodMeta, nil)}, false, "system method should be rejected"},
+		{[]BatchCall{NewBatchCall(methodCritical, nil)}, false, "critical method should be rejected"},
+	} {
+		b := Batch{Calls: tc.calls}
+		err := b.ValidateBasic(2)
+		switch tc.ok {
+		case true:
+			require.NoError(err, tc.msg)
+		case false:
+			require.Error(err, tc.msg)
+		}
+	}
+}
@@ -30,6 +30,8 @@ var (
 	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", transaction.SignedTransaction{})
 	// methodSubmitTxWithProof is the SubmitTxWithProof method.
 	methodSubmitTxWithProof = serviceName.NewMethod("SubmitTxWithProof", transaction.SignedTransaction{})
+	// methodSubmitMultiSignedTx is the SubmitMultiSignedTx method.
+	methodSubmitMultiSignedTx = serviceName.NewMethod("SubmitMultiSignedTx", transaction.MultiSignedTransaction{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodEstimateGas is the EstimateGas method.
@@ -89,6 +91,10 @@ var (
 				MethodName: methodSubmitTxWithProof.ShortName(),
 				Handler:    handlerSubmitTxWithProof,
 			},
+			{
+				MethodName: methodSubmitMultiSignedTx.ShortName(),
+				Handler:    handlerSubmitMultiSignedTx,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -245,6 +251,29 @@ func handlerSubmitTxWithProof(
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerSubmitMultiSignedTx(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(transaction.MultiSignedTransaction)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(ClientBackend).SubmitMultiSignedTx(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitMultiSignedTx.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(ClientBackend).SubmitMultiSignedTx(ctx, req.(*transaction.MultiSignedTransaction))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -711,6 +740,10 @@ func (c *consensusClient) SubmitTxWithProof(ctx context.Context, tx *transaction
 	return &proof, nil
 }
 
+func (c *consensusClient) SubmitMultiSignedTx(ctx context.Context, tx *transaction.MultiSignedTransaction) error {
+	return c.conn.Invoke(ctx, methodSubmitMultiSignedTx.FullName(), tx, nil)
+}
+
 func (c *consensusClient) StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error) {
 	var rsp genesis.Document
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -0,0 +1,254 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+)
+
+// MaxMultisigSigners is the maximum number of signers in a multisig account configuration.
+const MaxMultisigSigners = 16
+
+var _ prettyprint.PrettyPrinter = (*MultiSignedTransaction)(nil)
+
+// MultisigConfig is a threshold multisig account configuration.
+//
+// The account address is derived from the configuration so the same set of signers and threshold
+// always results in the same account.
+type MultisigConfig struct {
+	// Signers are the public keys of the account signers, sorted in ascending order.
+	Signers []signature.PublicKey `json:"signers"`
+	// Threshold is the minimum number of signers that need to sign a transaction.
+	Threshold uint16 `json:"threshold"`
+}
+
+// NewMultisigConfig creates a new multisig account configuration with the given threshold and
+// signers. The signers are sorted so that the order in which they are given does not matter.
+func NewMultisigConfig(threshold uint16, signers ...signature.PublicKey) MultisigConfig {
+	sorted := append([]signature.PublicKey{}, signers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+	return MultisigConfig{
+		Signers:   sorted,
+		Threshold: threshold,
+	}
+}
+
+// ValidateBasic performs basic multisig configuration validity checks.
+func (c *MultisigConfig) ValidateBasic() error {
+	if len(c.Signers) == 0 {
+		return fmt.Errorf("multisig: no signers")
+	}
+	if len(c.Signers) > MaxMultisigSigners {
+		return fmt.Errorf("multisig: too many signers (max: %d)", MaxMultisigSigners)
+	}
+	if c.Threshold == 0 || int(c.Threshold) > len(c.Signers) {
+		return fmt.Errorf("multisig: invalid threshold %d for %d signers", c.Threshold, len(c.Signers))
+	}
+	for i, pk := range c.Signers {
+		if !pk.IsValid() {
+			return fmt.Errorf("multisig: signer %d: invalid public key", i)
+		}
+		if i > 0 && bytes.Compare(c.Signers[i-1][:], pk[:]) >= 0 {
+			return fmt.Errorf("multisig: signers must be unique and sorted")
+		}
+	}
+	return nil
+}
+
+// IsSigner returns true iff the given public key is one of the configured signers.
+func (c *MultisigConfig) IsSigner(pk signature.PublicKey) bool {
+	for _, v := range c.Signers {
+		if v.Equal(pk) {
+			return true
+		}
+	}
+	return false
+}
+
+// MultiSignedTransaction is a consensus transaction signed by a threshold multisig account.
+type MultiSignedTransaction struct {
+	signature.MultiSigned
+
+	// Config is the configuration of the multisig account that signed the transaction.
+	Config MultisigConfig `json:"multisig_config"`
+}
+
+// multisigSignedMessage is the message signed by the multisig account signers.
+type multisigSignedMessage struct {
+	// Config is the configuration of the multisig account that signs the transaction.
+	Config MultisigConfig `json:"multisig_config"`
+	// Blob is the encoded transaction.
+	Blob []byte `json:"tx"`
+}
+
+// signedMessage returns the message signed by the multisig account signers.
+//
+// The message includes the account configuration from which the account address is derived so
+// that the signatures cannot be reused for a different account.
+func (s *MultiSignedTransaction) signedMessage() []byte {
+	return cbor.Marshal(&multisigSignedMessage{
+		Config: s.Config,
+		Blob:   s.Blob,
+	})
+}
+
+// Hash returns the cryptographic hash of the encoded transaction.
+func (s *MultiSignedTransaction) Hash() hash.Hash {
+	return hash.NewFrom(s)
+}
+
+// PrettyPrint writes a pretty-printed representation of the type
+// to the given writer.
+func (s MultiSignedTransaction) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sHash: %s\n", prefix, s.Hash())
+
+	fmt.Fprintf(w, "%sThreshold: %d\n", prefix, s.Config.Threshold)
+	fmt.Fprintf(w, "%sSigners:\n", prefix)
+	for _, pk := range s.Config.Signers {
+		fmt.Fprintf(w, "%s  - %s\n", prefix, pk)
+	}
+
+	fmt.Fprintf(w, "%sSignatures:\n", prefix)
+	msg := s.signedMessage()
+	for _, sig := range s.Signatures {
+		fmt.Fprintf(w, "%s  - %s\n", prefix, sig.PublicKey)
+		fmt.Fprintf(w, "%s    (signature: %s)\n", prefix, sig.Signature)
+
+		// Check if signature is valid.
+		if !sig.Verify(MultisigSignatureContext, msg) {
+			fmt.Fprintf(w, "%s    [INVALID SIGNATURE]\n", prefix)
+		}
+	}
+
+	// Display the blob even if signature verification failed as it may
+	// be useful to look into it regardless.
+	var tx Transaction
+	fmt.Fprintf(w, "%sContent:\n", prefix)
+	if err := cbor.Unmarshal(s.Blob, &tx); err != nil {
+		fmt.Fprintf(w, "%s  <error: %s>\n", prefix, err)
+		fmt.Fprintf(w, "%s  <malformed: %s>\n", prefix, base64.StdEncoding.EncodeToString(s.Blob))
+		return
+	}
+
+	tx.PrettyPrint(ctx, prefix+"  ", w)
+}
+
+// PrettyType returns a representation of the type that can be used for pretty printing.
+func (s MultiSignedTransaction) PrettyType() (interface{}, error) {
+	var tx Transaction
+	if err := cbor.Unmarshal(s.Blob, &tx); err != nil {
+		return nil, fmt.Errorf("malformed signed blob: %w", err)
+	}
+	pt, err := tx.PrettyType()
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		Body       interface{}           `json:"untrusted_raw_value"`
+		Signatures []signature.Signature `json:"signatures"`
+		Config     MultisigConfig        `json:"multisig_config"`
+	}{
+		Body:       pt,
+		Signatures: s.Signatures,
+		Config:     s.Config,
+	}, nil
+}
+
+// Open verifies that the transaction has been signed by at least the threshold number of the
+// configured signers and then unmarshals the blob.
+func (s *MultiSignedTransaction) Open(tx *Transaction) error { // nolint: interfacer
+	if err := s.Config.ValidateBasic(); err != nil {
+		return err
+	}
+
+	seen := make(map[signature.PublicKey]bool, len(s.Signatures))
+	for _, sig := range s.Signatures {
+		if !s.Config.IsSigner(sig.PublicKey) {
+			return fmt.Errorf("multisig: signature by unknown signer %s", sig.PublicKey)
+		}
+		if seen[sig.PublicKey] {
+			return fmt.Errorf("multisig: duplicate signature by %s", sig.PublicKey)
+		}
+		seen[sig.PublicKey] = true
+	}
+	if len(s.Signatures) < int(s.Config.Threshold) {
+		return fmt.Errorf("multisig: not enough signatures (have: %d, need: %d)",
+			len(s.Signatures), s.Config.Threshold,
+		)
+	}
+
+	if !signature.VerifyManyToOne(MultisigSignatureContext, s.signedMessage(), s.Signatures) {
+		return signature.ErrVerifyFailed
+	}
+	if err := cbor.Unmarshal(s.Blob, tx); err != nil {
+		return err
+	}
+	if tx.FeePayer != nil {
+		return fmt.Errorf("%w: fee payers are not supported for multisig transactions", ErrInvalidFeePayer)
+	}
+	return nil
+}
+
+// AddSignature signs the transaction as one of the multisig account signers.
+//
+// This allows the signers to sign the transaction independently of each other.
+func (s *MultiSignedTransaction) AddSignature(signer signature.Signer) error {
+	pk := signer.Public()
+	if !s.Config.IsSigner(pk) {
+		return fmt.Errorf("multisig: %s is not a signer", pk)
+	}
+	if s.IsSignedBy(pk) {
+		return fmt.Errorf("multisig: already signed by %s", pk)
+	}
+
+	sig, err := signature.Sign(signer, MultisigSignatureContext, s.signedMessage())
+	if err != nil {
+		return err
+	}
+	s.Signatures = append(s.Signatures, *sig)
+
+	return nil
+}
+
+// NewMultiSignedTransaction creates a new multisig transaction envelope without any signatures.
+//
+// Signatures can be added by calling AddSignature.
+func NewMultiSignedTransaction(cfg MultisigConfig, tx *Transaction) (*MultiSignedTransaction, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if tx.FeePayer != nil {
+		return nil, fmt.Errorf("%w: fee payers are not supported for multisig transactions", ErrInvalidFeePayer)
+	}
+
+	return &MultiSignedTransaction{
+		MultiSigned: signature.MultiSigned{
+			Blob: cbor.Marshal(tx),
+		},
+		Config: cfg,
+	}, nil
+}
+
+// SignMultisig signs a transaction by the given multisig account signers.
+func SignMultisig(signers []signature.Signer, cfg MultisigConfig, tx *Transaction) (*MultiSignedTransaction, error) {
+	msTx, err := NewMultiSignedTransaction(cfg, tx)
+	if err != nil {
+		return nil, err
+	}
+	for _, signer := range signers {
+		if err = msTx.AddSignature(signer); err != nil {
+			return nil, err
+		}
+	}
+	return msTx, nil
+}
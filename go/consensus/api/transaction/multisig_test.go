@@ -0,0 +1,137 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+func TestMultisigConfig(t *testing.T) {
+	require := require.New(t)
+
+	pk1 := memorySigner.NewTestSigner("consensus/transaction: multisig config test 1").Public()
+	pk2 := memorySigner.NewTestSigner("consensus/transaction: multisig config test 2").Public()
+
+	cfg := NewMultisigConfig(1, pk2, pk1)
+	require.NoError(cfg.ValidateBasic())
+	require.EqualValues(NewMultisigConfig(1, pk1, pk2), cfg, "signers should be sorted")
+	require.True(cfg.IsSigner(pk1))
+	require.True(cfg.IsSigner(pk2))
+
+	for _, tc := range []struct {
+		cfg MultisigConfig
+		msg string
+	}{
+		{MultisigConfig{Threshold: 1}, "no signers should be rejected"},
+		{NewMultisigConfig(0, pk1, pk2), "zero threshold should be rejected"},
+		{NewMultisigConfig(3, pk1, pk2), "threshold above signer count should be rejected"},
+		{NewMultisigConfig(1, pk1, pk1), "duplicate signers should be rejected"},
+		{MultisigConfig{Signers: []signature.PublicKey{cfg.Signers[1], cfg.Signers[0]}, Threshold: 1}, "unsorted signers should be rejected"},
+	} {
+		require.Error(tc.cfg.ValidateBasic(), tc.msg)
+	}
+
+	signers := make([]signature.PublicKey, MaxMultisigSigners+1)
+	for i := range signers {
+		signers[i] = memorySigner.NewTestSigner("consensus/transaction: multisig config test " + string(rune('a'+i))).Public()
+	}
+	cfg = NewMultisigConfig(1, signers...)
+	require.Error(cfg.ValidateBasic(), "too many signers should be rejected")
+	cfg = NewMultisigConfig(1, signers[:MaxMultisigSigners]...)
+	require.NoError(cfg.ValidateBasic())
+}
+
+func TestMultiSignedTransaction(t *testing.T) {
+	require := require.New(t)
+
+	signature.UnsafeResetChainContext()
+	defer signature.UnsafeResetChainContext()
+	signature.SetChainContext("test: oasis-core multisig tests")
+
+	signer1 := memorySigner.NewTestSigner("consensus/transaction: multisig test signer 1")
+	signer2 := memorySigner.NewTestSigner("consensus/transaction: multisig test signer 2")
+	signer3 := memorySigner.NewTestSigner("consensus/transaction: multisig test signer 3")
+	other := memorySigner.NewTestSigner("consensus/transaction: multisig test other")
+
+	cfg := NewMultisigConfig(2, signer1.Public(), signer2.Public(), signer3.Public())
+	method := NewMethodName("test", "Multisig", nil)
+	open := func(msTx *MultiSignedTransaction) error {
+		// Round-trip through serialization to make sure the envelope is preserved.
+		var dec MultiSignedTransaction
+		require.NoError(cbor.Unmarshal(cbor.Marshal(msTx), &dec))
+		var tx Transaction
+		return dec.Open(&tx)
+	}
+
+	// Multisig transactions should not decode as regular signed transactions.
+	msTx, err := SignMultisig([]signature.Signer{signer1, signer3}, cfg, NewTransaction(0, nil, method, nil))
+	require.NoError(err, "SignMultisig")
+	require.NoError(open(msTx))
+	var sigTx SignedTransaction
+	require.Error(cbor.Unmarshal(cbor.Marshal(msTx), &sigTx), "multisig transaction should not decode as signed transaction")
+
+	// Signers can sign independently.
+	msTx, err = NewMultiSignedTransaction(cfg, NewTransaction(0, nil, method, nil))
+	require.NoError(err, "NewMultiSignedTransaction")
+	require.NoError(msTx.AddSignature(signer2), "AddSignature")
+	require.Error(open(msTx), "transaction below threshold should be rejected")
+	require.Error(msTx.AddSignature(signer2), "signing twice should fail")
+	require.Error(msTx.AddSignature(other), "signing by a non-signer should fail")
+	require.NoError(msTx.AddSignature(signer1), "AddSignature")
+	require.NoError(open(msTx))
+
+	// Duplicate signatures must not count towards the threshold.
+	dupTx := *msTx
+	dupTx.Signatures = []signature.Signature{msTx.Signatures[0], msTx.Signatures[0]}
+	require.Error(open(&dupTx), "duplicate signatures should be rejected")
+
+	// Signatures by unknown signers must be rejected.
+	sig, err := signature.Sign(other, MultisigSignatureContext, msTx.signedMessage())
+	require.NoError(err)
+	unkTx := *msTx
+	unkTx.Signatures = append(append([]signature.Signature{}, msTx.Signatures...), *sig)
+	require.Error(open(&unkTx), "signature by an unknown signer should be rejected")
+
+	// Signatures must be valid.
+	sig, err = signature.Sign(signer3, FeePayerSignatureContext, msTx.Blob)
+	require.NoError(err)
+	badTx := *msTx
+	badTx.Signatures = []signature.Signature{msTx.Signatures[0], *sig}
+	require.Error(open(&badTx), "signature with a wrong context should be rejected")
+
+	// Regular transaction signatures must not be accepted as multisig signatures.
+	sig, err = signature.Sign(signer3, SignatureContext, msTx.Blob)
+	require.NoError(err)
+	badTx.Signatures = []signature.Signature{msTx.Signatures[0], *sig}
+	require.Error(open(&badTx), "regular transaction signature should be rejected")
+
+	// Multisig signatures must not be accepted as regular transaction signatures.
+	sigTx = SignedTransaction{
+		Signed: signature.Signed{
+			Blob:      msTx.Blob,
+			Signature: msTx.Signatures[0],
+		},
+	}
+	var tx Transaction
+	require.Error(sigTx.Open(&tx), "multisig signature should not open as a signed transaction")
+
+	// Signatures must be bound to the multisig account configuration.
+	for _, otherCfg := range []MultisigConfig{
+		NewMultisigConfig(1, signer1.Public(), signer2.Public(), signer3.Public()),
+		NewMultisigConfig(2, signer1.Public(), signer2.Public()),
+	} {
+		cfgTx := *msTx
+		cfgTx.Config = otherCfg
+		require.Error(open(&cfgTx), "signatures should not verify under a different configuration")
+	}
+
+	// Fee payers are not supported.
+	tx = *NewTransaction(0, nil, method, nil)
+	tx.FeePayer = &FeePayer{PublicKey: other.Public()}
+	_, err = NewMultiSignedTransaction(cfg, &tx)
+	require.ErrorIs(err, ErrInvalidFeePayer, "multisig transaction with a fee payer should be rejected")
+}
@@ -30,9 +30,22 @@ var (
 	// ErrMethodNotSupported is the error returned if transaction method is not supported.
 	ErrMethodNotSupported = errors.New(moduleName, 5, "transaction: method not supported")
 
+	// ErrExpired is the error returned when the transaction is past its validity bound.
+	ErrExpired = errors.New(moduleName, 6, "transaction: expired")
+
+	// ErrInvalidFeePayer is the error returned when the fee payer signature is missing or invalid.
+	ErrInvalidFeePayer = errors.New(moduleName, 7, "transaction: invalid fee payer")
+
 	// SignatureContext is the context used for signing transactions.
 	SignatureContext = signature.NewContext("oasis-core/consensus: tx", signature.WithChainSeparation())
 
+	// FeePayerSignatureContext is the context used by fee payers for co-signing transactions.
+	FeePayerSignatureContext = signature.NewContext("oasis-core/consensus: tx fee payer", signature.WithChainSeparation())
+
+	// MultisigSignatureContext is the context used by multisig account signers for signing
+	// transactions.
+	MultisigSignatureContext = signature.NewContext("oasis-core/consensus: multisig tx", signature.WithChainSeparation())
+
 	registeredMethods sync.Map
 
 	_ prettyprint.PrettyPrinter = (*Transaction)(nil)
@@ -46,6 +59,12 @@ type Transaction struct {
 	// Fee is an optional fee that the sender commits to pay to execute this
 	// transaction.
 	Fee *Fee `json:"fee,omitempty"`
+	// ValidUntil is an optional validity bound after which the transaction can no longer be
+	// executed.
+	ValidUntil *ValidUntil `json:"valid_until,omitempty"`
+	// FeePayer is an optional fee payer that pays the fee instead of the signer. The fee payer
+	// must co-sign the transaction.
+	FeePayer *FeePayer `json:"fee_payer,omitempty"`
 
 	// Method is the method that should be called.
 	Method MethodName `json:"method"`
@@ -53,6 +72,63 @@ type Transaction struct {
 	Body cbor.RawMessage `json:"body,omitempty"`
 }
 
+// FeePayer is the account that pays the transaction fee on behalf of the signer.
+type FeePayer struct {
+	// PublicKey is the public key of the fee payer.
+	PublicKey signature.PublicKey `json:"public_key"`
+	// Nonce is the current fee payer's nonce to prevent replays.
+	Nonce uint64 `json:"nonce"`
+}
+
+// ValidUntil is a transaction validity bound.
+//
+// In case both the height and the epoch are set, the transaction is only valid while neither of
+// them has passed.
+type ValidUntil struct {
+	// Height is the last consensus height at which the transaction can be executed.
+	Height int64 `json:"height,omitempty"`
+	// Epoch is the last epoch in which the transaction can be executed.
+	Epoch uint64 `json:"epoch,omitempty"`
+}
+
+// SanityCheck performs a basic sanity check on the validity bound.
+func (v *ValidUntil) SanityCheck() error {
+	if v.Height < 0 {
+		return fmt.Errorf("transaction: negative valid until height")
+	}
+	if v.Height == 0 && v.Epoch == 0 {
+		return fmt.Errorf("transaction: empty valid until")
+	}
+	return nil
+}
+
+// IsExpired returns true iff a transaction with this validity bound can no longer be executed at
+// the given height and epoch.
+func (v *ValidUntil) IsExpired(height int64, epoch uint64) bool {
+	if v.Height > 0 && height > v.Height {
+		return true
+	}
+	if v.Epoch > 0 && epoch > v.Epoch {
+		return true
+	}
+	return false
+}
+
+// PrettyPrint writes a pretty-printed representation of the validity bound to the given writer.
+func (v ValidUntil) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	if v.Height > 0 {
+		fmt.Fprintf(w, "%sHeight: %d\n", prefix, v.Height)
+	}
+	if v.Epoch > 0 {
+		fmt.Fprintf(w, "%sEpoch:  %d\n", prefix, v.Epoch)
+	}
+}
+
+// PrettyType returns a representation of ValidUntil that can be used for pretty printing.
+func (v ValidUntil) PrettyType() (interface{}, error) {
+	return v, nil
+}
+
 // PrettyPrintBody writes a pretty-printed representation of transaction's body
 // to the given writer.
 func (t Transaction) PrettyPrintBody(ctx context.Context, prefix string, w io.Writer) {
@@ -98,6 +174,13 @@ func (t Transaction) PrettyPrint(ctx context.Context, prefix string, w io.Writer
 	} else {
 		fmt.Fprintf(w, "%sFee:   none\n", prefix)
 	}
+	if t.ValidUntil != nil {
+		fmt.Fprintf(w, "%sValid until:\n", prefix)
+		t.ValidUntil.PrettyPrint(ctx, prefix+"  ", w)
+	}
+	if t.FeePayer != nil {
+		fmt.Fprintf(w, "%sFee payer: %s (nonce: %d)\n", prefix, t.FeePayer.PublicKey, t.FeePayer.Nonce)
+	}
 	if genesisHash, ok := ctx.Value(prettyprint.ContextKeyGenesisHash).(hash.Hash); ok {
 		fmt.Println("Other info:")
 		fmt.Printf("  Genesis document's hash: %s\n", genesisHash)
@@ -126,15 +209,22 @@ func (t *Transaction) PrettyType() (interface{}, error) {
 	}
 
 	return &PrettyTransaction{
-		Nonce:  t.Nonce,
-		Fee:    t.Fee,
-		Method: t.Method,
-		Body:   body,
+		Nonce:      t.Nonce,
+		Fee:        t.Fee,
+		ValidUntil: t.ValidUntil,
+		FeePayer:   t.FeePayer,
+		Method:     t.Method,
+		Body:       body,
 	}, nil
 }
 
 // SanityCheck performs a basic sanity check on the transaction.
 func (t *Transaction) SanityCheck() error {
+	if t.ValidUntil != nil {
+		if err := t.ValidUntil.SanityCheck(); err != nil {
+			return err
+		}
+	}
 	return t.Method.SanityCheck()
 }
 
@@ -158,15 +248,21 @@ func NewTransaction(nonce uint64, fee *Fee, method MethodName, body interface{})
 //
 // It should only be used for pretty printing.
 type PrettyTransaction struct {
-	Nonce  uint64      `json:"nonce"`
-	Fee    *Fee        `json:"fee,omitempty"`
-	Method MethodName  `json:"method"`
-	Body   interface{} `json:"body,omitempty"`
+	Nonce      uint64      `json:"nonce"`
+	Fee        *Fee        `json:"fee,omitempty"`
+	ValidUntil *ValidUntil `json:"valid_until,omitempty"`
+	FeePayer   *FeePayer   `json:"fee_payer,omitempty"`
+	Method     MethodName  `json:"method"`
+	Body       interface{} `json:"body,omitempty"`
 }
 
 // SignedTransaction is a signed consensus transaction.
 type SignedTransaction struct {
 	signature.Signed
+
+	// FeePayerSignature is the fee payer signature over the transaction. It must be present iff
+	// the transaction specifies a fee payer.
+	FeePayerSignature *signature.Signature `json:"fee_payer_signature,omitempty"`
 }
 
 // Hash returns the cryptographic hash of the encoded transaction.
@@ -187,6 +283,15 @@ func (s SignedTransaction) PrettyPrint(ctx context.Context, prefix string, w io.
 		fmt.Fprintf(w, "%s        [INVALID SIGNATURE]\n", prefix)
 	}
 
+	if s.FeePayerSignature != nil {
+		fmt.Fprintf(w, "%sFee payer: %s\n", prefix, s.FeePayerSignature.PublicKey)
+		fmt.Fprintf(w, "%s           (signature: %s)\n", prefix, s.FeePayerSignature.Signature)
+
+		if !s.FeePayerSignature.Verify(FeePayerSignatureContext, s.Blob) {
+			fmt.Fprintf(w, "%s           [INVALID SIGNATURE]\n", prefix)
+		}
+	}
+
 	// Display the blob even if signature verification failed as it may
 	// be useful to look into it regardless.
 	var tx Transaction
@@ -209,9 +314,36 @@ func (s SignedTransaction) PrettyType() (interface{}, error) {
 	return signature.NewPrettySigned(s.Signed, tx)
 }
 
-// Open first verifies the blob signature and then unmarshals the blob.
+// Open first verifies the blob signature and then unmarshals the blob. In case the transaction
+// specifies a fee payer, the fee payer signature is verified as well.
 func (s *SignedTransaction) Open(tx *Transaction) error { // nolint: interfacer
-	return s.Signed.Open(SignatureContext, tx)
+	if err := s.Signed.Open(SignatureContext, tx); err != nil {
+		return err
+	}
+	return s.verifyFeePayer(tx)
+}
+
+// verifyFeePayer verifies the fee payer signature against the given (already opened) transaction.
+func (s *SignedTransaction) verifyFeePayer(tx *Transaction) error {
+	switch {
+	case tx.FeePayer == nil && s.FeePayerSignature == nil:
+		return nil
+	case tx.FeePayer == nil:
+		return fmt.Errorf("%w: unexpected fee payer signature", ErrInvalidFeePayer)
+	case s.FeePayerSignature == nil:
+		return fmt.Errorf("%w: missing fee payer signature", ErrInvalidFeePayer)
+	}
+
+	if tx.FeePayer.PublicKey.Equal(s.Signature.PublicKey) {
+		return fmt.Errorf("%w: fee payer must differ from signer", ErrInvalidFeePayer)
+	}
+	if !s.FeePayerSignature.PublicKey.Equal(tx.FeePayer.PublicKey) {
+		return fmt.Errorf("%w: fee payer signature public key mismatch", ErrInvalidFeePayer)
+	}
+	if !s.FeePayerSignature.Verify(FeePayerSignatureContext, s.Blob) {
+		return fmt.Errorf("%w: invalid fee payer signature", ErrInvalidFeePayer)
+	}
+	return nil
 }
 
 // Sign signs a transaction.
@@ -224,6 +356,27 @@ func Sign(signer signature.Signer, tx *Transaction) (*SignedTransaction, error)
 	return &SignedTransaction{Signed: *signed}, nil
 }
 
+// SignFeePayer co-signs an already signed transaction as its fee payer.
+//
+// The transaction must specify the public key of the given signer as its fee payer.
+func SignFeePayer(signer signature.Signer, sigTx *SignedTransaction) error {
+	var tx Transaction
+	if err := cbor.Unmarshal(sigTx.Blob, &tx); err != nil {
+		return fmt.Errorf("malformed signed blob: %w", err)
+	}
+	if tx.FeePayer == nil || !tx.FeePayer.PublicKey.Equal(signer.Public()) {
+		return fmt.Errorf("%w: signer is not the transaction fee payer", ErrInvalidFeePayer)
+	}
+
+	sig, err := signature.Sign(signer, FeePayerSignatureContext, sigTx.Blob)
+	if err != nil {
+		return err
+	}
+	sigTx.FeePayerSignature = sig
+
+	return nil
+}
+
 // OpenRawTransactions takes a vector of raw byte-serialized SignedTransactions,
 // and deserializes them, returning all of the signing public key and deserialized
 // Transaction, for the transactions that have valid signatures.
@@ -265,6 +418,10 @@ func OpenRawTransactions(rawTxBytes [][]byte) ([]signature.PublicKey, []*Transac
 			errs[i] = err
 			continue
 		}
+		if err := signedTxes[i].verifyFeePayer(&tx); err != nil {
+			errs[i] = err
+			continue
+		}
 		txes[i] = &tx
 	}
 
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 )
 
 type testMethodBodyNormal struct{}
@@ -24,3 +28,82 @@ func TestMethodMetadata(t *testing.T) {
 	require.False(methodNormal.IsCritical())
 	require.True(methodCritical.IsCritical())
 }
+
+func TestValidUntil(t *testing.T) {
+	require := require.New(t)
+
+	require.Error((&ValidUntil{}).SanityCheck(), "empty validity bound should be rejected")
+	require.Error((&ValidUntil{Height: -1, Epoch: 1}).SanityCheck(), "negative height should be rejected")
+	require.NoError((&ValidUntil{Height: 10}).SanityCheck())
+	require.NoError((&ValidUntil{Epoch: 10}).SanityCheck())
+
+	vu := ValidUntil{Height: 100}
+	require.False(vu.IsExpired(99, 1000))
+	require.False(vu.IsExpired(100, 1000))
+	require.True(vu.IsExpired(101, 0))
+
+	vu = ValidUntil{Epoch: 5}
+	require.False(vu.IsExpired(1000, 5))
+	require.True(vu.IsExpired(0, 6))
+
+	vu = ValidUntil{Height: 100, Epoch: 5}
+	require.False(vu.IsExpired(100, 5))
+	require.True(vu.IsExpired(101, 5))
+	require.True(vu.IsExpired(100, 6))
+
+	tx := NewTransaction(0, nil, NewMethodName("test", "ValidUntil", nil), nil)
+	require.NoError(tx.SanityCheck())
+	tx.ValidUntil = &ValidUntil{}
+	require.Error(tx.SanityCheck(), "transaction with an empty validity bound should be rejected")
+}
+
+func TestFeePayer(t *testing.T) {
+	require := require.New(t)
+
+	signature.UnsafeResetChainContext()
+	defer signature.UnsafeResetChainContext()
+	signature.SetChainContext("test: oasis-core fee payer tests")
+
+	signer := memorySigner.NewTestSigner("consensus/transaction: fee payer test signer")
+	payer := memorySigner.NewTestSigner("consensus/transaction: fee payer test payer")
+	other := memorySigner.NewTestSigner("consensus/transaction: fee payer test other")
+
+	method := NewMethodName("test", "FeePayer", nil)
+	open := func(sigTx *SignedTransaction) error {
+		// Round-trip through serialization to make sure the envelope is preserved.
+		var dec SignedTransaction
+		require.NoError(cbor.Unmarshal(cbor.Marshal(sigTx), &dec))
+		var tx Transaction
+		return dec.Open(&tx)
+	}
+
+	// Transaction without a fee payer.
+	sigTx, err := Sign(signer, NewTransaction(0, nil, method, nil))
+	require.NoError(err, "Sign")
+	require.NoError(open(sigTx))
+	require.ErrorIs(SignFeePayer(payer, sigTx), ErrInvalidFeePayer, "co-signing without a fee payer should fail")
+
+	// Transaction with a fee payer.
+	tx := NewTransaction(0, nil, method, nil)
+	tx.FeePayer = &FeePayer{PublicKey: payer.Public(), Nonce: 5}
+	sigTx, err = Sign(signer, tx)
+	require.NoError(err, "Sign")
+	require.ErrorIs(open(sigTx), ErrInvalidFeePayer, "missing fee payer signature should be rejected")
+	require.ErrorIs(SignFeePayer(other, sigTx), ErrInvalidFeePayer, "co-signing by a non-fee payer should fail")
+	require.NoError(SignFeePayer(payer, sigTx), "SignFeePayer")
+	require.NoError(open(sigTx))
+
+	// Fee payer signature must be made using the fee payer context.
+	badSig, err := signature.Sign(payer, SignatureContext, sigTx.Blob)
+	require.NoError(err)
+	badTx := *sigTx
+	badTx.FeePayerSignature = badSig
+	require.ErrorIs(open(&badTx), ErrInvalidFeePayer, "fee payer signature with a wrong context should be rejected")
+
+	// Fee payer must differ from signer.
+	tx.FeePayer = &FeePayer{PublicKey: signer.Public()}
+	sigTx, err = Sign(signer, tx)
+	require.NoError(err, "Sign")
+	require.NoError(SignFeePayer(signer, sigTx), "SignFeePayer")
+	require.ErrorIs(open(sigTx), ErrInvalidFeePayer, "fee payer equal to signer should be rejected")
+}
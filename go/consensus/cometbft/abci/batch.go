@@ -0,0 +1,65 @@
+package abci
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+)
+
+// decodeBatch decodes and validates the body of a batch transaction and makes sure that all of the
+// called methods are available.
+func (mux *abciMux) decodeBatch(ctx *api.Context, tx *transaction.Transaction) (*consensus.Batch, error) {
+	params := mux.state.ConsensusParameters()
+	if params.MaxBatchCalls == 0 {
+		// If batches are disabled, treat it as if the method does not exist.
+		return nil, fmt.Errorf("mux: unknown method: %s", tx.Method)
+	}
+
+	var batch consensus.Batch
+	if err := cbor.Unmarshal(tx.Body, &batch); err != nil {
+		return nil, consensus.ErrInvalidArgument
+	}
+	if err := batch.ValidateBasic(params.MaxBatchCalls); err != nil {
+		ctx.Logger().Debug("invalid batch",
+			"err", err,
+		)
+		return nil, consensus.ErrInvalidArgument
+	}
+	for _, call := range batch.Calls {
+		if _, err := mux.resolveAppForMethod(ctx, call.Method); err != nil {
+			return nil, err
+		}
+	}
+	return &batch, nil
+}
+
+// executeBatch executes all calls of a batch transaction.
+//
+// Calls are executed as subcalls on behalf of the transaction caller. In case any of the calls
+// fails, none of the state changes or events are applied.
+func (mux *abciMux) executeBatch(ctx *api.Context, batch *consensus.Batch) error {
+	ctx = ctx.NewTransaction()
+	defer ctx.Close()
+
+	for i, call := range batch.Calls {
+		if err := mux.executeSubcall(ctx, &api.SubcallInfo{
+			Caller: ctx.CallerAddress(),
+			Method: call.Method,
+			Body:   call.Body,
+		}); err != nil {
+			ctx.Logger().Debug("batch call failed",
+				"call", i,
+				"method", call.Method,
+				"err", err,
+			)
+			return fmt.Errorf("batch call %d: %w", i, err)
+		}
+	}
+
+	ctx.Commit()
+
+	return nil
+}
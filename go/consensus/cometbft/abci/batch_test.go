@@ -0,0 +1,123 @@
+package abci
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+)
+
+var (
+	methodTestWrite = transaction.NewMethodName("test", "Write", nil)
+	methodTestFail  = transaction.NewMethodName("test", "Fail", nil)
+
+	gasOpTestWrite = transaction.Op("write")
+)
+
+// testWrittenEvent is the event emitted by the batch test application on writes.
+type testWrittenEvent struct {
+	Key string `json:"key"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *testWrittenEvent) EventKind() string {
+	return "written"
+}
+
+// testBatchApp is an application which writes keys to state and emits events, used for testing
+// batch transactions.
+type testBatchApp struct {
+	api.Application
+}
+
+func (app *testBatchApp) Name() string {
+	return "test"
+}
+
+func (app *testBatchApp) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
+	if err := ctx.Gas().UseGas(1, gasOpTestWrite, transaction.Costs{gasOpTestWrite: 10}); err != nil {
+		return err
+	}
+
+	switch tx.Method {
+	case methodTestWrite:
+		var key string
+		if err := cbor.Unmarshal(tx.Body, &key); err != nil {
+			return err
+		}
+		if err := ctx.State().Insert(ctx, []byte(key), []byte("value")); err != nil {
+			return err
+		}
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&testWrittenEvent{Key: key}))
+		return nil
+	case methodTestFail:
+		return fmt.Errorf("test: call failed")
+	default:
+		return fmt.Errorf("test: unknown method")
+	}
+}
+
+func TestProcessBatchTx(t *testing.T) {
+	require := require.New(t)
+
+	app := &testBatchApp{}
+	mux := &abciMux{
+		state: &applicationState{
+			blockParams: &consensusGenesis.Parameters{
+				MaxBatchCalls: 4,
+			},
+		},
+		appsByMethod: map[transaction.MethodName]api.Application{
+			methodTestWrite: app,
+			methodTestFail:  app,
+		},
+	}
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+
+	processTx := func(calls ...consensus.BatchCall) (*api.Context, error) {
+		ctx := appState.NewContext(api.ContextDeliverTx)
+		ctx.SetGasAccountant(api.NewGasAccountant(1000))
+		return ctx, mux.processTx(ctx, consensus.NewBatchTx(0, nil, calls), 0)
+	}
+	get := func(ctx *api.Context, key string) []byte {
+		value, err := ctx.State().Get(context.Background(), []byte(key))
+		require.NoError(err, "Get")
+		return value
+	}
+
+	// A failing call should roll back the state changes and events of all previous calls.
+	ctx, err := processTx(
+		consensus.NewBatchCall(methodTestWrite, "a"),
+		consensus.NewBatchCall(methodTestWrite, "b"),
+		consensus.NewBatchCall(methodTestFail, nil),
+		consensus.NewBatchCall(methodTestWrite, "c"),
+	)
+	defer ctx.Close()
+	require.ErrorContains(err, "batch call 2")
+	for _, key := range []string{"a", "b", "c"} {
+		require.Nil(get(ctx, key), "state changes should be rolled back")
+	}
+	require.Empty(ctx.GetEvents(), "events should be rolled back")
+	require.EqualValues(30, ctx.Gas().GasUsed(), "gas should be charged for executed calls")
+
+	// A successful batch should apply all calls and use the sum of gas across calls.
+	ctx, err = processTx(
+		consensus.NewBatchCall(methodTestWrite, "a"),
+		consensus.NewBatchCall(methodTestWrite, "b"),
+		consensus.NewBatchCall(methodTestWrite, "c"),
+	)
+	defer ctx.Close()
+	require.NoError(err, "processTx")
+	for _, key := range []string{"a", "b", "c"} {
+		require.Equal([]byte("value"), get(ctx, key))
+	}
+	require.Len(ctx.GetEvents(), 3, "events of all calls should be emitted")
+	require.EqualValues(30, ctx.Gas().GasUsed(), "gas should be the sum across calls")
+}
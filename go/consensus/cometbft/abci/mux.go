@@ -27,6 +27,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	abciState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -61,8 +63,14 @@ type ApplicationConfig struct { // nolint: maligned
 	HaltHeight     uint64
 	MinGasPrice    uint64
 
-	DisableCheckpointer       bool
-	CheckpointerCheckInterval time.Duration
+	// MaxProposalTxsPerSigner is the maximum number of transactions of the same account (either
+	// as the signer or the fee payer) that are included in a block proposal (zero means no limit).
+	MaxProposalTxsPerSigner uint64
+
+	DisableCheckpointer             bool
+	CheckpointerCheckInterval       time.Duration
+	CheckpointerMaxDeltaCheckpoints uint64
+	CheckpointerChunkCodec          checkpoint.ChunkCodec
 
 	// Identity is the local node identity.
 	Identity *identity.Identity
@@ -226,6 +234,8 @@ type abciMux struct {
 	invalidatedTxs sync.Map
 
 	md messageDispatcher
+
+	maxProposalTxsPerSigner uint64
 }
 
 type invalidatedTxSubscription struct {
@@ -385,15 +395,13 @@ func (mux *abciMux) PrepareProposal(req types.RequestPrepareProposal) types.Resp
 	// Make sure there will be enough space for any metadata transactions.
 	maxTxBytes := req.MaxTxBytes - consensus.BlockMetadataMaxSize
 
-	// Schedule an initial set of transactions.
-	txs := make([][]byte, 0, len(req.Txs))
-	var totalBytes int64
-	for _, tx := range req.Txs {
-		totalBytes += int64(len(tx))
-		if totalBytes > maxTxBytes {
-			break
-		}
-		txs = append(txs, tx)
+	// Schedule an initial set of transactions, ordered by their effective gas price.
+	txs, skipped := orderProposalTxs(req.Txs, maxTxBytes, mux.maxProposalTxsPerSigner)
+	if skipped > 0 {
+		mux.logger.Debug("skipped malformed transactions in proposal",
+			"height", req.Height,
+			"skipped", skipped,
+		)
 	}
 
 	// Execute the proposal.
@@ -984,10 +992,14 @@ func newABCIMux(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationC
 		state:        state,
 		appsByName:   make(map[string]api.Application),
 		appsByMethod: make(map[transaction.MethodName]api.Application),
+
+		maxProposalTxsPerSigner: cfg.MaxProposalTxsPerSigner,
 	}
 
 	// Subscribe message handlers.
 	mux.md.Subscribe(api.MessageExecuteSubcall, mux)
+	mux.md.Subscribe(governanceApi.MessageChangeParameters, mux)
+	mux.md.Subscribe(governanceApi.MessageValidateParameterChanges, mux)
 
 	mux.logger.Debug("ABCI multiplexer initialized",
 		"block_height", state.BlockHeight(),
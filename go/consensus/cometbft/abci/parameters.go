@@ -0,0 +1,60 @@
+package abci
+
+import (
+	"fmt"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	abciState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+)
+
+// immutableParameters are the consensus parameters that cannot be changed via parameters patches
+// as they are either mirrored in the CometBFT consensus parameters or only used during startup.
+var immutableParameters = []string{
+	"timeout_commit",
+	"skip_timeout_commit",
+	"empty_block_interval",
+	"max_tx_size",
+	"max_block_size",
+	"max_block_gas",
+	"max_evidence_size",
+	"state_checkpoint_interval",
+	"state_checkpoint_num_kept",
+	"state_checkpoint_chunk_size",
+	"public_key_blacklist",
+	"feature_version",
+}
+
+func (mux *abciMux) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
+	// Unmarshal changes and check if they should be applied to this module.
+	proposal, ok := msg.(*governance.ChangeParametersProposal)
+	if !ok {
+		return nil, fmt.Errorf("mux: failed to type assert change parameters proposal")
+	}
+
+	if proposal.Module != consensus.ModuleName {
+		return nil, nil
+	}
+
+	// Validate changes against current parameters. The core consensus parameters do not define
+	// module-specific changes so only generic parameter patches are supported.
+	state := abciState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("mux: failed to load consensus parameters: %w", err)
+	}
+	if err = governance.ApplyChangeParametersProposal(proposal, params, nil, immutableParameters); err != nil {
+		return nil, fmt.Errorf("mux: %w", err)
+	}
+
+	// Apply changes. The cached consensus parameters are updated when the block is committed.
+	if apply {
+		if err = state.SetConsensusParameters(ctx, params); err != nil {
+			return nil, fmt.Errorf("mux: failed to update consensus parameters: %w", err)
+		}
+	}
+
+	// Non-nil response signals that changes are valid and were successfully applied (if required).
+	return struct{}{}, nil
+}
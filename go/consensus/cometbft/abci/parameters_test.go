@@ -0,0 +1,75 @@
+package abci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	abciState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+)
+
+func TestChangeParameters(t *testing.T) {
+	require := require.New(t)
+
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	ctx := appState.NewContext(api.ContextInitChain)
+	defer ctx.Close()
+
+	state := abciState.NewMutableState(ctx.State())
+	err := state.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		SkipTimeoutCommit: true,
+		MaxTxSize:         32 * 1024,
+		MaxBatchCalls:     4,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	ctx = appState.NewContext(api.ContextEndBlock)
+	defer ctx.Close()
+
+	mux := &abciMux{}
+
+	// Proposals for other modules should be ignored.
+	res, err := mux.changeParameters(ctx, &governance.ChangeParametersProposal{
+		Module: "other",
+		Patch:  cbor.Marshal(map[string]any{"max_batch_calls": uint16(8)}),
+	}, true)
+	require.NoError(err, "changeParameters")
+	require.Nil(res, "proposals for other modules should be ignored")
+
+	for _, tc := range []struct {
+		name  string
+		patch map[string]any
+	}{
+		{"module-specific changes", nil},
+		{"immutable field", map[string]any{"max_tx_size": uint64(64 * 1024)}},
+		{"invalid parameters", map[string]any{"skip_timeout_commit": false}},
+	} {
+		proposal := &governance.ChangeParametersProposal{Module: consensus.ModuleName}
+		if tc.patch != nil {
+			proposal.Patch = cbor.Marshal(tc.patch)
+		} else {
+			proposal.Changes = cbor.Marshal(map[string]any{"max_batch_calls": uint16(8)})
+		}
+
+		_, err = mux.changeParameters(ctx, proposal, true)
+		require.Error(err, tc.name)
+	}
+
+	// Valid patches should be applied.
+	res, err = mux.changeParameters(ctx, &governance.ChangeParametersProposal{
+		Module: consensus.ModuleName,
+		Patch:  cbor.Marshal(map[string]any{"max_batch_calls": uint16(8)}),
+	}, true)
+	require.NoError(err, "changeParameters")
+	require.NotNil(res, "changeParameters")
+
+	params, err := state.ConsensusParameters(ctx)
+	require.NoError(err, "ConsensusParameters")
+	require.EqualValues(8, params.MaxBatchCalls)
+	require.EqualValues(32*1024, params.MaxTxSize, "other parameters should not change")
+}
@@ -0,0 +1,224 @@
+package abci
+
+import (
+	"container/heap"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// proposalTx is a transaction considered for inclusion in a block proposal.
+type proposalTx struct {
+	raw      []byte
+	index    int
+	gasPrice *quantity.Quantity
+	accounts []staking.Address
+
+	// waiting is the number of accounts for which earlier transactions still need to be
+	// included or dropped before this transaction.
+	waiting int
+}
+
+// proposalQueue is a priority queue of transactions ordered by their effective gas price.
+type proposalQueue []*proposalTx
+
+// Implements heap.Interface.
+func (q proposalQueue) Len() int {
+	return len(q)
+}
+
+// Implements heap.Interface.
+func (q proposalQueue) Less(i, j int) bool {
+	ti, tj := q[i], q[j]
+	switch ti.gasPrice.Cmp(tj.gasPrice) {
+	case 1:
+		return true
+	case -1:
+		return false
+	default:
+		// Use arrival order for transactions with the same gas price.
+		return ti.index < tj.index
+	}
+}
+
+// Implements heap.Interface.
+func (q proposalQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+// Implements heap.Interface.
+func (q *proposalQueue) Push(x interface{}) {
+	*q = append(*q, x.(*proposalTx))
+}
+
+// Implements heap.Interface.
+func (q *proposalQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	tx := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return tx
+}
+
+// decodeProposalTx decodes the given raw transaction for the purpose of ordering it in a block
+// proposal.
+//
+// Signatures are not verified as this is done when the proposal is executed.
+func decodeProposalTx(raw []byte, index int) (*proposalTx, error) {
+	var (
+		tx       transaction.Transaction
+		blob     []byte
+		accounts []staking.Address
+	)
+
+	var sigTx transaction.SignedTransaction
+	switch err := cbor.Unmarshal(raw, &sigTx); err {
+	case nil:
+		blob = sigTx.Blob
+		accounts = append(accounts, staking.NewAddress(sigTx.Signature.PublicKey))
+	default:
+		var msTx transaction.MultiSignedTransaction
+		if msErr := cbor.Unmarshal(raw, &msTx); msErr != nil {
+			return nil, err
+		}
+		blob = msTx.Blob
+		accounts = append(accounts, staking.NewMultisigAddress(&msTx.Config))
+	}
+	if err := cbor.Unmarshal(blob, &tx); err != nil {
+		return nil, err
+	}
+	if tx.FeePayer != nil {
+		if feePayer := staking.NewAddress(tx.FeePayer.PublicKey); !feePayer.Equal(accounts[0]) {
+			accounts = append(accounts, feePayer)
+		}
+	}
+
+	gasPrice := quantity.NewQuantity()
+	if tx.Fee != nil {
+		gasPrice = tx.Fee.GasPrice()
+	}
+
+	return &proposalTx{
+		raw:      raw,
+		index:    index,
+		gasPrice: gasPrice,
+		accounts: accounts,
+	}, nil
+}
+
+// orderProposalTxs selects and orders transactions for inclusion in a block proposal.
+//
+// Transactions are ordered by their effective gas price, highest first. Transactions of the same
+// account (either as the transaction signer or the fee payer) keep their relative arrival order so
+// that the account nonces remain in order. At most maxTxsPerSigner transactions of each account
+// are included (zero means no limit) so that a single signer cannot fill the whole block. The
+// total size of the selected transactions does not exceed maxTxBytes.
+//
+// Transactions that are not included also cause all later transactions of the same accounts to
+// be excluded as those depend on them.
+//
+// Transactions that cannot be decoded are skipped and their number is returned.
+func orderProposalTxs(rawTxs [][]byte, maxTxBytes int64, maxTxsPerSigner uint64) ([][]byte, int) {
+	var skipped int
+	ptxs := make([]*proposalTx, 0, len(rawTxs))
+	for i, raw := range rawTxs {
+		ptx, err := decodeProposalTx(raw, i)
+		if err != nil {
+			skipped++
+			continue
+		}
+		ptxs = append(ptxs, ptx)
+	}
+
+	// Queue transactions of each account in arrival order, limiting their number.
+	var (
+		excluded = make(map[staking.Address]bool)
+		counts   = make(map[staking.Address]uint64)
+		pending  = make(map[staking.Address][]*proposalTx)
+		queue    = make(proposalQueue, 0, len(ptxs))
+	)
+	for _, ptx := range ptxs {
+		var drop bool
+		for _, acct := range ptx.accounts {
+			if excluded[acct] || (maxTxsPerSigner > 0 && counts[acct] >= maxTxsPerSigner) {
+				drop = true
+				break
+			}
+		}
+		if drop {
+			for _, acct := range ptx.accounts {
+				excluded[acct] = true
+			}
+			continue
+		}
+
+		for _, acct := range ptx.accounts {
+			counts[acct]++
+			if len(pending[acct]) > 0 {
+				ptx.waiting++
+			}
+			pending[acct] = append(pending[acct], ptx)
+		}
+		if ptx.waiting == 0 {
+			queue = append(queue, ptx)
+		}
+	}
+	heap.Init(&queue)
+
+	// Accounts with transactions dropped during selection.
+	blocked := make(map[staking.Address]bool)
+
+	// release removes the given transaction from the account queues and queues any transactions
+	// that no longer wait for earlier ones. In case the transaction has been dropped, all later
+	// transactions of its accounts are dropped as well.
+	var release func(ptx *proposalTx, dropped bool)
+	release = func(ptx *proposalTx, dropped bool) {
+		for _, acct := range ptx.accounts {
+			if dropped {
+				blocked[acct] = true
+			}
+			pending[acct] = pending[acct][1:]
+		}
+		for _, acct := range ptx.accounts {
+			if len(pending[acct]) == 0 {
+				continue
+			}
+			next := pending[acct][0]
+			if next.waiting--; next.waiting > 0 {
+				continue
+			}
+
+			var drop bool
+			for _, nextAcct := range next.accounts {
+				drop = drop || blocked[nextAcct]
+			}
+			if drop {
+				release(next, true)
+				continue
+			}
+			heap.Push(&queue, next)
+		}
+	}
+
+	// Select transactions in order of their gas price.
+	txs := make([][]byte, 0, len(ptxs))
+	var totalBytes int64
+	for queue.Len() > 0 {
+		ptx := heap.Pop(&queue).(*proposalTx)
+
+		if totalBytes+int64(len(ptx.raw)) > maxTxBytes {
+			// Transaction does not fit, skip it together with all transactions depending on it.
+			release(ptx, true)
+			continue
+		}
+		totalBytes += int64(len(ptx.raw))
+		txs = append(txs, ptx.raw)
+
+		release(ptx, false)
+	}
+
+	return txs, skipped
+}
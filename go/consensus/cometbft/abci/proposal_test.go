@@ -0,0 +1,91 @@
+package abci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+func TestOrderProposalTxs(t *testing.T) {
+	require := require.New(t)
+
+	signature.UnsafeResetChainContext()
+	defer signature.UnsafeResetChainContext()
+	signature.SetChainContext("test: oasis-core proposal tests")
+
+	signer1 := memorySigner.NewTestSigner("consensus/cometbft/abci: proposal test signer 1")
+	signer2 := memorySigner.NewTestSigner("consensus/cometbft/abci: proposal test signer 2")
+	signer3 := memorySigner.NewTestSigner("consensus/cometbft/abci: proposal test signer 3")
+	signer4 := memorySigner.NewTestSigner("consensus/cometbft/abci: proposal test signer 4")
+	method := transaction.NewMethodName("test", "Proposal", nil)
+
+	newTx := func(signer signature.Signer, nonce uint64, gasPrice uint64, feePayer signature.Signer) []byte {
+		tx := transaction.NewTransaction(nonce, &transaction.Fee{
+			Amount: *quantity.NewFromUint64(gasPrice * 1000),
+			Gas:    1000,
+		}, method, nil)
+		if feePayer != nil {
+			tx.FeePayer = &transaction.FeePayer{PublicKey: feePayer.Public()}
+		}
+		sigTx, err := transaction.Sign(signer, tx)
+		require.NoError(err, "Sign")
+		return cbor.Marshal(sigTx)
+	}
+
+	// Transactions should be ordered by gas price.
+	a := newTx(signer1, 0, 1, nil)
+	b := newTx(signer2, 0, 3, nil)
+	c := newTx(signer3, 0, 2, nil)
+	txs, skipped := orderProposalTxs([][]byte{a, b, c}, 1<<20, 0)
+	require.Equal(0, skipped)
+	require.Equal([][]byte{b, c, a}, txs, "transactions should be ordered by gas price")
+
+	// Transactions with the same gas price should keep arrival order.
+	d := newTx(signer3, 0, 1, nil)
+	txs, _ = orderProposalTxs([][]byte{d, a}, 1<<20, 0)
+	require.Equal([][]byte{d, a}, txs, "transactions with the same gas price should keep arrival order")
+
+	// Transactions from the same signer should keep nonce order.
+	a0 := newTx(signer1, 0, 1, nil)
+	a1 := newTx(signer1, 1, 10, nil)
+	txs, _ = orderProposalTxs([][]byte{a0, a1, b}, 1<<20, 0)
+	require.Equal([][]byte{b, a0, a1}, txs, "transactions from the same signer should keep nonce order")
+
+	// Transactions sharing a fee payer should keep their relative order.
+	p0 := newTx(signer2, 0, 1, signer1)
+	p1 := newTx(signer3, 0, 10, signer1)
+	txs, _ = orderProposalTxs([][]byte{p0, p1}, 1<<20, 0)
+	require.Equal([][]byte{p0, p1}, txs, "transactions sharing a fee payer should keep their order")
+
+	// Number of transactions per signer should be limited.
+	a2 := newTx(signer1, 2, 10, nil)
+	txs, _ = orderProposalTxs([][]byte{a0, a1, a2, c}, 1<<20, 2)
+	require.Equal([][]byte{c, a0, a1}, txs, "transactions per signer should be limited")
+
+	// Number of transactions should be limited per account even when accounts share a sponsor.
+	b0 := newTx(signer2, 0, 1, signer1)
+	c0 := newTx(signer3, 0, 10, signer1)
+	b1 := newTx(signer2, 1, 5, nil)
+	c1 := newTx(signer3, 1, 3, nil)
+	b2 := newTx(signer2, 2, 20, nil)
+	e0 := newTx(signer4, 0, 20, signer1)
+	txs, _ = orderProposalTxs([][]byte{b0, c0, b1, c1, b2, e0}, 1<<20, 2)
+	require.Equal([][]byte{b0, c0, b1, c1}, txs, "transactions per account should be limited")
+
+	// Total size should be limited.
+	txs, _ = orderProposalTxs([][]byte{a, b, c}, int64(len(b)+len(c)), 0)
+	require.Equal([][]byte{b, c}, txs, "total size should be limited")
+	txs, _ = orderProposalTxs([][]byte{a0, a1, b}, int64(len(b)+len(a0)), 0)
+	require.Equal([][]byte{b, a0}, txs, "total size should be limited")
+
+	// Malformed transactions should be skipped.
+	txs, skipped = orderProposalTxs([][]byte{a, []byte("malformed"), b}, 1<<20, 0)
+	require.Equal(1, skipped)
+	require.Equal([][]byte{b, a}, txs, "malformed transactions should be skipped")
+}
@@ -16,9 +16,7 @@ import (
 
 func (mux *abciMux) ListSnapshots(types.RequestListSnapshots) types.ResponseListSnapshots {
 	// Get a list of all current checkpoints.
-	cps, err := mux.state.storage.Checkpointer().GetCheckpoints(mux.state.ctx, &checkpoint.GetCheckpointsRequest{
-		Version: 1,
-	})
+	cps, err := checkpoint.GetCheckpointsAllVersions(mux.state.ctx, mux.state.storage.Checkpointer(), &checkpoint.GetCheckpointsRequest{})
 	if err != nil {
 		mux.logger.Error("failed to get checkpoints",
 			"err", err,
@@ -46,7 +44,7 @@ func (mux *abciMux) OfferSnapshot(req types.RequestOfferSnapshot) types.Response
 	if req.Snapshot == nil {
 		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_REJECT}
 	}
-	if req.Snapshot.Format != 1 {
+	if req.Snapshot.Format < checkpoint.MinimumVersion || req.Snapshot.Format > checkpoint.LatestVersion {
 		mux.logger.Warn("received snapshot with unsupported version",
 			"version", req.Snapshot.Format,
 		)
@@ -80,6 +78,21 @@ func (mux *abciMux) OfferSnapshot(req types.RequestOfferSnapshot) types.Response
 		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_REJECT}
 	}
 
+	// Checkpoint format must match.
+	if uint32(cp.Version) != req.Snapshot.Format {
+		mux.logger.Warn("received snapshot with mismatching format",
+			"expected_format", req.Snapshot.Format,
+			"format", cp.Version,
+		)
+		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_REJECT}
+	}
+	if err := cp.ValidateFormat(); err != nil {
+		mux.logger.Warn("received snapshot with unsupported format",
+			"err", err,
+		)
+		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_REJECT_FORMAT}
+	}
+
 	// Number of chunks must match.
 	if int(req.Snapshot.Chunks) != len(cp.Chunks) {
 		mux.logger.Warn("received snapshot with mismatching number of chunks",
@@ -765,9 +765,11 @@ func newApplicationState(ctx context.Context, upgrader upgrade.Backend, cfg *App
 	// Initialize the checkpointer.
 	if !cfg.DisableCheckpointer {
 		checkpointerCfg := checkpoint.CheckpointerConfig{
-			Name:            "consensus",
-			CheckInterval:   cfg.CheckpointerCheckInterval,
-			RootsPerVersion: 1,
+			Name:                "consensus",
+			CheckInterval:       cfg.CheckpointerCheckInterval,
+			RootsPerVersion:     1,
+			ChunkCodec:          cfg.CheckpointerChunkCodec,
+			MaxDeltaCheckpoints: cfg.CheckpointerMaxDeltaCheckpoints,
 			GetParameters: func(_ context.Context) (*checkpoint.CreationParameters, error) {
 				params := s.ConsensusParameters()
 				return &checkpoint.CreationParameters{
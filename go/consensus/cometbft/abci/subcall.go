@@ -5,6 +5,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/api"
 )
 
 // maxSubcallDepth is the maximum subcall depth.
@@ -20,6 +21,13 @@ func (mux *abciMux) ExecuteMessage(ctx *api.Context, kind, msg interface{}) (int
 			return nil, fmt.Errorf("invalid subcall info")
 		}
 		return struct{}{}, mux.executeSubcall(ctx, info)
+	case governanceApi.MessageValidateParameterChanges:
+		// A change parameters proposal is about to be submitted. Validate changes.
+		return mux.changeParameters(ctx, msg, false)
+	case governanceApi.MessageChangeParameters:
+		// A change parameters proposal has just been accepted and closed. Validate and apply
+		// changes.
+		return mux.changeParameters(ctx, msg, true)
 	default:
 		return nil, nil
 	}
@@ -60,6 +60,10 @@ func (mux *abciMux) prepareSystemTxs() ([][]byte, []*types.ResponseDeliverTx, er
 
 // processSystemTx processes a system transaction in DeliverTx context.
 func (mux *abciMux) processSystemTx(ctx *api.Context, tx *transaction.Transaction) error {
+	// System transactions do not pay fees, so they cannot have a fee payer either.
+	if tx.FeePayer != nil {
+		return fmt.Errorf("%w: system transactions cannot have a fee payer", transaction.ErrInvalidFeePayer)
+	}
 	if ctx.Mode() != api.ContextDeliverTx {
 		return fmt.Errorf("system methods are not allowed to be called")
 	}
@@ -73,8 +77,8 @@ func (mux *abciMux) processSystemTx(ctx *api.Context, tx *transaction.Transactio
 		panic(fmt.Errorf("system transaction included during proposal phase"))
 	}
 
-	// Nonce must be zero and fee must be nil.
-	if tx.Nonce != 0 || tx.Fee != nil {
+	// Nonce must be zero, fee and validity bound must be nil.
+	if tx.Nonce != 0 || tx.Fee != nil || tx.ValidUntil != nil {
 		panic(fmt.Errorf("malformed system transaction in block"))
 	}
 	// Transaction must be signed by the proposer.
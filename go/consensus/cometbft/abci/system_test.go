@@ -0,0 +1,58 @@
+package abci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+)
+
+func TestProcessSystemTxFeePayer(t *testing.T) {
+	require := require.New(t)
+
+	mux := &abciMux{}
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	sponsor := memorySigner.NewTestSigner("consensus/cometbft/abci: system tx sponsor")
+
+	for _, mode := range []api.ContextMode{api.ContextCheckTx, api.ContextDeliverTx} {
+		ctx := appState.NewContext(mode)
+
+		tx := transaction.NewTransaction(0, nil, consensus.MethodMeta, &consensus.BlockMetadata{})
+		tx.FeePayer = &transaction.FeePayer{PublicKey: sponsor.Public()}
+		err := mux.processSystemTx(ctx, tx)
+		require.ErrorIs(err, transaction.ErrInvalidFeePayer, "system transactions with a fee payer should be rejected")
+
+		ctx.Close()
+	}
+}
+
+type testCriticalBody struct{}
+
+func (testCriticalBody) MethodMetadata() transaction.MethodMetadata {
+	return transaction.MethodMetadata{Priority: transaction.MethodPriorityCritical}
+}
+
+func TestProcessCriticalTxFeePayer(t *testing.T) {
+	require := require.New(t)
+
+	methodCritical := transaction.NewMethodName("test", "Critical", testCriticalBody{})
+
+	mux := &abciMux{}
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	sponsor := memorySigner.NewTestSigner("consensus/cometbft/abci: critical tx sponsor")
+
+	for _, mode := range []api.ContextMode{api.ContextCheckTx, api.ContextDeliverTx} {
+		ctx := appState.NewContext(mode)
+
+		tx := transaction.NewTransaction(0, nil, methodCritical, nil)
+		tx.FeePayer = &transaction.FeePayer{PublicKey: sponsor.Public()}
+		err := mux.processTx(ctx, tx, 0)
+		require.ErrorIs(err, transaction.ErrInvalidFeePayer, "critical transactions with a fee payer should be rejected")
+
+		ctx.Close()
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -12,13 +13,18 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
-func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Transaction, *transaction.SignedTransaction, error) {
+// decodeTx decodes and authenticates the given raw transaction and sets the authenticated
+// transaction caller in the given context.
+//
+// The transaction may either be a regular signed transaction or a multisig transaction.
+func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Transaction, error) {
 	params := mux.state.ConsensusParameters()
 	if params == nil {
 		ctx.Logger().Debug("decodeTx: state not yet initialized")
-		return nil, nil, consensus.ErrNoCommittedBlocks
+		return nil, consensus.ErrNoCommittedBlocks
 	}
 
 	if params.MaxTxSize > 0 && uint64(len(rawTx)) > params.MaxTxSize {
@@ -27,32 +33,49 @@ func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Trans
 		ctx.Logger().Debug("received oversized transaction",
 			"tx_size", len(rawTx),
 		)
-		return nil, nil, consensus.ErrOversizedTx
+		return nil, consensus.ErrOversizedTx
 	}
 
 	// Unmarshal envelope and verify transaction.
-	var sigTx transaction.SignedTransaction
-	if err := cbor.Unmarshal(rawTx, &sigTx); err != nil {
-		ctx.Logger().Debug("failed to unmarshal signed transaction",
-			"tx", base64.StdEncoding.EncodeToString(rawTx),
-		)
-		return nil, nil, err
-	}
-	var tx transaction.Transaction
-	if err := sigTx.Open(&tx); err != nil {
-		ctx.Logger().Debug("failed to verify transaction signature",
-			"tx", base64.StdEncoding.EncodeToString(rawTx),
-		)
-		return nil, nil, err
+	var (
+		tx    transaction.Transaction
+		sigTx transaction.SignedTransaction
+		msTx  transaction.MultiSignedTransaction
+	)
+	switch err := cbor.Unmarshal(rawTx, &sigTx); err {
+	case nil:
+		if err = sigTx.Open(&tx); err != nil {
+			ctx.Logger().Debug("failed to verify transaction signature",
+				"tx", base64.StdEncoding.EncodeToString(rawTx),
+			)
+			return nil, err
+		}
+		ctx.SetTxSigner(sigTx.Signature.PublicKey)
+	default:
+		// Not a regular signed transaction, try a multisig transaction.
+		if msErr := cbor.Unmarshal(rawTx, &msTx); msErr != nil {
+			ctx.Logger().Debug("failed to unmarshal signed transaction",
+				"tx", base64.StdEncoding.EncodeToString(rawTx),
+			)
+			return nil, err
+		}
+		if err = msTx.Open(&tx); err != nil {
+			ctx.Logger().Debug("failed to verify multisig transaction signatures",
+				"tx", base64.StdEncoding.EncodeToString(rawTx),
+				"err", err,
+			)
+			return nil, err
+		}
+		ctx.SetTxMultisigCaller(staking.NewMultisigAddress(&msTx.Config))
 	}
 	if err := tx.SanityCheck(); err != nil {
 		ctx.Logger().Debug("bad transaction",
 			"tx", base64.StdEncoding.EncodeToString(rawTx),
 		)
-		return nil, nil, err
+		return nil, err
 	}
 
-	return &tx, &sigTx, nil
+	return &tx, nil
 }
 
 func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txSize int) error {
@@ -61,8 +84,28 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 		return mux.processSystemTx(ctx, tx)
 	}
 
-	// Lookup method handler.
-	app, err := mux.resolveAppForMethod(ctx, tx.Method)
+	// Critical protocol methods do not pay fees, so they cannot have a fee payer either.
+	if tx.Method.IsCritical() && tx.FeePayer != nil {
+		return fmt.Errorf("%w: critical methods cannot have a fee payer", transaction.ErrInvalidFeePayer)
+	}
+
+	// Reject transactions that are past their validity bound.
+	if err := mux.checkTxValidUntil(ctx, tx); err != nil {
+		return err
+	}
+
+	// Lookup method handler. Batches are handled by the multiplexer itself.
+	var (
+		app   api.Application
+		batch *consensus.Batch
+		err   error
+	)
+	switch tx.Method {
+	case consensus.MethodBatch:
+		batch, err = mux.decodeBatch(ctx, tx)
+	default:
+		app, err = mux.resolveAppForMethod(ctx, tx.Method)
+	}
 	if err != nil {
 		return err
 	}
@@ -100,13 +143,25 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 	}
 
 	// Route to correct handler.
-	ctx.Logger().Debug("dispatching",
-		"app", app.Name(),
-		"tx", tx,
-	)
+	switch batch {
+	case nil:
+		ctx.Logger().Debug("dispatching",
+			"app", app.Name(),
+			"tx", tx,
+		)
 
-	if err := app.ExecuteTx(ctx, tx); err != nil {
-		return err
+		if err := app.ExecuteTx(ctx, tx); err != nil {
+			return err
+		}
+	default:
+		ctx.Logger().Debug("dispatching batch",
+			"tx", tx,
+			"num_calls", len(batch.Calls),
+		)
+
+		if err := mux.executeBatch(ctx, batch); err != nil {
+			return err
+		}
 	}
 
 	//  Pass the transaction through the PostExecuteTx handler if configured.
@@ -125,15 +180,45 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 	return nil
 }
 
+// checkTxValidUntil makes sure that the transaction can still be executed in the current block.
+//
+// This is also performed when re-checking transactions in the mempool so that expired transactions
+// are evicted.
+func (mux *abciMux) checkTxValidUntil(ctx *api.Context, tx *transaction.Transaction) error {
+	if tx.ValidUntil == nil {
+		return nil
+	}
+
+	// Transactions are executed in the block following the last committed one.
+	height := ctx.BlockHeight() + 1
+
+	var epoch beacon.EpochTime
+	if tx.ValidUntil.Epoch > 0 {
+		// Make sure to take into account any epoch transition pending for the block in which the
+		// transaction is executed.
+		var err error
+		if epoch, err = mux.state.GetCurrentEpoch(ctx); err != nil {
+			return fmt.Errorf("failed to get epoch: %w", err)
+		}
+	}
+
+	if tx.ValidUntil.IsExpired(height, uint64(epoch)) {
+		ctx.Logger().Debug("transaction expired",
+			"tx", tx,
+			"height", height,
+			"epoch", epoch,
+		)
+		return transaction.ErrExpired
+	}
+	return nil
+}
+
 func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte) error {
-	tx, sigTx, err := mux.decodeTx(ctx, rawTx)
+	tx, err := mux.decodeTx(ctx, rawTx)
 	if err != nil {
 		return err
 	}
 
-	// Set authenticated transaction signer.
-	ctx.SetTxSigner(sigTx.Signature.PublicKey)
-
 	// If we are in CheckTx mode and there is a pending upgrade in this block, make sure to reject
 	// any transactions before processing as they may potentially query incompatible state.
 	if upgrader := mux.state.Upgrader(); upgrader != nil && ctx.IsCheckOnly() {
@@ -180,6 +265,9 @@ func (mux *abciMux) EstimateGas(caller signature.PublicKey, tx *transaction.Tran
 			// Signature is fixed-size, so we can leave it as default.
 		},
 	}
+	if tx.FeePayer != nil {
+		mockSignedTx.FeePayerSignature = &signature.Signature{}
+	}
 	txSize := len(cbor.Marshal(mockSignedTx))
 
 	// Ignore any errors that occurred during simulation as we only need to estimate gas even if the
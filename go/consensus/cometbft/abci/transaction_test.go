@@ -0,0 +1,68 @@
+package abci
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+// testTimeSource is a time source with an optional epoch transition scheduled for the future.
+type testTimeSource struct {
+	beacon.Backend
+
+	epoch  beacon.EpochTime
+	future *beacon.EpochTimeState
+}
+
+func (ts *testTimeSource) GetEpoch(context.Context, int64) (beacon.EpochTime, error) {
+	return ts.epoch, nil
+}
+
+func (ts *testTimeSource) GetFutureEpoch(context.Context, int64) (*beacon.EpochTimeState, error) {
+	return ts.future, nil
+}
+
+func TestCheckTxValidUntil(t *testing.T) {
+	require := require.New(t)
+
+	const height = 10
+
+	ts := &testTimeSource{epoch: 4}
+	mux := &abciMux{
+		state: &applicationState{
+			stateRoot:  storage.Root{Version: height},
+			timeSource: ts,
+		},
+	}
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{
+		BlockHeight: height,
+	})
+	ctx := appState.NewContext(api.ContextCheckTx)
+	defer ctx.Close()
+
+	checkTx := func(validUntil transaction.ValidUntil) error {
+		return mux.checkTxValidUntil(ctx, &transaction.Transaction{ValidUntil: &validUntil})
+	}
+
+	// Transactions are executed in the next block.
+	require.NoError(checkTx(transaction.ValidUntil{Height: height + 1}))
+	require.ErrorIs(checkTx(transaction.ValidUntil{Height: height}), transaction.ErrExpired)
+
+	require.NoError(checkTx(transaction.ValidUntil{Epoch: 4}))
+	require.ErrorIs(checkTx(transaction.ValidUntil{Epoch: 3}), transaction.ErrExpired)
+
+	// An epoch transition scheduled for a later block should not be taken into account.
+	ts.future = &beacon.EpochTimeState{Epoch: 5, Height: height + 2}
+	require.NoError(checkTx(transaction.ValidUntil{Epoch: 4}))
+
+	// An epoch transition pending for the next block should be taken into account.
+	ts.future = &beacon.EpochTimeState{Epoch: 5, Height: height + 1}
+	require.ErrorIs(checkTx(transaction.ValidUntil{Epoch: 4}), transaction.ErrExpired, "transaction should expire at the epoch boundary")
+	require.NoError(checkTx(transaction.ValidUntil{Epoch: 5}))
+}
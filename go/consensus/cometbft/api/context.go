@@ -202,6 +202,25 @@ func (c *Context) SetTxSigner(txSigner signature.PublicKey) {
 	}
 }
 
+// SetTxMultisigCaller sets the authenticated multisig account as the transaction caller.
+//
+// Multisig transactions have no single signer so the transaction signer is left empty and any
+// methods that authorize based on the transaction signer will reject such transactions.
+//
+// This must only be done after verifying the multisig transaction signatures.
+//
+// In case the method is called on a non-transaction context, this method
+// will panic.
+func (c *Context) SetTxMultisigCaller(addr staking.Address) {
+	switch c.mode {
+	case ContextCheckTx, ContextDeliverTx, ContextSimulateTx:
+		c.txSigner = signature.PublicKey{}
+		c.callerAddress = addr
+	default:
+		panic("context: only available in transaction context")
+	}
+}
+
 // CallerAddress returns the authenticated address representing the caller.
 func (c *Context) CallerAddress() staking.Address {
 	return c.callerAddress
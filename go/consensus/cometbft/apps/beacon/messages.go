@@ -0,0 +1,55 @@
+package beacon
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+)
+
+// immutableParameters are the consensus parameters that cannot be changed via parameters patches.
+var immutableParameters = []string{
+	"backend",
+	"debug_mock_backend",
+	"insecure_parameters",
+}
+
+func (app *beaconApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
+	// Unmarshal changes and check if they should be applied to this module.
+	proposal, ok := msg.(*governance.ChangeParametersProposal)
+	if !ok {
+		return nil, fmt.Errorf("beacon: failed to type assert change parameters proposal")
+	}
+
+	if proposal.Module != beacon.ModuleName {
+		return nil, nil
+	}
+
+	// Validate changes against current parameters. The beacon does not define module-specific
+	// changes so only generic parameter patches are supported.
+	state := beaconState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beacon: failed to load consensus parameters: %w", err)
+	}
+	prevInterval := params.Interval()
+	if err = governance.ApplyChangeParametersProposal(proposal, params, nil, immutableParameters); err != nil {
+		return nil, fmt.Errorf("beacon: %w", err)
+	}
+	// Changing the epoch interval would shift the epoch schedule.
+	if params.Interval() != prevInterval {
+		return nil, fmt.Errorf("beacon: epoch interval cannot be changed")
+	}
+
+	// Apply changes.
+	if apply {
+		if err = state.SetConsensusParameters(ctx, params); err != nil {
+			return nil, fmt.Errorf("beacon: failed to update consensus parameters: %w", err)
+		}
+	}
+
+	// Non-nil response signals that changes are valid and were successfully applied (if required).
+	return struct{}{}, nil
+}
@@ -49,6 +49,13 @@ func (app *governanceApplication) InitChain(ctx *abciAPI.Context, _ types.Reques
 		}
 	}
 
+	// Insert proxy voter assignments.
+	for delegator, vd := range st.VoteDelegates {
+		if err = state.SetVoteDelegate(ctx, delegator, vd); err != nil {
+			return fmt.Errorf("cometbft/governance: failed to set vote delegate: %w", err)
+		}
+	}
+
 	// Compute pending upgrades from proposals.
 	upgrades, ids := governance.PendingUpgradesFromProposals(st.Proposals, epoch)
 	for i, up := range upgrades {
@@ -86,9 +93,15 @@ func (gq *governanceQuerier) Genesis(ctx context.Context) (*governance.Genesis,
 		voteEntries[proposal.ID] = votes
 	}
 
+	voteDelegates, err := gq.state.VoteDelegates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &governance.Genesis{
-		Parameters:  *params,
-		Proposals:   proposals,
-		VoteEntries: voteEntries,
+		Parameters:    *params,
+		Proposals:     proposals,
+		VoteEntries:   voteEntries,
+		VoteDelegates: voteDelegates,
 	}, nil
 }
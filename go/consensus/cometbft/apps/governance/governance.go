@@ -94,6 +94,15 @@ func (app *governanceApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tr
 			return governance.ErrInvalidArgument
 		}
 		return app.castVote(ctx, state, &proposalVote)
+	case governance.MethodSetVoteDelegate:
+		var voteDelegate governance.VoteDelegate
+		if err := cbor.Unmarshal(tx.Body, &voteDelegate); err != nil {
+			ctx.Logger().Debug("governance: failed to unmarshal vote delegate",
+				"err", err,
+			)
+			return governance.ErrInvalidArgument
+		}
+		return app.setVoteDelegate(ctx, state, &voteDelegate)
 	default:
 		return governance.ErrInvalidArgument
 	}
@@ -398,16 +407,17 @@ func (app *governanceApplication) closeProposal(
 		}
 	}
 
-	// Tally delegator votes.
-	for _, vote := range votes {
+	// applyDelegatorVote overrides the validator votes for all of the delegator's shares delegated
+	// to validators with the given vote. Returns true iff the delegator delegates to any validator.
+	applyDelegatorVote := func(delegator stakingAPI.Address, vote governance.Vote) (bool, error) {
 		// Fetch outgoing delegations.
-		delegations, err := stakingState.DelegationsFor(ctx, vote.Voter)
+		delegations, err := stakingState.DelegationsFor(ctx, delegator)
 		if err != nil {
 			ctx.Logger().Error("failed to fetch delegations for",
-				"delegator", vote.Voter,
+				"delegator", delegator,
 				"err", err,
 			)
-			return fmt.Errorf("failed to fetch delegations: %w", err)
+			return false, fmt.Errorf("failed to fetch delegations: %w", err)
 		}
 		var delegationToValidator bool
 		for to, delegation := range delegations {
@@ -418,23 +428,64 @@ func (app *governanceApplication) closeProposal(
 			validatorVote := validatorVotes[to]
 
 			// Skip if vote matches the delegated validator vote.
-			if validatorVote != nil && *validatorVote == vote.Vote {
+			if validatorVote != nil && *validatorVote == vote {
 				continue
 			}
 
 			// Deduct shares from the validators shares.
 			if validatorVote != nil {
 				if err := subShares(validatorVoteShares[to], *validatorVote, delegation.Shares); err != nil {
-					return fmt.Errorf("failed to sub votes: %w", err)
+					return false, fmt.Errorf("failed to sub votes: %w", err)
 				}
 			}
 
 			// Add shares to the voters vote.
-			if err := addShares(validatorVoteShares[to], vote.Vote, delegation.Shares); err != nil {
-				return fmt.Errorf("failed to add votes: %w", err)
+			if err := addShares(validatorVoteShares[to], vote, delegation.Shares); err != nil {
+				return false, fmt.Errorf("failed to add votes: %w", err)
 			}
 		}
-		if !delegationToValidator {
+		return delegationToValidator, nil
+	}
+
+	voters := make(map[stakingAPI.Address]struct{}, len(votes))
+	for _, vote := range votes {
+		voters[vote.Voter] = struct{}{}
+	}
+
+	// Tally delegator votes. The precedence is: own vote > proxy vote > validator vote.
+	for _, vote := range votes {
+		valid, err := applyDelegatorVote(vote.Voter, vote.Vote)
+		if err != nil {
+			return err
+		}
+
+		// Apply the vote to the shares of delegators that assigned the voter as their proxy voter
+		// and did not vote themselves.
+		if params.AllowVoteDelegation {
+			delegators, err := state.VoteDelegators(ctx, vote.Voter)
+			if err != nil {
+				return fmt.Errorf("failed to fetch vote delegators: %w", err)
+			}
+			for _, delegator := range delegators {
+				if _, ok := voters[delegator]; ok {
+					continue
+				}
+				vd, err := state.VoteDelegate(ctx, delegator)
+				if err != nil {
+					return fmt.Errorf("failed to fetch vote delegate: %w", err)
+				}
+				if vd == nil || !vd.AppliesTo(proposal.Content.Kind()) {
+					continue
+				}
+				delegationToValidator, err := applyDelegatorVote(delegator, vote.Vote)
+				if err != nil {
+					return err
+				}
+				valid = valid || delegationToValidator
+			}
+		}
+
+		if !valid {
 			proposal.InvalidVotes++
 		}
 	}
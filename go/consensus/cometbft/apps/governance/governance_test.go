@@ -448,6 +448,163 @@ func TestCloseProposal(t *testing.T) {
 	}
 }
 
+func TestCloseProposalVoteDelegation(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup staking state.
+	stakingState := stakingState.NewMutableState(ctx.State())
+	addr1 := staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr2 := staking.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr3 := staking.NewAddress(signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr4 := staking.NewAddress(signature.NewPublicKey("dddfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	// Validator addr1 with delegators addr2 and addr3, addr4 is a proxy voter without any stake.
+	require.NoError(stakingState.SetDelegation(ctx, addr1, addr1, &staking.Delegation{Shares: *quantity.NewFromUint64(40)}))
+	require.NoError(stakingState.SetDelegation(ctx, addr2, addr1, &staking.Delegation{Shares: *quantity.NewFromUint64(30)}))
+	require.NoError(stakingState.SetDelegation(ctx, addr3, addr1, &staking.Delegation{Shares: *quantity.NewFromUint64(30)}))
+	validatorEntitiesEscrow := map[staking.Address]*staking.SharePool{
+		addr1: {
+			Balance:     *quantity.NewFromUint64(100),
+			TotalShares: *quantity.NewFromUint64(100),
+		},
+	}
+
+	// Setup governance state.
+	state := governanceState.NewMutableState(ctx.State())
+	app := &governanceApplication{
+		state: appState,
+	}
+
+	// addr2 delegates votes to addr4 for all proposals, addr3 only for upgrade proposals.
+	require.NoError(state.SetVoteDelegate(ctx, addr2, &governance.VoteDelegate{Delegate: &addr4}))
+	require.NoError(state.SetVoteDelegate(ctx, addr3, &governance.VoteDelegate{
+		Delegate: &addr4,
+		Kinds:    []governance.ProposalKind{governance.ProposalKindUpgrade},
+	}))
+
+	baseConsParams := &governance.ConsensusParameters{
+		GasCosts:            governance.DefaultGasCosts,
+		MinProposalDeposit:  *quantity.NewFromUint64(100),
+		StakeThreshold:      60,
+		VotingPeriod:        beacon.EpochTime(50),
+		AllowVoteDelegation: true,
+	}
+	disabledConsParams := *baseConsParams
+	disabledConsParams.AllowVoteDelegation = false
+
+	upgradeContent := governance.ProposalContent{Upgrade: &governance.UpgradeProposal{}}
+	changeParametersContent := governance.ProposalContent{ChangeParameters: &governance.ChangeParametersProposal{}}
+
+	for i, tc := range []struct {
+		msg                  string
+		params               *governance.ConsensusParameters
+		content              governance.ProposalContent
+		votes                []*governance.VoteEntry
+		expectedState        governance.ProposalState
+		expectedInvalidVotes uint64
+		expectedResults      map[governance.Vote]quantity.Quantity
+	}{
+		{
+			"proxy vote should override validator vote",
+			baseConsParams,
+			upgradeContent,
+			[]*governance.VoteEntry{
+				{Voter: addr1, Vote: governance.VoteNo},
+				{Voter: addr4, Vote: governance.VoteYes},
+			},
+			governance.StatePassed,
+			0,
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteNo:  *quantity.NewFromUint64(40),
+				governance.VoteYes: *quantity.NewFromUint64(60), // addr2 + addr3 via addr4.
+			},
+		},
+		{
+			"own vote should override proxy vote",
+			baseConsParams,
+			upgradeContent,
+			[]*governance.VoteEntry{
+				{Voter: addr1, Vote: governance.VoteNo},
+				{Voter: addr3, Vote: governance.VoteAbstain},
+				{Voter: addr4, Vote: governance.VoteYes},
+			},
+			governance.StateRejected,
+			0,
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteNo:      *quantity.NewFromUint64(40),
+				governance.VoteYes:     *quantity.NewFromUint64(30), // addr2 via addr4.
+				governance.VoteAbstain: *quantity.NewFromUint64(30),
+			},
+		},
+		{
+			"proxy vote should respect proposal kinds",
+			baseConsParams,
+			changeParametersContent,
+			[]*governance.VoteEntry{
+				{Voter: addr1, Vote: governance.VoteNo},
+				{Voter: addr4, Vote: governance.VoteYes},
+			},
+			governance.StateRejected,
+			0,
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteNo:  *quantity.NewFromUint64(70), // addr1 + addr3.
+				governance.VoteYes: *quantity.NewFromUint64(30), // addr2 via addr4.
+			},
+		},
+		{
+			"proxy vote should work if validator doesn't vote",
+			baseConsParams,
+			changeParametersContent,
+			[]*governance.VoteEntry{
+				{Voter: addr4, Vote: governance.VoteYes},
+			},
+			governance.StateRejected,
+			0,
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteYes: *quantity.NewFromUint64(30), // addr2 via addr4.
+			},
+		},
+		{
+			"proxy vote should be ignored if vote delegation is disabled",
+			&disabledConsParams,
+			upgradeContent,
+			[]*governance.VoteEntry{
+				{Voter: addr1, Vote: governance.VoteNo},
+				{Voter: addr4, Vote: governance.VoteYes},
+			},
+			governance.StateRejected,
+			1, // addr4 has no delegations.
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteNo: *quantity.NewFromUint64(100),
+			},
+		},
+	} {
+		err := state.SetConsensusParameters(ctx, tc.params)
+		require.NoError(err, "setting governance consensus parameters should not error")
+
+		proposal := &governance.Proposal{
+			ID:      uint64(i + 1),
+			State:   governance.StateActive,
+			Content: tc.content,
+		}
+		for _, vote := range tc.votes {
+			err = state.SetVote(ctx, proposal.ID, vote.Voter, vote.Vote)
+			require.NoError(err, "SetVote()")
+		}
+
+		err = app.closeProposal(ctx, state, stakingState.ImmutableState, *quantity.NewFromUint64(100), validatorEntitiesEscrow, proposal)
+		require.NoError(err, tc.msg)
+
+		require.EqualValues(tc.expectedState, proposal.State, tc.msg)
+		require.EqualValues(tc.expectedInvalidVotes, proposal.InvalidVotes, tc.msg)
+		require.EqualValues(tc.expectedResults, proposal.Results, tc.msg)
+	}
+}
+
 func TestExecuteProposal(t *testing.T) {
 	require := require.New(t)
 	var err error
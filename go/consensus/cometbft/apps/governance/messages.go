@@ -3,7 +3,6 @@ package governance
 import (
 	"fmt"
 
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
@@ -47,25 +46,15 @@ func (app *governanceApplication) changeParameters(ctx *api.Context, msg interfa
 		return nil, nil
 	}
 
-	var changes governance.ConsensusParameterChanges
-	if err := cbor.Unmarshal(proposal.Changes, &changes); err != nil {
-		return nil, fmt.Errorf("cometbft/governance: failed to unmarshal consensus parameter changes: %w", err)
-	}
-
 	// Validate changes against current parameters.
 	state := governanceState.NewMutableState(ctx.State())
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cometbft/governance: failed to load consensus parameters: %w", err)
 	}
-	if err = changes.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("cometbft/governance: failed to validate consensus parameter changes: %w", err)
-	}
-	if err = changes.Apply(params); err != nil {
-		return nil, fmt.Errorf("cometbft/governance: failed to apply consensus parameter changes: %w", err)
-	}
-	if err = params.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("cometbft/governance: failed to validate consensus parameters: %w", err)
+	var changes governance.ConsensusParameterChanges
+	if err = governance.ApplyChangeParametersProposal(proposal, params, &changes, nil); err != nil {
+		return nil, fmt.Errorf("cometbft/governance: %w", err)
 	}
 
 	// Apply changes.
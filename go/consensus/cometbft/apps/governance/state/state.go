@@ -47,6 +47,18 @@ var (
 	// Key format is: 0x85.
 	// Value is CBOR-serialized governance.ConsensusParameters.
 	parametersKeyFmt = consensus.KeyFormat.New(0x85)
+
+	// voteDelegatesKeyFmt is the key format used for proxy voter assignments.
+	//
+	// Key format is: 0x86 <delegator-address (staking.Address)>.
+	// Value is CBOR-serialized governance.VoteDelegate.
+	voteDelegatesKeyFmt = consensus.KeyFormat.New(0x86, &staking.Address{})
+
+	// voteDelegatorsKeyFmt is the key format used for indexing proxy voter assignments by
+	// delegate.
+	//
+	// Key format is: 0x87 <delegate-address (staking.Address)> <delegator-address (staking.Address)>.
+	voteDelegatorsKeyFmt = consensus.KeyFormat.New(0x87, &staking.Address{}, &staking.Address{})
 )
 
 // ImmutableState is the immutable consensus state wrapper.
@@ -174,6 +186,69 @@ func (s *ImmutableState) Votes(ctx context.Context, id uint64) ([]*governance.Vo
 	return voteEntries, nil
 }
 
+// VoteDelegate looks up the proxy voter assignment of the given delegator.
+//
+// Returns nil in case the delegator has no proxy voter assigned.
+func (s *ImmutableState) VoteDelegate(ctx context.Context, delegator staking.Address) (*governance.VoteDelegate, error) {
+	data, err := s.is.Get(ctx, voteDelegatesKeyFmt.Encode(&delegator))
+	if err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	var vd governance.VoteDelegate
+	if err = cbor.Unmarshal(data, &vd); err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	return &vd, nil
+}
+
+// VoteDelegates returns all proxy voter assignments by delegator address.
+func (s *ImmutableState) VoteDelegates(ctx context.Context) (map[staking.Address]*governance.VoteDelegate, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	delegates := make(map[staking.Address]*governance.VoteDelegate)
+	for it.Seek(voteDelegatesKeyFmt.Encode()); it.Valid(); it.Next() {
+		var delegator staking.Address
+		if !voteDelegatesKeyFmt.Decode(it.Key(), &delegator) {
+			break
+		}
+		var vd governance.VoteDelegate
+		if err := cbor.Unmarshal(it.Value(), &vd); err != nil {
+			return nil, api.UnavailableStateError(err)
+		}
+		delegates[delegator] = &vd
+	}
+	if it.Err() != nil {
+		return nil, api.UnavailableStateError(it.Err())
+	}
+	return delegates, nil
+}
+
+// VoteDelegators returns the addresses of all delegators that assigned the given proxy voter.
+func (s *ImmutableState) VoteDelegators(ctx context.Context, delegate staking.Address) ([]staking.Address, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var delegators []staking.Address
+	for it.Seek(voteDelegatorsKeyFmt.Encode(&delegate)); it.Valid(); it.Next() {
+		var decDelegate, delegator staking.Address
+		if !voteDelegatorsKeyFmt.Decode(it.Key(), &decDelegate, &delegator) {
+			break
+		}
+		if !decDelegate.Equal(delegate) {
+			break
+		}
+		delegators = append(delegators, delegator)
+	}
+	if it.Err() != nil {
+		return nil, api.UnavailableStateError(it.Err())
+	}
+	return delegators, nil
+}
+
 func (s *ImmutableState) isProposalPendingUpgrade(ctx context.Context, proposal *governance.Proposal) (bool, error) {
 	if proposal.Content.Upgrade == nil {
 		return false, nil
@@ -349,6 +424,36 @@ func (s *MutableState) SetVote(
 	return api.UnavailableStateError(err)
 }
 
+// SetVoteDelegate sets the proxy voter assignment of the given delegator.
+//
+// In case the delegate is nil, the existing assignment (if any) is removed.
+func (s *MutableState) SetVoteDelegate(
+	ctx context.Context,
+	delegator staking.Address,
+	vd *governance.VoteDelegate,
+) error {
+	existing, err := s.VoteDelegate(ctx, delegator)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if err = s.ms.Remove(ctx, voteDelegatorsKeyFmt.Encode(existing.Delegate, &delegator)); err != nil {
+			return api.UnavailableStateError(err)
+		}
+	}
+
+	if vd == nil || vd.Delegate == nil {
+		err = s.ms.Remove(ctx, voteDelegatesKeyFmt.Encode(&delegator))
+		return api.UnavailableStateError(err)
+	}
+
+	if err = s.ms.Insert(ctx, voteDelegatesKeyFmt.Encode(&delegator), cbor.Marshal(vd)); err != nil {
+		return api.UnavailableStateError(err)
+	}
+	err = s.ms.Insert(ctx, voteDelegatorsKeyFmt.Encode(vd.Delegate, &delegator), []byte(""))
+	return api.UnavailableStateError(err)
+}
+
 // SetConsensusParameters sets governance consensus parameters.
 //
 // NOTE: This method must only be called from InitChain/EndBlock contexts.
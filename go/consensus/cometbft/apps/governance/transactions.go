@@ -302,6 +302,16 @@ func (app *governanceApplication) castVote(
 		}
 	}
 
+	// Or if the submitter is a proxy voter for any delegators.
+	if !eligible && params.AllowVoteDelegation {
+		var delegators []stakingAPI.Address
+		delegators, err = state.VoteDelegators(ctx, submitterAddr)
+		if err != nil {
+			return fmt.Errorf("governance: failed to query vote delegators: %w", err)
+		}
+		eligible = len(delegators) > 0
+	}
+
 	if !eligible {
 		ctx.Logger().Debug("governance: submitter not eligible to vote",
 			"submitter", ctx.CallerAddress(),
@@ -349,3 +359,65 @@ func (app *governanceApplication) castVote(
 
 	return nil
 }
+
+func (app *governanceApplication) setVoteDelegate(
+	ctx *api.Context,
+	state *governanceState.MutableState,
+	voteDelegate *governance.VoteDelegate,
+) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("governance: failed to fetch consensus parameters: %w", err)
+	}
+
+	// To not violate the consensus, vote delegation should be ignored when disabled.
+	if !params.AllowVoteDelegation {
+		return governance.ErrVoteDelegationNotAllowed
+	}
+
+	if err = voteDelegate.ValidateBasic(); err != nil {
+		ctx.Logger().Debug("governance: malformed vote delegate",
+			"vote_delegate", voteDelegate,
+			"err", err,
+		)
+		return governance.ErrInvalidArgument
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	if err = ctx.Gas().UseGas(1, governance.GasOpSetVoteDelegate, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	delegatorAddr := ctx.CallerAddress()
+	if !delegatorAddr.IsValid() {
+		return stakingAPI.ErrForbidden
+	}
+	if voteDelegate.Delegate != nil && voteDelegate.Delegate.Equal(delegatorAddr) {
+		ctx.Logger().Debug("governance: delegating votes to self is not allowed",
+			"delegator", delegatorAddr,
+		)
+		return governance.ErrInvalidArgument
+	}
+
+	if err = state.SetVoteDelegate(ctx, delegatorAddr, voteDelegate); err != nil {
+		return fmt.Errorf("governance: failed to set vote delegate: %w", err)
+	}
+
+	// Emit event.
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&governance.VoteDelegateEvent{
+		Delegator: delegatorAddr,
+		Delegate:  voteDelegate.Delegate,
+		Kinds:     voteDelegate.Kinds,
+	}))
+
+	return nil
+}
@@ -489,3 +489,137 @@ func TestCastVote(t *testing.T) {
 		tc.check()
 	}
 }
+
+func TestSetVoteDelegate(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup state.
+	registryState := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	schedulerState := schedulerState.NewMutableState(ctx.State())
+	signers, addresses, _ := initValidatorsEscrowState(t, stakeState, registryState, schedulerState)
+	delegator := signers[numValidators].Public()
+	delegatorAddr := addresses[numValidators]
+	proxyPK := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	proxyAddr := staking.NewAddress(proxyPK)
+
+	// Setup governance state.
+	state := governanceState.NewMutableState(ctx.State())
+	app := &governanceApplication{
+		state: appState,
+	}
+	params := &governance.ConsensusParameters{
+		GasCosts:               governance.DefaultGasCosts,
+		MinProposalDeposit:     *quantity.NewFromUint64(100),
+		StakeThreshold:         90,
+		VotingPeriod:           beacon.EpochTime(50),
+		AllowVoteWithoutEntity: true,
+	}
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting governance consensus parameters should not error")
+
+	p1 := &governance.Proposal{ID: 1, State: governance.StateActive}
+	err = state.SetActiveProposal(ctx, p1)
+	require.NoError(err, "SetActiveProposal")
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(delegator)
+
+	// Vote delegation should fail when disabled.
+	err = app.setVoteDelegate(txCtx, state, &governance.VoteDelegate{Delegate: &proxyAddr})
+	require.Equal(governance.ErrVoteDelegationNotAllowed, err, "setVoteDelegate should fail when disabled")
+
+	params.AllowVoteDelegation = true
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting governance consensus parameters should not error")
+
+	// Proxy voter without any delegators should not be eligible to vote.
+	proxyCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer proxyCtx.Close()
+	proxyCtx.SetTxSigner(proxyPK)
+	err = app.castVote(proxyCtx, state, &governance.ProposalVote{ID: p1.ID, Vote: governance.VoteYes})
+	require.Equal(governance.ErrNotEligible, err, "castVote should fail for proxy voter without delegators")
+
+	for _, tc := range []struct {
+		msg   string
+		vd    *governance.VoteDelegate
+		err   error
+		check func()
+	}{
+		{
+			"should fail with invalid proposal kind",
+			&governance.VoteDelegate{
+				Delegate: &proxyAddr,
+				Kinds:    []governance.ProposalKind{"invalid"},
+			},
+			governance.ErrInvalidArgument,
+			func() {},
+		},
+		{
+			"should fail with kinds but no delegate",
+			&governance.VoteDelegate{
+				Kinds: []governance.ProposalKind{governance.ProposalKindUpgrade},
+			},
+			governance.ErrInvalidArgument,
+			func() {},
+		},
+		{
+			"should fail when delegating to self",
+			&governance.VoteDelegate{Delegate: &delegatorAddr},
+			governance.ErrInvalidArgument,
+			func() {},
+		},
+		{
+			"should work",
+			&governance.VoteDelegate{
+				Delegate: &proxyAddr,
+				Kinds:    []governance.ProposalKind{governance.ProposalKindUpgrade},
+			},
+			nil,
+			func() {
+				vd, err := state.VoteDelegate(ctx, delegatorAddr)
+				require.NoError(err, "VoteDelegate()")
+				require.NotNil(vd, "vote delegate should exist")
+				require.EqualValues(proxyAddr, *vd.Delegate, "vote delegate should match")
+				require.EqualValues([]governance.ProposalKind{governance.ProposalKindUpgrade}, vd.Kinds, "proposal kinds should match")
+
+				delegators, err := state.VoteDelegators(ctx, proxyAddr)
+				require.NoError(err, "VoteDelegators()")
+				require.EqualValues([]staking.Address{delegatorAddr}, delegators, "vote delegators should match")
+
+				// Proxy voter should now be eligible to vote.
+				err = app.castVote(proxyCtx, state, &governance.ProposalVote{ID: p1.ID, Vote: governance.VoteYes})
+				require.NoError(err, "castVote should work for proxy voter")
+			},
+		},
+		{
+			"clearing should work",
+			&governance.VoteDelegate{},
+			nil,
+			func() {
+				vd, err := state.VoteDelegate(ctx, delegatorAddr)
+				require.NoError(err, "VoteDelegate()")
+				require.Nil(vd, "vote delegate should not exist")
+
+				delegators, err := state.VoteDelegators(ctx, proxyAddr)
+				require.NoError(err, "VoteDelegators()")
+				require.Empty(delegators, "vote delegators should be empty")
+			},
+		},
+	} {
+		err = app.setVoteDelegate(txCtx, state, tc.vd)
+		require.Equal(tc.err, err, tc.msg)
+
+		tc.check()
+	}
+
+	// Ensure events were emitted.
+	events := txCtx.GetEvents()
+	require.Len(events, 2, "two vote delegate events should be emitted")
+}
@@ -49,9 +49,9 @@ func (ext *churpExt) create(ctx *tmapi.Context, req *churp.CreateRequest) error
 		return err
 	}
 
-	// Ensure that the tx signer is the key manager owner.
-	if !kmRt.EntityID.Equal(ctx.TxSigner()) {
-		return fmt.Errorf("keymanager: churp: invalid signer")
+	// Ensure that the caller is the key manager owner.
+	if err = authorizeOwner(ctx, kmRt); err != nil {
+		return err
 	}
 
 	// Make sure the ID is unique.
@@ -160,9 +160,9 @@ func (ext *churpExt) update(ctx *tmapi.Context, req *churp.UpdateRequest) error
 		return err
 	}
 
-	// Ensure that the tx signer is the key manager owner.
-	if !kmRt.EntityID.Equal(ctx.TxSigner()) {
-		return fmt.Errorf("keymanager: churp: invalid signer")
+	// Ensure that the caller is the key manager owner.
+	if err = authorizeOwner(ctx, kmRt); err != nil {
+		return err
 	}
 
 	// Get the existing status.
@@ -572,3 +572,33 @@ func verifyPolicy(ctx *tmapi.Context, policy *churp.SignedPolicySGX) error {
 	}
 	return nil
 }
+
+// authorizeOwner ensures that the caller is the owner of the given key manager runtime.
+//
+// Transactions need to be signed by the entity controlling the key manager, while runtime
+// messages can only manage key managers used by the emitting compute runtime, provided that
+// the runtime governs itself and is owned by the same entity as the key manager.
+func authorizeOwner(ctx *tmapi.Context, kmRt *api.Runtime) error {
+	if !ctx.IsMessageExecution() {
+		if !kmRt.EntityID.Equal(ctx.TxSigner()) {
+			return fmt.Errorf("keymanager: churp: invalid signer")
+		}
+		return nil
+	}
+
+	regState := registryState.NewMutableState(ctx.State())
+	runtimes, err := regState.Runtimes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, rt := range runtimes {
+		if rt.GovernanceModel != api.GovernanceRuntime || !ctx.CallerAddress().Equal(*rt.StakingAddress()) {
+			continue
+		}
+		if rt.KeyManager == nil || !rt.KeyManager.Equal(&kmRt.ID) || !rt.EntityID.Equal(kmRt.EntityID) {
+			break
+		}
+		return nil
+	}
+	return fmt.Errorf("keymanager: churp: invalid caller")
+}
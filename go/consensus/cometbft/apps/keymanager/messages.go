@@ -4,13 +4,42 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	churpState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/churp/state"
 	secretsState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/secrets/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/churp"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 )
 
+func (app *keymanagerApplication) executeRuntimeMessage(ctx *api.Context, msg interface{}) (interface{}, error) {
+	m, ok := msg.(*message.KeyManagerMessage)
+	if !ok {
+		return nil, fmt.Errorf("keymanager: failed to type assert runtime message")
+	}
+
+	// Dispatch the message to the extension handling the corresponding method, as if the runtime
+	// submitted a transaction.
+	var tx *transaction.Transaction
+	switch {
+	case m.CreateChurp != nil:
+		tx = &transaction.Transaction{Method: churp.MethodCreate, Body: cbor.Marshal(m.CreateChurp)}
+	case m.UpdateChurp != nil:
+		tx = &transaction.Transaction{Method: churp.MethodUpdate, Body: cbor.Marshal(m.UpdateChurp)}
+	default:
+		return nil, fmt.Errorf("keymanager: runtime message has no fields set")
+	}
+
+	ext, ok := app.extsByMethod[tx.Method]
+	if !ok {
+		return nil, fmt.Errorf("keymanager: invalid method: %s", tx.Method)
+	}
+	return nil, ext.ExecuteTx(ctx, tx)
+}
+
 func (app *keymanagerApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
 	// Unmarshal changes and check if they should be applied to this module.
 	proposal, ok := msg.(*governance.ChangeParametersProposal)
@@ -18,35 +47,55 @@ func (app *keymanagerApplication) changeParameters(ctx *api.Context, msg interfa
 		return nil, fmt.Errorf("keymanager: failed to type assert change parameters proposal")
 	}
 
-	if proposal.Module != keymanager.ModuleName {
+	switch proposal.Module {
+	case keymanager.ModuleName:
+		return app.changeSecretsParameters(ctx, proposal, apply)
+	case churp.ModuleName:
+		return app.changeChurpParameters(ctx, proposal, apply)
+	default:
 		return nil, nil
 	}
+}
 
-	var changes secrets.ConsensusParameterChanges
-	if err := cbor.Unmarshal(proposal.Changes, &changes); err != nil {
-		return nil, fmt.Errorf("keymanager: failed to unmarshal consensus parameter changes: %w", err)
-	}
-
+func (app *keymanagerApplication) changeSecretsParameters(ctx *api.Context, proposal *governance.ChangeParametersProposal, apply bool) (interface{}, error) {
 	// Validate changes against current parameters.
 	state := secretsState.NewMutableState(ctx.State())
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("keymanager: failed to load consensus parameters: %w", err)
 	}
-	if err = changes.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("keymanager: failed to validate consensus parameter changes: %w", err)
+	var changes secrets.ConsensusParameterChanges
+	if err = governance.ApplyChangeParametersProposal(proposal, params, &changes, nil); err != nil {
+		return nil, fmt.Errorf("keymanager: %w", err)
 	}
-	if err = changes.Apply(params); err != nil {
-		return nil, fmt.Errorf("keymanager: failed to apply consensus parameter changes: %w", err)
+
+	// Apply changes.
+	if apply {
+		if err = state.SetConsensusParameters(ctx, params); err != nil {
+			return nil, fmt.Errorf("keymanager: failed to update consensus parameters: %w", err)
+		}
+	}
+
+	// Non-nil response signals that changes are valid and were successfully applied (if required).
+	return struct{}{}, nil
+}
+
+func (app *keymanagerApplication) changeChurpParameters(ctx *api.Context, proposal *governance.ChangeParametersProposal, apply bool) (interface{}, error) {
+	// Validate changes against current parameters. CHURP does not define module-specific
+	// changes so only generic parameter patches are supported.
+	state := churpState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("keymanager: churp: failed to load consensus parameters: %w", err)
 	}
-	if err = params.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("keymanager: failed to validate consensus parameters: %w", err)
+	if err = governance.ApplyChangeParametersProposal(proposal, params, nil, nil); err != nil {
+		return nil, fmt.Errorf("keymanager: churp: %w", err)
 	}
 
 	// Apply changes.
 	if apply {
 		if err = state.SetConsensusParameters(ctx, params); err != nil {
-			return nil, fmt.Errorf("keymanager: failed to update consensus parameters: %w", err)
+			return nil, fmt.Errorf("keymanager: churp: failed to update consensus parameters: %w", err)
 		}
 	}
 
@@ -5,13 +5,27 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	churpState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/churp/state"
 	secretsState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/secrets/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/churp"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 func TestChangeParameters(t *testing.T) {
@@ -93,4 +107,203 @@ func TestChangeParameters(t *testing.T) {
 		_, err := app.changeParameters(ctx, &proposal, true)
 		require.EqualError(err, "keymanager: failed to validate consensus parameter changes: consensus parameter changes should not be empty")
 	})
+	t.Run("happy path - apply patch", func(t *testing.T) {
+		require := require.New(t)
+
+		gasCosts := transaction.Costs{
+			secrets.GasOpUpdatePolicy: 3000,
+		}
+		proposal := governance.ChangeParametersProposal{
+			Module: keymanager.ModuleName,
+			Patch:  cbor.Marshal(map[string]any{"gas_costs": gasCosts}),
+		}
+		res, err := app.changeParameters(ctx, &proposal, true)
+		require.NoError(err, "patching consensus parameters should succeed")
+		require.Equal(struct{}{}, res)
+
+		state, err := state.ConsensusParameters(ctx)
+		require.NoError(err, "fetching consensus parameters should succeed")
+		require.Equal(gasCosts, state.GasCosts, "consensus parameters should change")
+	})
+	t.Run("invalid patch", func(t *testing.T) {
+		require := require.New(t)
+
+		proposal := governance.ChangeParametersProposal{
+			Module: keymanager.ModuleName,
+			Patch:  cbor.Marshal(map[string]any{"unknown": 1}),
+		}
+		_, err := app.changeParameters(ctx, &proposal, false)
+		require.Error(err, "patches with unknown fields should fail")
+	})
+}
+
+func TestChangeChurpParameters(t *testing.T) {
+	// Prepare context.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup state.
+	state := churpState.NewMutableState(ctx.State())
+	app := &keymanagerApplication{
+		state: appState,
+	}
+	params := &churp.ConsensusParameters{
+		GasCosts: churp.DefaultGasCosts,
+	}
+	err := state.SetConsensusParameters(ctx, params)
+	require.NoError(t, err, "setting consensus parameters should succeed")
+
+	gasCosts := transaction.Costs{
+		churp.GasOpCreate: 2000,
+	}
+
+	t.Run("happy path - validate only", func(t *testing.T) {
+		require := require.New(t)
+
+		proposal := governance.ChangeParametersProposal{
+			Module: churp.ModuleName,
+			Patch:  cbor.Marshal(map[string]any{"gas_costs": gasCosts}),
+		}
+		res, err := app.changeParameters(ctx, &proposal, false)
+		require.NoError(err, "validation of consensus parameters patch should succeed")
+		require.Equal(struct{}{}, res)
+
+		state, err := state.ConsensusParameters(ctx)
+		require.NoError(err, "fetching consensus parameters should succeed")
+		require.Equal(params.GasCosts, state.GasCosts, "consensus parameters shouldn't change")
+	})
+	t.Run("happy path - apply patch", func(t *testing.T) {
+		require := require.New(t)
+
+		proposal := governance.ChangeParametersProposal{
+			Module: churp.ModuleName,
+			Patch:  cbor.Marshal(map[string]any{"gas_costs": gasCosts}),
+		}
+		res, err := app.changeParameters(ctx, &proposal, true)
+		require.NoError(err, "patching consensus parameters should succeed")
+		require.Equal(struct{}{}, res)
+
+		state, err := state.ConsensusParameters(ctx)
+		require.NoError(err, "fetching consensus parameters should succeed")
+		require.Equal(gasCosts, state.GasCosts, "consensus parameters should change")
+	})
+	t.Run("module-specific changes", func(t *testing.T) {
+		require := require.New(t)
+
+		proposal := governance.ChangeParametersProposal{
+			Module:  churp.ModuleName,
+			Changes: cbor.Marshal(map[string]any{"gas_costs": gasCosts}),
+		}
+		_, err := app.changeParameters(ctx, &proposal, true)
+		require.EqualError(err, "keymanager: churp: module-specific parameter changes are not supported")
+	})
+}
+
+func TestRuntimeMessages(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	app := New()
+	app.OnRegister(appState, &abciAPI.NoopMessageDispatcher{})
+
+	// Set up default consensus parameters.
+	err := churpState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &churp.DefaultConsensusParameters)
+	require.NoError(err, "SetConsensusParameters")
+	regState := registryState.NewMutableState(ctx.State())
+	err = regState.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+	err = stakingState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+	err = consensusState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Register an entity that owns a key manager and compute runtimes using it.
+	entitySigner := memorySigner.NewTestSigner("keymanager/messages test entity")
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = regState.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	kmRt := &registry.Runtime{
+		ID:              common.NewTestNamespaceFromSeed([]byte("keymanager/messages test km"), common.NamespaceTest),
+		Kind:            registry.KindKeyManager,
+		TEEHardware:     node.TEEHardwareIntelSGX,
+		EntityID:        ent.ID,
+		GovernanceModel: registry.GovernanceEntity,
+	}
+	newComputeRuntime := func(seed string, gm registry.RuntimeGovernanceModel) *registry.Runtime {
+		rt := &registry.Runtime{
+			ID:              common.NewTestNamespaceFromSeed([]byte(seed), common.NamespaceTest),
+			Kind:            registry.KindCompute,
+			EntityID:        ent.ID,
+			KeyManager:      &kmRt.ID,
+			GovernanceModel: gm,
+		}
+		err = regState.SetRuntime(ctx, rt, false)
+		require.NoError(err, "SetRuntime")
+		return rt
+	}
+	err = regState.SetRuntime(ctx, kmRt, false)
+	require.NoError(err, "SetRuntime")
+	rt := newComputeRuntime("keymanager/messages test rt", registry.GovernanceRuntime)
+	entityRt := newComputeRuntime("keymanager/messages test entity rt", registry.GovernanceEntity)
+
+	identity := churp.Identity{
+		ID:        1,
+		RuntimeID: kmRt.ID,
+	}
+	msg := &message.KeyManagerMessage{
+		CreateChurp: &churp.CreateRequest{
+			Identity:    identity,
+			Threshold:   1,
+			ExtraShares: 2,
+			Policy: churp.SignedPolicySGX{
+				Policy: churp.PolicySGX{
+					Identity: identity,
+				},
+			},
+		},
+	}
+	execute := func(caller *registry.Runtime) error {
+		msgCtx := appState.NewContext(abciAPI.ContextDeliverTx).WithMessageExecution()
+		defer msgCtx.Close()
+		msgCtx = msgCtx.WithCallerAddress(staking.NewRuntimeAddress(caller.ID))
+		defer msgCtx.Close()
+
+		_, err = app.ExecuteMessage(msgCtx, roothashApi.RuntimeMessageKeyManager, msg)
+		return err
+	}
+
+	// Runtimes that are not governed by themselves should not be able to manage key managers.
+	err = execute(entityRt)
+	require.ErrorContains(err, "invalid caller", "runtime messages from entity-governed runtimes should fail")
+
+	// Runtimes using the key manager should be able to manage it.
+	err = execute(rt)
+	require.NoError(err, "runtime messages from runtimes using the key manager should succeed")
+
+	status, err := churpState.NewMutableState(ctx.State()).Status(ctx, kmRt.ID, identity.ID)
+	require.NoError(err, "Status")
+	require.EqualValues(2, status.ExtraShares)
+
+	// Runtimes using other key managers should not be able to manage it.
+	rt.KeyManager = nil
+	err = regState.SetRuntime(ctx, rt, false)
+	require.NoError(err, "SetRuntime")
+	msg = &message.KeyManagerMessage{
+		UpdateChurp: &churp.UpdateRequest{
+			Identity:    identity,
+			ExtraShares: &identity.ID,
+		},
+	}
+	err = execute(rt)
+	require.ErrorContains(err, "invalid caller", "runtime messages from runtimes not using the key manager should fail")
 }
@@ -3,13 +3,19 @@ package registry
 import (
 	"fmt"
 
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
+// immutableParameters are the consensus parameters that cannot be changed via parameters patches.
+var immutableParameters = []string{
+	"debug_allow_unroutable_addresses",
+	"debug_allow_test_runtimes",
+	"debug_deploy_immediately",
+}
+
 func (app *registryApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
 	// Unmarshal changes and check if they should be applied to this module.
 	proposal, ok := msg.(*governance.ChangeParametersProposal)
@@ -21,25 +27,15 @@ func (app *registryApplication) changeParameters(ctx *api.Context, msg interface
 		return nil, nil
 	}
 
-	var changes registry.ConsensusParameterChanges
-	if err := cbor.Unmarshal(proposal.Changes, &changes); err != nil {
-		return nil, fmt.Errorf("registry: failed to unmarshal consensus parameter changes: %w", err)
-	}
-
 	// Validate changes against current parameters.
 	state := registryState.NewMutableState(ctx.State())
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("registry: failed to load consensus parameters: %w", err)
 	}
-	if err = changes.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("registry: failed to validate consensus parameter changes: %w", err)
-	}
-	if err = changes.Apply(params); err != nil {
-		return nil, fmt.Errorf("registry: failed to apply consensus parameter changes: %w", err)
-	}
-	if err = params.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("registry: failed to validate consensus parameters: %w", err)
+	var changes registry.ConsensusParameterChanges
+	if err = governance.ApplyChangeParametersProposal(proposal, params, &changes, immutableParameters); err != nil {
+		return nil, fmt.Errorf("registry: %w", err)
 	}
 
 	// Apply changes.
@@ -15,4 +15,10 @@ var (
 
 	// RuntimeMessageGovernance is the message kind used when dispatching Governance runtime messages.
 	RuntimeMessageGovernance = messageKind(3)
+
+	// RuntimeMessageVault is the message kind used when dispatching Vault runtime messages.
+	RuntimeMessageVault = messageKind(4)
+
+	// RuntimeMessageKeyManager is the message kind used when dispatching KeyManager runtime messages.
+	RuntimeMessageKeyManager = messageKind(5)
 )
@@ -18,6 +18,12 @@ import (
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
+// immutableParameters are the consensus parameters that cannot be changed via parameters patches.
+var immutableParameters = []string{
+	"debug_do_not_suspend_runtimes",
+	"debug_bypass_stake",
+}
+
 func fetchRuntimeMessages(
 	ctx *tmapi.Context,
 	state *roothashState.MutableState,
@@ -140,6 +146,10 @@ func (app *rootHashApplication) processRuntimeMessages(
 			result, err = app.md.Publish(ctx, roothashApi.RuntimeMessageRegistry, msg.Registry)
 		case msg.Governance != nil:
 			result, err = app.md.Publish(ctx, roothashApi.RuntimeMessageGovernance, msg.Governance)
+		case msg.Vault != nil:
+			result, err = app.md.Publish(ctx, roothashApi.RuntimeMessageVault, msg.Vault)
+		case msg.KeyManager != nil:
+			result, err = app.md.Publish(ctx, roothashApi.RuntimeMessageKeyManager, msg.KeyManager)
 		default:
 			// Unsupported message.
 			err = roothash.ErrInvalidArgument
@@ -206,32 +216,20 @@ func (app *rootHashApplication) changeParameters(ctx *tmapi.Context, msg interfa
 		return nil, nil
 	}
 
-	var changes roothash.ConsensusParameterChanges
-	if err := cbor.Unmarshal(proposal.Changes, &changes); err != nil {
-		return nil, fmt.Errorf("roothash: failed to unmarshal consensus parameter changes: %w", err)
-	}
-
 	// Validate changes against current parameters.
 	state := roothashState.NewMutableState(ctx.State())
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("roothash: failed to load consensus parameters: %w", err)
 	}
-	var needToDeletePastRoots bool
-	if changes.MaxPastRootsStored != nil && *changes.MaxPastRootsStored < params.MaxPastRootsStored {
-		// If we've reduced the number of past roots stored, we need to delete
-		// the excess when applying the new parameters.
-		needToDeletePastRoots = true
-	}
-	if err = changes.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("roothash: failed to validate consensus parameter changes: %w", err)
-	}
-	if err = changes.Apply(params); err != nil {
-		return nil, fmt.Errorf("roothash: failed to apply consensus parameter changes: %w", err)
-	}
-	if err = params.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("roothash: failed to validate consensus parameters: %w", err)
+	prevMaxPastRootsStored := params.MaxPastRootsStored
+	var changes roothash.ConsensusParameterChanges
+	if err = governance.ApplyChangeParametersProposal(proposal, params, &changes, immutableParameters); err != nil {
+		return nil, fmt.Errorf("roothash: %w", err)
 	}
+	// If we've reduced the number of past roots stored, we need to delete
+	// the excess when applying the new parameters.
+	needToDeletePastRoots := params.MaxPastRootsStored < prevMaxPastRootsStored
 
 	// Apply changes.
 	if apply {
@@ -23,6 +23,7 @@ import (
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/churp"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
@@ -30,6 +31,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
 )
 
 type testMsgDispatcher struct{}
@@ -54,6 +56,15 @@ func (nd *testMsgDispatcher) Publish(ctx *abciAPI.Context, kind, msg interface{}
 		governance.GasOpCastVote:       1000,
 		governance.GasOpSubmitProposal: 2000,
 	}
+	vaultGasCosts := transaction.Costs{
+		vault.GasOpCreate:          1000,
+		vault.GasOpAuthorizeAction: 2000,
+		vault.GasOpCancelAction:    1000,
+	}
+	churpGasCosts := transaction.Costs{
+		churp.GasOpCreate: 3000,
+		churp.GasOpUpdate: 2000,
+	}
 
 	switch kind {
 	case roothashApi.RuntimeMessageStaking:
@@ -109,6 +120,43 @@ func (nd *testMsgDispatcher) Publish(ctx *abciAPI.Context, kind, msg interface{}
 		default:
 			return nil, governance.ErrInvalidArgument
 		}
+	case roothashApi.RuntimeMessageVault:
+		m := msg.(*message.VaultMessage)
+		switch {
+		case m.Create != nil:
+			if err := ctx.Gas().UseGas(1, vault.GasOpCreate, vaultGasCosts); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		case m.AuthorizeAction != nil:
+			if err := ctx.Gas().UseGas(1, vault.GasOpAuthorizeAction, vaultGasCosts); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		case m.CancelAction != nil:
+			if err := ctx.Gas().UseGas(1, vault.GasOpCancelAction, vaultGasCosts); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		default:
+			return nil, vault.ErrInvalidArgument
+		}
+	case roothashApi.RuntimeMessageKeyManager:
+		m := msg.(*message.KeyManagerMessage)
+		switch {
+		case m.CreateChurp != nil:
+			if err := ctx.Gas().UseGas(1, churp.GasOpCreate, churpGasCosts); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		case m.UpdateChurp != nil:
+			if err := ctx.Gas().UseGas(1, churp.GasOpUpdate, churpGasCosts); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		default:
+			return nil, staking.ErrInvalidArgument
+		}
 	default:
 		return nil, staking.ErrInvalidArgument
 	}
@@ -196,6 +244,16 @@ func TestMessagesGasEstimation(t *testing.T) {
 		{Governance: &message.GovernanceMessage{CastVote: &governance.ProposalVote{}}},
 		// Each submit proposal message costs 2000 gas.
 		{Governance: &message.GovernanceMessage{SubmitProposal: &governance.ProposalContent{}}},
+		// Each vault create message costs 1000 gas.
+		{Vault: &message.VaultMessage{Create: &vault.Create{}}},
+		// Each vault authorize action message costs 2000 gas.
+		{Vault: &message.VaultMessage{AuthorizeAction: &vault.AuthorizeAction{}}},
+		// Each vault cancel action message costs 1000 gas.
+		{Vault: &message.VaultMessage{CancelAction: &vault.CancelAction{}}},
+		// Each CHURP create message costs 3000 gas.
+		{KeyManager: &message.KeyManagerMessage{CreateChurp: &churp.CreateRequest{}}},
+		// Each CHURP update message costs 2000 gas.
+		{KeyManager: &message.KeyManagerMessage{UpdateChurp: &churp.UpdateRequest{}}},
 	}
 	msgsHash := message.MessagesHash(msgs)
 
@@ -229,7 +287,7 @@ func TestMessagesGasEstimation(t *testing.T) {
 
 	err = app.executorCommit(ctx, roothashState, cc)
 	require.NoError(err, "ExecutorCommit")
-	require.EqualValues(15000, ctx.Gas().GasUsed(), "gas amount should be correct")
+	require.EqualValues(24000, ctx.Gas().GasUsed(), "gas amount should be correct")
 }
 
 func TestEvidence(t *testing.T) {
@@ -3,13 +3,19 @@ package scheduler
 import (
 	"fmt"
 
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
 
+// immutableParameters are the consensus parameters that cannot be changed via parameters patches.
+var immutableParameters = []string{
+	"debug_bypass_stake",
+	"debug_force_elect",
+	"debug_allow_weak_alpha",
+}
+
 func (app *schedulerApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
 	// Unmarshal changes and check if they should be applied to this module.
 	proposal, ok := msg.(*governance.ChangeParametersProposal)
@@ -21,25 +27,15 @@ func (app *schedulerApplication) changeParameters(ctx *api.Context, msg interfac
 		return nil, nil
 	}
 
-	var changes scheduler.ConsensusParameterChanges
-	if err := cbor.Unmarshal(proposal.Changes, &changes); err != nil {
-		return nil, fmt.Errorf("cometbft/scheduler: failed to unmarshal consensus parameter changes: %w", err)
-	}
-
 	// Validate changes against current parameters.
 	state := schedulerState.NewMutableState(ctx.State())
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cometbft/scheduler: failed to load consensus parameters: %w", err)
 	}
-	if err = changes.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("cometbft/scheduler: failed to validate consensus parameter changes: %w", err)
-	}
-	if err = changes.Apply(params); err != nil {
-		return nil, fmt.Errorf("cometbft/scheduler: failed to apply consensus parameter changes: %w", err)
-	}
-	if err = params.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("cometbft/scheduler: failed to validate consensus parameters: %w", err)
+	var changes scheduler.ConsensusParameterChanges
+	if err = governance.ApplyChangeParametersProposal(proposal, params, &changes, immutableParameters); err != nil {
+		return nil, fmt.Errorf("cometbft/scheduler: %w", err)
 	}
 
 	// Apply changes.
@@ -29,7 +29,7 @@ func (app *stakingApplication) GetSignerNonce(ctx context.Context, req *consensu
 
 // Implements api.TransactionAuthHandler.
 func (app *stakingApplication) AuthenticateTx(ctx *api.Context, tx *transaction.Transaction) error {
-	return stakingState.AuthenticateAndPayFees(ctx, ctx.TxSigner(), tx.Nonce, tx.Fee)
+	return stakingState.AuthenticateAndPayFees(ctx, ctx.CallerAddress(), tx.Nonce, tx.Fee, tx.FeePayer)
 }
 
 // Implements api.TransactionAuthHandler.
@@ -50,16 +50,33 @@ func (app *stakingApplication) PostExecuteTx(ctx *api.Context, tx *transaction.T
 		fee = &transaction.Fee{}
 	}
 
-	addr := staking.NewAddress(ctx.TxSigner())
+	addr := ctx.CallerAddress()
 
 	account, err := state.Account(ctx, addr)
 	if err != nil {
 		return fmt.Errorf("failed to fetch account state: %w", err)
 	}
 
-	// Deduct fee and increment the nonce.
-	if err := account.General.Balance.Sub(&fee.Amount); err != nil {
-		return transaction.ErrInsufficientFeeBalance
+	// Deduct fee from the paying account and increment the nonces.
+	switch tx.FeePayer {
+	case nil:
+		if err = account.General.Balance.Sub(&fee.Amount); err != nil {
+			return transaction.ErrInsufficientFeeBalance
+		}
+	default:
+		payerAddr := staking.NewAddress(tx.FeePayer.PublicKey)
+		var payerAccount *staking.Account
+		if payerAccount, err = state.Account(ctx, payerAddr); err != nil {
+			return fmt.Errorf("failed to fetch fee payer account state: %w", err)
+		}
+		if err = payerAccount.General.Balance.Sub(&fee.Amount); err != nil {
+			return transaction.ErrInsufficientFeeBalance
+		}
+
+		payerAccount.General.Nonce++
+		if err = state.SetAccount(ctx, payerAddr, payerAccount); err != nil {
+			return fmt.Errorf("failed to set fee payer account: %w", err)
+		}
 	}
 
 	account.General.Nonce++
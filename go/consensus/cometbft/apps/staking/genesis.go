@@ -115,6 +115,16 @@ func (app *stakingApplication) initLedger(
 			return fmt.Errorf("cometbft/staking: invalid genesis debonding escrow balance for account %s", addr)
 		}
 
+		if vs := acct.General.Vesting; vs != nil {
+			if err := vs.ValidateBasic(); err != nil {
+				ctx.Logger().Error("InitChain: invalid genesis vesting schedule",
+					"address", addr,
+					"err", err,
+				)
+				return fmt.Errorf("cometbft/staking: invalid genesis vesting schedule for account %s: %w", addr, err)
+			}
+		}
+
 		// Make sure that the stake accumulator is empty as otherwise it could be inconsistent with
 		// what is registered in the genesis block.
 		if len(acct.Escrow.StakeAccumulator.Claims) > 0 {
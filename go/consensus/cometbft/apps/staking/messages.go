@@ -4,13 +4,19 @@ import (
 	"fmt"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
+// immutableParameters are the consensus parameters that cannot be changed via parameters patches.
+var immutableParameters = []string{
+	"token_symbol",
+	"token_value_exponent",
+	"debug_bypass_stake",
+}
+
 func (app *stakingApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
 	proposal, ok := msg.(*governance.ChangeParametersProposal)
 	if !ok {
@@ -21,29 +27,22 @@ func (app *stakingApplication) changeParameters(ctx *api.Context, msg interface{
 		return nil, nil
 	}
 
-	var changes staking.ConsensusParameterChanges
-	if err := cbor.Unmarshal(proposal.Changes, &changes); err != nil {
-		return nil, fmt.Errorf("staking: failed to unmarshal consensus parameter changes: %w", err)
-	}
-
 	// Validate and apply changes to the parameters.
 	state := stakingState.NewMutableState(ctx.State())
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("staking: failed to load consensus parameters: %w", err)
 	}
-	if err = changes.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("staking: failed to validate consensus parameter changes: %w", err)
-	}
-	if err = changes.Apply(params); err != nil {
-		return nil, fmt.Errorf("staking: failed to apply consensus parameter changes: %w", err)
-	}
-	if err = params.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("staking: failed to validate consensus parameters: %w", err)
+	prevMinCommissionRate := params.CommissionScheduleRules.MinCommissionRate.Clone()
+	var changes staking.ConsensusParameterChanges
+	if err = governance.ApplyChangeParametersProposal(proposal, params, &changes, immutableParameters); err != nil {
+		return nil, fmt.Errorf("staking: %w", err)
 	}
 
 	// Do any necessary state migrations.
-	if changes.MinCommissionRate != nil && apply {
+	minCommissionRate := &params.CommissionScheduleRules.MinCommissionRate
+	minCommissionRateChanged := changes.MinCommissionRate != nil || minCommissionRate.Cmp(prevMinCommissionRate) != 0
+	if minCommissionRateChanged && apply {
 		var epoch beacon.EpochTime
 		epoch, err = ctx.AppState().GetCurrentEpoch(ctx)
 		if err != nil {
@@ -64,21 +63,21 @@ func (app *stakingApplication) changeParameters(ctx *api.Context, msg interface{
 			}
 			var updated bool
 			for i, bound := range acc.Escrow.CommissionSchedule.Bounds {
-				if changes.MinCommissionRate.Cmp(&bound.RateMin) > 0 { //nolint:gosec
+				if minCommissionRate.Cmp(&bound.RateMin) > 0 {
 					// Update the minimum rate bound, to be at least the minimum bound.
-					acc.Escrow.CommissionSchedule.Bounds[i].RateMin = *changes.MinCommissionRate.Clone()
+					acc.Escrow.CommissionSchedule.Bounds[i].RateMin = *minCommissionRate.Clone()
 					updated = true
 				}
-				if changes.MinCommissionRate.Cmp(&bound.RateMax) > 0 { //nolint:gosec
+				if minCommissionRate.Cmp(&bound.RateMax) > 0 {
 					// Update the maximum rate bound, to be at least the minimum bound.
-					acc.Escrow.CommissionSchedule.Bounds[i].RateMax = *changes.MinCommissionRate.Clone()
+					acc.Escrow.CommissionSchedule.Bounds[i].RateMax = *minCommissionRate.Clone()
 					updated = true
 				}
 			}
 			for i, rate := range acc.Escrow.CommissionSchedule.Rates {
-				if changes.MinCommissionRate.Cmp(&rate.Rate) > 0 { //nolint:gosec
+				if minCommissionRate.Cmp(&rate.Rate) > 0 {
 					// Update the rate, to be at least the minimum bound.
-					acc.Escrow.CommissionSchedule.Rates[i].Rate = *changes.MinCommissionRate.Clone()
+					acc.Escrow.CommissionSchedule.Rates[i].Rate = *minCommissionRate.Clone()
 					updated = true
 				}
 			}
@@ -164,6 +164,13 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 
 		_, err := app.transfer(ctx, state, &xfer)
 		return err
+	case staking.MethodTransferBatch:
+		var batch staking.TransferBatch
+		if err := cbor.Unmarshal(tx.Body, &batch); err != nil {
+			return staking.ErrInvalidArgument
+		}
+
+		return app.transferBatch(ctx, state, &batch)
 	case staking.MethodBurn:
 		var burn staking.Burn
 		if err := cbor.Unmarshal(tx.Body, &burn); err != nil {
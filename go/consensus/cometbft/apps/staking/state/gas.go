@@ -24,16 +24,21 @@ type feeAccumulator struct {
 	balance quantity.Quantity
 }
 
-// AuthenticateAndPayFees authenticates the message signer and makes sure that
-// any gas fees are paid.
+// AuthenticateAndPayFees authenticates the account of the message signer (either
+// a regular or a multisig account) and makes sure that any gas fees are paid.
+//
+// In case a fee payer is specified, the fees are paid by the fee payer instead
+// of the signer and the nonces of both accounts are checked and incremented.
+// The fee payer signature must have already been verified.
 //
 // This method transfers the fees to the per-block fee accumulator which is
 // persisted at the end of the block.
 func AuthenticateAndPayFees(
 	ctx *abciAPI.Context,
-	signer signature.PublicKey,
+	addr staking.Address,
 	nonce uint64,
 	fee *transaction.Fee,
+	feePayer *transaction.FeePayer,
 ) error {
 	state := NewMutableState(ctx.State())
 
@@ -45,8 +50,6 @@ func AuthenticateAndPayFees(
 		return nil
 	}
 
-	// Convert signer's public key to account address.
-	addr := staking.NewAddress(signer)
 	if addr.IsReserved() {
 		return fmt.Errorf("using reserved account address %s is prohibited", addr)
 	}
@@ -69,7 +72,32 @@ func AuthenticateAndPayFees(
 		fee = &transaction.Fee{}
 	}
 
-	// Account must have enough to pay fee and maintain minimum balance.
+	// Fees are paid by the fee payer, if any.
+	payerAddr, payerAccount := addr, account
+	if feePayer != nil {
+		payerAddr = staking.NewAddress(feePayer.PublicKey)
+		if payerAddr.IsReserved() {
+			return fmt.Errorf("using reserved account address %s is prohibited", payerAddr)
+		}
+		if payerAddr.Equal(addr) {
+			return transaction.ErrInvalidFeePayer
+		}
+
+		payerAccount, err = state.Account(ctx, payerAddr)
+		if err != nil {
+			return fmt.Errorf("failed to fetch fee payer account state: %w", err)
+		}
+		if payerAccount.General.Nonce != feePayer.Nonce {
+			logger.Error("invalid fee payer account nonce",
+				"account_addr", payerAddr,
+				"account_nonce", payerAccount.General.Nonce,
+				"nonce", feePayer.Nonce,
+			)
+			return transaction.ErrInvalidNonce
+		}
+	}
+
+	// Fee payer account must have enough to pay fee and maintain minimum balance.
 	needed := fee.Amount.Clone()
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
@@ -80,16 +108,26 @@ func AuthenticateAndPayFees(
 	}
 
 	// Check against minimum balance plus fee.
-	if account.General.Balance.Cmp(needed) < 0 {
+	if payerAccount.General.Balance.Cmp(needed) < 0 {
 		logger.Error("account balance too low",
-			"account_addr", addr,
-			"account_balance", account.General.Balance,
+			"account_addr", payerAddr,
+			"account_balance", payerAccount.General.Balance,
 			"min_transact_balance", params.MinTransactBalance,
 			"fee_amount", fee.Amount,
 		)
 		return staking.ErrBalanceTooLow
 	}
 
+	// Fees cannot be paid using tokens locked by a vesting schedule.
+	if payerAccount.General.Vesting != nil {
+		remaining := *payerAccount
+		remaining.General.Balance = *payerAccount.General.Balance.Clone()
+		_ = remaining.General.Balance.Sub(&fee.Amount) // Balance checked above.
+		if err = state.CheckVestingLocked(ctx, payerAddr, &remaining); err != nil {
+			return err
+		}
+	}
+
 	if ctx.IsCheckOnly() {
 		// Configure gas accountant on the context so that we can report gas wanted.
 		ctx.SetGasAccountant(abciAPI.NewGasAccountant(fee.Gas))
@@ -109,10 +147,17 @@ func AuthenticateAndPayFees(
 
 	// Transfer fee to per-block fee accumulator.
 	feeAcc := ctx.BlockContext().Get(feeAccumulatorKey{}).(*feeAccumulator)
-	if err = quantity.Move(&feeAcc.balance, &account.General.Balance, &fee.Amount); err != nil {
+	if err = quantity.Move(&feeAcc.balance, &payerAccount.General.Balance, &fee.Amount); err != nil {
 		return fmt.Errorf("staking: failed to pay fees: %w", err)
 	}
 
+	if feePayer != nil {
+		payerAccount.General.Nonce++
+		if err := state.SetAccount(ctx, payerAddr, payerAccount); err != nil {
+			return fmt.Errorf("failed to set fee payer account: %w", err)
+		}
+	}
+
 	account.General.Nonce++
 	if err := state.SetAccount(ctx, addr, account); err != nil {
 		return fmt.Errorf("failed to set account: %w", err)
@@ -121,7 +166,7 @@ func AuthenticateAndPayFees(
 	// Emit transfer event if fee is non-zero.
 	if !fee.Amount.IsZero() {
 		ctx.EmitEvent(abciAPI.NewEventBuilder(AppName).TypedAttribute(&staking.TransferEvent{
-			From:   addr,
+			From:   payerAddr,
 			To:     staking.FeeAccumulatorAddress,
 			Amount: fee.Amount,
 		}))
@@ -0,0 +1,103 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// delegatedStake returns the amount of stake that the given account has delegated, including
+// stake that is currently debonding.
+//
+// The passed account is used instead of the stored one for self-delegations so that any pending
+// modifications of the account are taken into account.
+func (s *ImmutableState) delegatedStake(ctx *abciAPI.Context, addr staking.Address, acct *staking.Account) (*quantity.Quantity, error) {
+	escrowAccount := func(escrowAddr staking.Address) (*staking.Account, error) {
+		if escrowAddr.Equal(addr) {
+			return acct, nil
+		}
+		return s.Account(ctx, escrowAddr)
+	}
+
+	total := quantity.NewQuantity()
+	dels, err := s.DelegationsFor(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch delegations: %w", err)
+	}
+	for escrowAddr, del := range dels {
+		var escrow *staking.Account
+		if escrow, err = escrowAccount(escrowAddr); err != nil {
+			return nil, fmt.Errorf("failed to fetch escrow account: %w", err)
+		}
+		var stake *quantity.Quantity
+		if stake, err = escrow.Escrow.Active.StakeForShares(&del.Shares); err != nil {
+			return nil, fmt.Errorf("failed to compute delegated stake: %w", err)
+		}
+		if err = total.Add(stake); err != nil {
+			return nil, err
+		}
+	}
+
+	debDels, err := s.DebondingDelegationsFor(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch debonding delegations: %w", err)
+	}
+	for escrowAddr, debs := range debDels {
+		var escrow *staking.Account
+		if escrow, err = escrowAccount(escrowAddr); err != nil {
+			return nil, fmt.Errorf("failed to fetch escrow account: %w", err)
+		}
+		for _, deb := range debs {
+			var stake *quantity.Quantity
+			if stake, err = escrow.Escrow.Debonding.StakeForShares(&deb.Shares); err != nil {
+				return nil, fmt.Errorf("failed to compute debonding stake: %w", err)
+			}
+			if err = total.Add(stake); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// CheckVestingLocked makes sure that the given account still covers the amount that is locked by
+// its vesting schedule at the epoch of the block being processed.
+//
+// Stake that the account has delegated (active or debonding) counts towards the locked amount as
+// it can only be returned to the general balance of the account.
+func (s *ImmutableState) CheckVestingLocked(ctx *abciAPI.Context, addr staking.Address, acct *staking.Account) error {
+	if acct.General.Vesting == nil {
+		return nil
+	}
+
+	epoch, err := ctx.AppState().GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return fmt.Errorf("failed to fetch current epoch: %w", err)
+	}
+
+	locked := acct.General.LockedBalance(epoch)
+	if acct.General.Balance.Cmp(locked) >= 0 {
+		return nil
+	}
+
+	available, err := s.delegatedStake(ctx, addr, acct)
+	if err != nil {
+		return err
+	}
+	if err = available.Add(&acct.General.Balance); err != nil {
+		return err
+	}
+	if available.Cmp(locked) < 0 {
+		ctx.Logger().Debug("account balance below vesting locked amount",
+			"account_addr", addr,
+			"account_balance", acct.General.Balance,
+			"available", available,
+			"locked", locked,
+		)
+		return staking.ErrVestingLocked
+	}
+	return nil
+}
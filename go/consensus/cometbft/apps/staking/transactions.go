@@ -74,6 +74,156 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 	}, nil
 }
 
+func (app *stakingApplication) transferBatch(ctx *api.Context, state *stakingState.MutableState, batch *staking.TransferBatch) error {
+	if len(batch.Transfers) == 0 {
+		return staking.ErrInvalidArgument
+	}
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if params.MaxBatchTransfers == 0 {
+		return staking.ErrForbidden
+	}
+	if uint64(len(batch.Transfers)) > uint64(params.MaxBatchTransfers) {
+		return staking.ErrInvalidArgument
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction (per transfer in the batch). In case no separate cost is
+	// configured for batched transfers, use the cost of a regular transfer.
+	gasOp := staking.GasOpTransferBatch
+	if _, ok := params.GasCosts[gasOp]; !ok {
+		gasOp = staking.GasOpTransfer
+	}
+	if err = ctx.Gas().UseGas(len(batch.Transfers), gasOp, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	fromAddr := ctx.CallerAddress()
+	if fromAddr.IsReserved() || !isTransferPermitted(params, fromAddr) {
+		return staking.ErrForbidden
+	}
+
+	// Apply all transfers to the loaded accounts first and only update state after all of them
+	// succeeded so that either all or none of them are applied.
+	from, err := state.Account(ctx, fromAddr)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %w", err)
+	}
+	var (
+		recipients []staking.Address
+		accounts   = make(map[staking.Address]*staking.Account)
+		burned     quantity.Quantity
+	)
+	for i := range batch.Transfers {
+		xfer := &batch.Transfers[i]
+
+		// Check if sender provided at least a minimum amount.
+		if xfer.Amount.Cmp(&params.MinTransferAmount) < 0 {
+			return errors.WithContext(staking.ErrUnderMinTransferAmount, fmt.Sprintf("transfer %d", i))
+		}
+
+		switch {
+		case xfer.To.Equal(staking.BurnAddress):
+			if err = from.General.Balance.Sub(&xfer.Amount); err != nil {
+				return errors.WithContext(err, fmt.Sprintf("transfer %d", i))
+			}
+			_ = burned.Add(&xfer.Amount)
+		case xfer.To.Equal(fromAddr):
+			// Handle transfer to self as just a balance check.
+			if from.General.Balance.Cmp(&xfer.Amount) < 0 {
+				return errors.WithContext(staking.ErrInsufficientBalance, fmt.Sprintf("transfer %d", i))
+			}
+		default:
+			to, ok := accounts[xfer.To]
+			if !ok {
+				if to, err = state.Account(ctx, xfer.To); err != nil {
+					return fmt.Errorf("failed to fetch account: %w", err)
+				}
+				accounts[xfer.To] = to
+				recipients = append(recipients, xfer.To)
+			}
+			if err = quantity.Move(&to.General.Balance, &from.General.Balance, &xfer.Amount); err != nil {
+				return errors.WithContext(err, fmt.Sprintf("transfer %d", i))
+			}
+		}
+	}
+
+	// Check against minimum balance.
+	if from.General.Balance.Cmp(&params.MinTransactBalance) < 0 {
+		ctx.Logger().Debug("after transfer batch source account balance too low",
+			"account_addr", fromAddr,
+			"account_balance", from.General.Balance,
+			"min_transact_balance", params.MinTransactBalance,
+		)
+		return errors.WithContext(staking.ErrBalanceTooLow, "source account")
+	}
+	for _, addr := range recipients {
+		if to := accounts[addr]; to.General.Balance.Cmp(&params.MinTransactBalance) < 0 {
+			ctx.Logger().Debug("after transfer batch dest account balance too low",
+				"account_addr", addr,
+				"account_balance", to.General.Balance,
+				"min_transact_balance", params.MinTransactBalance,
+			)
+			return errors.WithContext(staking.ErrBalanceTooLow, "dest account")
+		}
+	}
+	if err = state.CheckVestingLocked(ctx, fromAddr, from); err != nil {
+		return err
+	}
+
+	for _, addr := range recipients {
+		if err = state.SetAccount(ctx, addr, accounts[addr]); err != nil {
+			return fmt.Errorf("failed to set account: %w", err)
+		}
+	}
+	if err = state.SetAccount(ctx, fromAddr, from); err != nil {
+		return fmt.Errorf("failed to set account: %w", err)
+	}
+	if !burned.IsZero() {
+		var totalSupply *quantity.Quantity
+		if totalSupply, err = state.TotalSupply(ctx); err != nil {
+			return fmt.Errorf("failed to fetch total supply: %w", err)
+		}
+		_ = totalSupply.Sub(&burned)
+		if err = state.SetTotalSupply(ctx, totalSupply); err != nil {
+			return fmt.Errorf("failed to set total supply: %w", err)
+		}
+	}
+
+	for i := range batch.Transfers {
+		xfer := &batch.Transfers[i]
+		if xfer.To.Equal(staking.BurnAddress) {
+			ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.BurnEvent{
+				Owner:  fromAddr,
+				Amount: xfer.Amount,
+			}))
+		}
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.TransferEvent{
+			From:   fromAddr,
+			To:     xfer.To,
+			Amount: xfer.Amount,
+		}))
+	}
+
+	ctx.Logger().Debug("TransferBatch: executed batched transfer",
+		"from", fromAddr,
+		"transfers", len(batch.Transfers),
+	)
+
+	return nil
+}
+
 func (app *stakingApplication) transferImpl(
 	ctx *api.Context,
 	state *stakingState.MutableState,
@@ -147,6 +297,9 @@ func (app *stakingApplication) transferImpl(
 			)
 			return errors.WithContext(staking.ErrBalanceTooLow, "dest account")
 		}
+		if err = state.CheckVestingLocked(ctx, fromAddr, from); err != nil {
+			return err
+		}
 
 		if err = state.SetAccount(ctx, xfer.To, to); err != nil {
 			return fmt.Errorf("failed to set account: %w", err)
@@ -227,6 +380,9 @@ func (app *stakingApplication) burnImpl(
 		)
 		return staking.ErrBalanceTooLow
 	}
+	if err = state.CheckVestingLocked(ctx, fromAddr, from); err != nil {
+		return err
+	}
 
 	totalSupply, err := state.TotalSupply(ctx)
 	if err != nil {
@@ -805,6 +961,9 @@ func (app *stakingApplication) withdraw(
 		)
 		return nil, errors.WithContext(staking.ErrBalanceTooLow, "source account")
 	}
+	if err = state.CheckVestingLocked(ctx, withdraw.From, from); err != nil {
+		return nil, err
+	}
 	if to.General.Balance.Cmp(&params.MinTransactBalance) < 0 {
 		ctx.Logger().Debug("after withdraw dest account balance too low",
 			"account_addr", toAddr,
@@ -1,6 +1,7 @@
 package staking
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,6 +9,7 @@ import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -966,6 +968,215 @@ func TestTransfer(t *testing.T) {
 	}
 }
 
+func TestTransferBatch(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount1")
+
+	requireBalance := func(addr staking.Address, expected uint64) {
+		acct, aerr := stakeState.Account(ctx, addr)
+		require.NoError(aerr, "Account")
+		require.EqualValues(*quantity.NewFromUint64(expected), acct.General.Balance, "account balance should be correct")
+	}
+
+	// Batches should be rejected when disabled.
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinTransferAmount: *quantity.NewFromUint64(1000),
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk1)
+
+	err = app.transferBatch(txCtx, stakeState, &staking.TransferBatch{
+		Transfers: []staking.Transfer{
+			{To: addr2, Amount: *quantity.NewFromUint64(10_000)},
+		},
+	})
+	require.ErrorIs(err, staking.ErrForbidden, "batch should fail when disabled")
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinTransferAmount: *quantity.NewFromUint64(1000),
+		MaxBatchTransfers: 3,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	// Empty batches should be rejected.
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk1)
+
+	err = app.transferBatch(txCtx, stakeState, &staking.TransferBatch{})
+	require.ErrorIs(err, staking.ErrInvalidArgument, "empty batch should fail")
+
+	// Batches with too many transfers should be rejected, also in CheckTx.
+	tooLarge := &staking.TransferBatch{
+		Transfers: []staking.Transfer{
+			{To: addr2, Amount: *quantity.NewFromUint64(1_000)},
+			{To: addr2, Amount: *quantity.NewFromUint64(1_000)},
+			{To: addr2, Amount: *quantity.NewFromUint64(1_000)},
+			{To: addr2, Amount: *quantity.NewFromUint64(1_000)},
+		},
+	}
+	checkCtx := appState.NewContext(abciAPI.ContextCheckTx)
+	defer checkCtx.Close()
+	checkCtx.SetTxSigner(pk1)
+
+	err = app.transferBatch(checkCtx, stakeState, tooLarge)
+	require.ErrorIs(err, staking.ErrInvalidArgument, "batch with too many transfers should fail in CheckTx")
+
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk1)
+
+	err = app.transferBatch(txCtx, stakeState, tooLarge)
+	require.ErrorIs(err, staking.ErrInvalidArgument, "batch with too many transfers should fail")
+	requireBalance(addr1, 100_000)
+
+	// Batches should be executed atomically.
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk1)
+
+	err = app.transferBatch(txCtx, stakeState, &staking.TransferBatch{
+		Transfers: []staking.Transfer{
+			{To: addr2, Amount: *quantity.NewFromUint64(10_000)},
+			{To: addr3, Amount: *quantity.NewFromUint64(999)},
+		},
+	})
+	require.ErrorIs(err, staking.ErrUnderMinTransferAmount, "batch with an invalid transfer should fail")
+	require.Empty(txCtx.GetEvents(), "failed batch should not emit any events")
+	requireBalance(addr1, 100_000)
+	requireBalance(addr2, 0)
+	requireBalance(addr3, 0)
+
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk1)
+
+	err = app.transferBatch(txCtx, stakeState, &staking.TransferBatch{
+		Transfers: []staking.Transfer{
+			{To: addr2, Amount: *quantity.NewFromUint64(10_000)},
+			{To: addr3, Amount: *quantity.NewFromUint64(90_001)},
+		},
+	})
+	require.ErrorIs(err, quantity.ErrInsufficientBalance, "batch exceeding the balance should fail")
+	requireBalance(addr1, 100_000)
+	requireBalance(addr2, 0)
+
+	// A valid batch should emit an event per recipient.
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk1)
+
+	batch := &staking.TransferBatch{
+		Transfers: []staking.Transfer{
+			{To: addr2, Amount: *quantity.NewFromUint64(10_000)},
+			{To: addr3, Amount: *quantity.NewFromUint64(20_000)},
+			{To: addr2, Amount: *quantity.NewFromUint64(1_000)},
+		},
+	}
+	err = app.transferBatch(txCtx, stakeState, batch)
+	require.NoError(err, "valid batch should succeed")
+	requireBalance(addr1, 69_000)
+	requireBalance(addr2, 11_000)
+	requireBalance(addr3, 20_000)
+
+	require.Len(txCtx.GetEvents(), len(batch.Transfers), "an event should be emitted per transfer")
+	for i, xfer := range batch.Transfers {
+		var ev staking.TransferEvent
+		err = txCtx.DecodeEvent(i, &ev)
+		require.NoError(err, "DecodeEvent")
+		require.Equal(addr1, ev.From, "event source should be correct")
+		require.Equal(xfer.To, ev.To, "event destination should be correct")
+		require.Equal(xfer.Amount, ev.Amount, "event amount should be correct")
+	}
+
+	// Batches should be able to burn.
+	err = stakeState.SetTotalSupply(ctx, quantity.NewFromUint64(100_000))
+	require.NoError(err, "SetTotalSupply")
+
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk1)
+
+	err = app.transferBatch(txCtx, stakeState, &staking.TransferBatch{
+		Transfers: []staking.Transfer{
+			{To: addr2, Amount: *quantity.NewFromUint64(1_000)},
+			{To: staking.BurnAddress, Amount: *quantity.NewFromUint64(5_000)},
+		},
+	})
+	require.NoError(err, "batch with a burn should succeed")
+	requireBalance(addr1, 63_000)
+	requireBalance(addr2, 12_000)
+	totalSupply, err := stakeState.TotalSupply(ctx)
+	require.NoError(err, "TotalSupply")
+	require.EqualValues(*quantity.NewFromUint64(95_000), *totalSupply, "total supply should be reduced")
+
+	// Gas should be charged per transfer, using the transfer cost unless a batch cost is set.
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinTransferAmount: *quantity.NewFromUint64(1000),
+		MaxBatchTransfers: 3,
+		GasCosts: transaction.Costs{
+			staking.GasOpTransfer: 10,
+		},
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	chargeGas := func() transaction.Gas {
+		txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk1)
+		txCtx.SetGasAccountant(abciAPI.NewGasAccountant(transaction.Gas(math.MaxUint64)))
+
+		err = app.transferBatch(txCtx, stakeState, &staking.TransferBatch{
+			Transfers: []staking.Transfer{
+				{To: addr2, Amount: *quantity.NewFromUint64(1_000)},
+				{To: addr3, Amount: *quantity.NewFromUint64(1_000)},
+				{To: addr2, Amount: *quantity.NewFromUint64(1_000)},
+			},
+		})
+		require.NoError(err, "transferBatch")
+		return txCtx.Gas().GasUsed()
+	}
+	require.EqualValues(30, chargeGas(), "transfer cost should be used without a batch cost")
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinTransferAmount: *quantity.NewFromUint64(1000),
+		MaxBatchTransfers: 3,
+		GasCosts: transaction.Costs{
+			staking.GasOpTransfer:      10,
+			staking.GasOpTransferBatch: 5,
+		},
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+	require.EqualValues(15, chargeGas(), "batch cost should be used when set")
+}
+
 func TestBurn(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -1052,6 +1263,131 @@ func TestBurn(t *testing.T) {
 	}
 }
 
+func TestVesting(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	cfg := &abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+			Vesting: &staking.VestingSchedule{
+				Amount: *quantity.NewFromUint64(80_000),
+				Start:  10,
+				Cliff:  10,
+				End:    20,
+			},
+		},
+	})
+	require.NoError(err, "SetAccount1")
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	newTxCtx := func() *abciAPI.Context {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		txCtx.SetTxSigner(pk1)
+		return txCtx
+	}
+
+	// Before the cliff, only the non-vesting part of the balance can be spent.
+	txCtx := newTxCtx()
+	defer txCtx.Close()
+	_, err = app.transfer(txCtx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(20_001)})
+	require.ErrorIs(err, staking.ErrVestingLocked, "transferring locked tokens should fail")
+
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	err = app.burn(txCtx, stakeState, &staking.Burn{Amount: *quantity.NewFromUint64(20_001)})
+	require.ErrorIs(err, staking.ErrVestingLocked, "burning locked tokens should fail")
+
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	_, err = app.transfer(txCtx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10_000)})
+	require.NoError(err, "transferring unlocked tokens should succeed")
+
+	// Locked tokens can still be escrowed.
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	_, err = app.addEscrow(txCtx, stakeState, &staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(50_000)})
+	require.NoError(err, "escrowing locked tokens should succeed")
+
+	// Escrowed tokens count towards the locked amount.
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	_, err = app.transfer(txCtx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10_001)})
+	require.ErrorIs(err, staking.ErrVestingLocked, "transferring locked tokens should fail with escrowed tokens")
+
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	_, err = app.transfer(txCtx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10_000)})
+	require.NoError(err, "transferring unlocked tokens should succeed with escrowed tokens")
+
+	// Debonding tokens also count towards the locked amount.
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval: 10,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	_, err = app.reclaimEscrow(txCtx, stakeState, &staking.ReclaimEscrow{Account: addr2, Shares: *quantity.NewFromUint64(20_000)})
+	require.NoError(err, "reclaiming escrowed tokens should succeed")
+
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	_, err = app.transfer(txCtx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(1)})
+	require.ErrorIs(err, staking.ErrVestingLocked, "transferring locked tokens should fail with debonding tokens")
+
+	// Fees cannot be paid using locked tokens.
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	err = stakingState.AuthenticateAndPayFees(txCtx, addr1, 0, &transaction.Fee{Amount: *quantity.NewFromUint64(1)}, nil)
+	require.ErrorIs(err, staking.ErrVestingLocked, "paying fees with locked tokens should fail")
+
+	// After the cliff, tokens are gradually unlocked.
+	cfg.CurrentEpoch = 15
+	appState.UpdateMockApplicationStateConfig(cfg)
+
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	err = stakingState.AuthenticateAndPayFees(txCtx, addr1, 0, &transaction.Fee{Amount: *quantity.NewFromUint64(1_000)}, nil)
+	require.NoError(err, "paying fees with unlocked tokens should succeed")
+
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	_, err = app.transfer(txCtx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(1_000)})
+	require.NoError(err, "transferring unlocked tokens should succeed")
+
+	// After the end epoch, everything is unlocked.
+	cfg.CurrentEpoch = 20
+	appState.UpdateMockApplicationStateConfig(cfg)
+
+	txCtx = newTxCtx()
+	defer txCtx.Close()
+	_, err = app.transfer(txCtx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(28_000)})
+	require.NoError(err, "transferring fully vested tokens should succeed")
+
+	acct, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewQuantity(), acct.General.Balance, "general balance should be fully spent")
+}
+
 func TestAmendCommissionSchedule(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -1,11 +1,157 @@
 package vault
 
 import (
+	"fmt"
+	"slices"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	vaultState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/vault/state"
 	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
 )
 
+// executePendingAction executes a given pending action that has been fully authorized, removes it
+// from the list of pending actions and advances the vault nonce.
+func (app *vaultApplication) executePendingAction(ctx *api.Context, vlt *vault.Vault, pendingAction *vault.PendingAction) error {
+	evExec := &vault.ActionExecutedEvent{
+		Vault: vlt.Address(),
+		Nonce: pendingAction.Nonce,
+	}
+	err := app.executeAction(ctx, vlt, &pendingAction.Action)
+	switch {
+	case api.IsUnavailableStateError(err):
+		// Propagate state unavailability errors.
+		return err
+	default:
+		// Record other errors (or success) in the execution event.
+		evExec.Result.Module, evExec.Result.Code = errors.Code(err)
+
+		ctx.Logger().Debug("vault executed action",
+			"err", err,
+			"vault", evExec.Vault,
+			"nonce", pendingAction.Nonce,
+			"action", pendingAction.Action,
+		)
+	}
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(evExec))
+
+	// Remove pending action as it has been executed.
+	state := vaultState.NewMutableState(ctx.State())
+	if err = state.RemovePendingAction(ctx, evExec.Vault, pendingAction.Nonce); err != nil {
+		return err
+	}
+
+	vlt.Nonce++
+	return state.SetVault(ctx, vlt)
+}
+
+// cancelPendingAction cancels a given pending action, removes it from the list of pending actions
+// and advances the vault nonce.
+func (app *vaultApplication) cancelPendingAction(ctx *api.Context, vlt *vault.Vault, pendingAction *vault.PendingAction) error {
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&vault.ActionCanceledEvent{
+		Vault: vlt.Address(),
+		Nonce: pendingAction.Nonce,
+	}))
+
+	state := vaultState.NewMutableState(ctx.State())
+	if err := state.RemovePendingAction(ctx, vlt.Address(), pendingAction.Nonce); err != nil {
+		return err
+	}
+
+	vlt.Nonce++
+	return state.SetVault(ctx, vlt)
+}
+
+// authorizeQueuedSuspend records a suspend authorization for a vault with a queued action. As the
+// queued action holds the nonce, suspend authorizations are recorded separately and the queued
+// action is only canceled once the suspend authority threshold is reached and the vault is
+// suspended.
+func (app *vaultApplication) authorizeQueuedSuspend(ctx *api.Context, vlt *vault.Vault, pendingAction *vault.PendingAction) error {
+	// Only an immediately executable suspend by the suspend authority can preempt queued actions.
+	caller := ctx.CallerAddress()
+	if !vlt.SuspendAuthority.Contains(caller) || vlt.SuspendAuthority.Delay > 0 {
+		return vault.ErrActionQueued
+	}
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&vault.ActionSubmittedEvent{
+		Submitter: caller,
+		Vault:     vlt.Address(),
+		Nonce:     pendingAction.Nonce,
+	}))
+
+	// Update list of suspend authorizers.
+	if !slices.Contains(pendingAction.SuspendAuthorizedBy, caller) {
+		pendingAction.SuspendAuthorizedBy = append(pendingAction.SuspendAuthorizedBy, caller)
+		state := vaultState.NewMutableState(ctx.State())
+		if err := state.SetPendingAction(ctx, vlt.Address(), pendingAction); err != nil {
+			return err
+		}
+	}
+
+	// Check if suspend has become executable.
+	if !vlt.SuspendAuthority.Verify(pendingAction.SuspendAuthorizedBy) {
+		return nil
+	}
+
+	if err := app.cancelPendingAction(ctx, vlt, pendingAction); err != nil {
+		return err
+	}
+	return app.executePendingAction(ctx, vlt, &vault.PendingAction{
+		Nonce:        vlt.Nonce,
+		AuthorizedBy: pendingAction.SuspendAuthorizedBy,
+		Action:       vault.Action{Suspend: &vault.ActionSuspend{}},
+	})
+}
+
+// executeDueActions executes all queued actions whose execution delay has expired.
+func (app *vaultApplication) executeDueActions(ctx *api.Context, epoch beacon.EpochTime) error {
+	state := vaultState.NewMutableState(ctx.State())
+	dueActions, err := state.DueActions(ctx, epoch)
+	if err != nil {
+		return fmt.Errorf("failed to fetch due actions: %w", err)
+	}
+
+	for _, qa := range dueActions {
+		if err = app.executeQueuedAction(ctx, qa); err != nil {
+			return fmt.Errorf("failed to execute queued action: %w", err)
+		}
+	}
+	return nil
+}
+
+func (app *vaultApplication) executeQueuedAction(ctx *api.Context, qa *vaultState.QueuedAction) error {
+	// Start a new transaction and rollback in case we fail.
+	ctx = ctx.NewTransaction()
+	defer ctx.Close()
+
+	state := vaultState.NewMutableState(ctx.State())
+	vlt, err := state.Vault(ctx, qa.Vault)
+	if err != nil {
+		return err
+	}
+	pendingAction, err := state.PendingAction(ctx, qa.Vault, qa.Nonce)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case vlt.IsActive(), pendingAction.Action.Suspend != nil, pendingAction.Action.Resume != nil:
+		err = app.executePendingAction(ctx, vlt, pendingAction)
+	default:
+		// Do not execute queued actions of suspended vaults, cancel them instead.
+		err = app.cancelPendingAction(ctx, vlt, pendingAction)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx.Commit()
+
+	return nil
+}
+
 // executeAction executes a given action in the context of a vault. Assumes the action has already
 // been validated before execution.
 func (app *vaultApplication) executeAction(ctx *api.Context, vlt *vault.Vault, action *vault.Action) error {
@@ -74,14 +220,35 @@ func (app *vaultApplication) executeAction(ctx *api.Context, vlt *vault.Vault, a
 		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&vault.AuthorityUpdatedEvent{
 			Vault: vlt.Address(),
 		}))
+	case action.UpdateSpendingPolicy != nil:
+		// Update the vault spending policy.
+		action.UpdateSpendingPolicy.Apply(vlt)
+
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&vault.SpendingPolicyUpdatedEvent{
+			Vault: vlt.Address(),
+		}))
 	case action.ExecuteMessage != nil:
+		// Ensure the message still complies with the spending policy as the policy or the
+		// spending accounting state may have changed since the action was authorized.
+		epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+		if err != nil {
+			return fmt.Errorf("failed to get current epoch: %w", err)
+		}
+		if err = vlt.AuthorizeSpending(action.ExecuteMessage, epoch, false); err != nil {
+			return err
+		}
+
 		// Execute a message with vault as the caller.
-		_, err := app.md.Publish(ctx, api.MessageExecuteSubcall, &api.SubcallInfo{
+		if _, err = app.md.Publish(ctx, api.MessageExecuteSubcall, &api.SubcallInfo{
 			Caller: vlt.Address(),
 			Method: action.ExecuteMessage.Method,
 			Body:   action.ExecuteMessage.Body,
-		})
-		return err
+		}); err != nil {
+			return err
+		}
+
+		// Account for the spending.
+		return vlt.AuthorizeSpending(action.ExecuteMessage, epoch, true)
 	default:
 		return vault.ErrUnsupportedAction
 	}
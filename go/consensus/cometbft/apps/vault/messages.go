@@ -3,7 +3,6 @@ package vault
 import (
 	"fmt"
 
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/api"
 	vaultState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/vault/state"
@@ -23,25 +22,15 @@ func (app *vaultApplication) changeParameters(ctx *api.Context, msg interface{},
 		return nil, nil
 	}
 
-	var changes vault.ConsensusParameterChanges
-	if err := cbor.Unmarshal(proposal.Changes, &changes); err != nil {
-		return nil, fmt.Errorf("cometbft/vault: failed to unmarshal consensus parameter changes: %w", err)
-	}
-
 	// Validate changes against current parameters.
 	state := vaultState.NewMutableState(ctx.State())
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cometbft/vault: failed to load consensus parameters: %w", err)
 	}
-	if err = changes.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("cometbft/vault: failed to validate consensus parameter changes: %w", err)
-	}
-	if err = changes.Apply(params); err != nil {
-		return nil, fmt.Errorf("cometbft/vault: failed to apply consensus parameter changes: %w", err)
-	}
-	if err = params.SanityCheck(); err != nil {
-		return nil, fmt.Errorf("cometbft/vault: failed to validate consensus parameters: %w", err)
+	var changes vault.ConsensusParameterChanges
+	if err = governance.ApplyChangeParametersProposal(proposal, params, &changes, nil); err != nil {
+		return nil, fmt.Errorf("cometbft/vault: %w", err)
 	}
 
 	// Apply changes.
@@ -86,6 +75,11 @@ func (app *vaultApplication) invokeAccountHook(ctx *api.Context, msg interface{}
 			return nil, vault.ErrForbidden
 		}
 
+		// Withdrawals must also comply with the vault spending policy.
+		if err = vlt.AuthorizeWithdrawal(hi.To); err != nil {
+			return nil, err
+		}
+
 		// Update address state.
 		if err = state.SetAddressState(ctx, hi.From, hi.To, as); err != nil {
 			return nil, err
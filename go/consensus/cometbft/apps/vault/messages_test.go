@@ -0,0 +1,93 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	stakingApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/api"
+	vaultState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/vault/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
+)
+
+func TestWithdrawHookSpendingPolicy(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	app := &vaultApplication{
+		state: appState,
+		md:    &testMsgDispatcher{},
+	}
+
+	state := vaultState.NewMutableState(ctx.State())
+	err := state.SetConsensusParameters(ctx, &vault.ConsensusParameters{
+		MaxAuthorityAddresses: 32,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	ctx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer ctx.Close()
+
+	// Create a vault with a spending policy.
+	err = app.create(ctx, &vault.Create{
+		AdminAuthority: vault.Authority{
+			Addresses: []staking.Address{testAddrA},
+			Threshold: 1,
+		},
+		SuspendAuthority: vault.Authority{
+			Addresses: []staking.Address{testAddrC},
+			Threshold: 1,
+		},
+		SpendingPolicy: &vault.SpendingPolicy{
+			AllowedDestinations: []staking.Address{testAddrD},
+			TotalLimit: &vault.SpendingLimit{
+				LimitAmount:   *quantity.NewFromUint64(100),
+				LimitInterval: 10,
+			},
+		},
+	})
+	require.NoError(err, "create")
+	vaultAddr := vault.NewVaultAddress(ctx.CallerAddress(), 0)
+
+	// Configure generous withdraw policies.
+	for _, addr := range []staking.Address{testAddrD, testAddrE} {
+		err = state.SetAddressState(ctx, vaultAddr, addr, &vault.AddressState{
+			WithdrawPolicy: vault.WithdrawPolicy{
+				LimitAmount:   *quantity.NewFromUint64(1000),
+				LimitInterval: 1,
+			},
+		})
+		require.NoError(err, "SetAddressState")
+	}
+
+	withdraw := func(to staking.Address, amount uint64) error {
+		_, err = app.invokeAccountHook(ctx, &stakingApi.WithdrawHookInvocation{
+			Destination: staking.HookDestination{Module: vault.ModuleName},
+			From:        vaultAddr,
+			To:          to,
+			Amount:      quantity.NewFromUint64(amount),
+		})
+		return err
+	}
+
+	// Withdrawals to destinations that are not allowed should be rejected.
+	err = withdraw(testAddrE, 10)
+	require.ErrorIs(err, vault.ErrSpendingPolicyViolation, "withdrawal to a disallowed destination should fail")
+
+	// Withdrawals should not count towards spending limits as they are accounted for when the
+	// allowance is set.
+	require.NoError(withdraw(testAddrD, 60), "withdrawal should succeed")
+	require.NoError(withdraw(testAddrD, 50), "withdrawal should succeed")
+
+	vlt, err := state.Vault(ctx, vaultAddr)
+	require.NoError(err, "Vault")
+	require.Nil(vlt.SpendingAccounts, "withdrawals should not be accounted for")
+}
@@ -3,6 +3,7 @@ package state
 import (
 	"context"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
@@ -32,8 +33,22 @@ var (
 	//
 	// Value is CBOR-serialized vault.ConsensusParameters.
 	parametersKeyFmt = consensus.KeyFormat.New(0x33)
+
+	// queuedActionsKeyFmt is the key format used for indexing pending actions queued for delayed
+	// execution by their execution epoch.
+	//
+	// Key format is: 0x34 <execute-at-epoch (uint64)> <vault-address (staking.Address)> <nonce (uint64)>.
+	queuedActionsKeyFmt = consensus.KeyFormat.New(0x34, uint64(0), &staking.Address{}, uint64(0))
 )
 
+// QueuedAction is a reference to a pending action queued for delayed execution.
+type QueuedAction struct {
+	// Vault is the vault address.
+	Vault staking.Address
+	// Nonce is the action nonce.
+	Nonce uint64
+}
+
 // ImmutableState is the immutable consensus state wrapper.
 type ImmutableState struct {
 	is *api.ImmutableState
@@ -170,6 +185,32 @@ func (s *ImmutableState) PendingActions(ctx context.Context, vaultAddr staking.A
 	return actions, nil
 }
 
+// DueActions returns references to all queued actions that should be executed at or before the
+// given epoch, ordered by their execution epoch.
+func (s *ImmutableState) DueActions(ctx context.Context, epoch beacon.EpochTime) ([]*QueuedAction, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var actions []*QueuedAction
+	for it.Seek(queuedActionsKeyFmt.Encode()); it.Valid(); it.Next() {
+		var (
+			executeAt uint64
+			qa        QueuedAction
+		)
+		if !queuedActionsKeyFmt.Decode(it.Key(), &executeAt, &qa.Vault, &qa.Nonce) {
+			break
+		}
+		if beacon.EpochTime(executeAt) > epoch {
+			break
+		}
+		actions = append(actions, &qa)
+	}
+	if it.Err() != nil {
+		return nil, api.UnavailableStateError(it.Err())
+	}
+	return actions, nil
+}
+
 // ConsensusParameters returns the vault consensus parameters.
 func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*vault.ConsensusParameters, error) {
 	raw, err := s.is.Get(ctx, parametersKeyFmt.Encode())
@@ -244,14 +285,38 @@ func (s *MutableState) SetAddressState(ctx context.Context, vaultAddr staking.Ad
 }
 
 // SetPendingAction updates the pending action.
+//
+// In case the action is queued for delayed execution, it is also added to the queue.
 func (s *MutableState) SetPendingAction(ctx context.Context, vaultAddr staking.Address, action *vault.PendingAction) error {
-	err := s.ms.Insert(ctx, pendingActionsKeyFmt.Encode(vaultAddr, action.Nonce), cbor.Marshal(action))
+	if err := s.ms.Insert(ctx, pendingActionsKeyFmt.Encode(vaultAddr, action.Nonce), cbor.Marshal(action)); err != nil {
+		return api.UnavailableStateError(err)
+	}
+	if !action.IsQueued() {
+		return nil
+	}
+	err := s.ms.Insert(ctx, queuedActionsKeyFmt.Encode(uint64(action.ExecuteAt), vaultAddr, action.Nonce), []byte(""))
 	return api.UnavailableStateError(err)
 }
 
 // RemovePendingAction removes the pending action with the given nonce.
+//
+// In case the action is queued for delayed execution, it is also removed from the queue.
 func (s *MutableState) RemovePendingAction(ctx context.Context, vaultAddr staking.Address, nonce uint64) error {
-	err := s.ms.Remove(ctx, pendingActionsKeyFmt.Encode(vaultAddr, nonce))
+	action, err := s.PendingAction(ctx, vaultAddr, nonce)
+	switch err {
+	case nil:
+		if action.IsQueued() {
+			err = s.ms.Remove(ctx, queuedActionsKeyFmt.Encode(uint64(action.ExecuteAt), vaultAddr, nonce))
+			if err != nil {
+				return api.UnavailableStateError(err)
+			}
+		}
+	case vault.ErrNoSuchAction:
+	default:
+		return err
+	}
+
+	err = s.ms.Remove(ctx, pendingActionsKeyFmt.Encode(vaultAddr, nonce))
 	return api.UnavailableStateError(err)
 }
 
@@ -3,7 +3,7 @@ package vault
 import (
 	"fmt"
 
-	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	vaultState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/vault/state"
@@ -46,14 +46,26 @@ func (app *vaultApplication) create(ctx *api.Context, create *vault.Create) erro
 		return err
 	}
 
+	vaultID := callerAcct.General.Nonce
+
+	// Runtimes do not submit transactions so their account nonce needs to be advanced here to
+	// ensure each created vault gets a unique identifier.
+	if ctx.IsMessageExecution() {
+		callerAcct.General.Nonce++
+		if err = stakeState.SetAccount(ctx, ctx.CallerAddress(), callerAcct); err != nil {
+			return err
+		}
+	}
+
 	// Create a new vault.
 	newVault := &vault.Vault{
 		State:            vault.StateActive,
 		Nonce:            0,
 		Creator:          ctx.CallerAddress(),
-		ID:               callerAcct.General.Nonce,
+		ID:               vaultID,
 		AdminAuthority:   create.AdminAuthority,
 		SuspendAuthority: create.SuspendAuthority,
+		SpendingPolicy:   create.SpendingPolicy,
 	}
 	if err = state.CreateVault(ctx, newVault); err != nil {
 		return err
@@ -91,6 +103,18 @@ func (app *vaultApplication) authorizeAction(ctx *api.Context, authAction *vault
 		return vault.ErrForbidden
 	}
 
+	// Reject messages that do not comply with the vault spending policy.
+	if am := authAction.Action.ExecuteMessage; am != nil && vlt.SpendingPolicy != nil {
+		var epoch beacon.EpochTime
+		epoch, err = app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+		if err != nil {
+			return fmt.Errorf("failed to get current epoch: %w", err)
+		}
+		if err = vlt.AuthorizeSpending(am, epoch, false); err != nil {
+			return err
+		}
+	}
+
 	if ctx.IsCheckOnly() {
 		return nil
 	}
@@ -107,6 +131,16 @@ func (app *vaultApplication) authorizeAction(ctx *api.Context, authAction *vault
 	// Check if there is an existing pending action for this nonce. In this case, we will be
 	// updating the action.
 	pendingAction, err := state.PendingAction(ctx, authAction.Vault, authAction.Nonce)
+	if err == nil && pendingAction.IsQueued() && authAction.Action.Suspend != nil && pendingAction.Action.Suspend == nil {
+		// The suspend authority must be able to suspend the vault even when another action is
+		// waiting for execution.
+		if err = app.authorizeQueuedSuspend(ctx, vlt, pendingAction); err != nil {
+			return err
+		}
+
+		ctx.Commit()
+		return nil
+	}
 	switch err {
 	case nil:
 		// Ensure that the action is the same so that the authorizer really signed the correct
@@ -114,10 +148,14 @@ func (app *vaultApplication) authorizeAction(ctx *api.Context, authAction *vault
 		if !pendingAction.Action.Equal(&authAction.Action) {
 			return vault.ErrInvalidArgument
 		}
+		// Ensure that the action is not already waiting for execution.
+		if pendingAction.IsQueued() {
+			return vault.ErrActionQueued
+		}
 	case vault.ErrNoSuchAction:
 		// Create a new pending action.
 		pendingAction = &vault.PendingAction{
-			Nonce:  authAction.Nonce,
+			Nonce:  vlt.Nonce,
 			Action: authAction.Action,
 		}
 	default:
@@ -127,7 +165,7 @@ func (app *vaultApplication) authorizeAction(ctx *api.Context, authAction *vault
 	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&vault.ActionSubmittedEvent{
 		Submitter: ctx.CallerAddress(),
 		Vault:     authAction.Vault,
-		Nonce:     authAction.Nonce,
+		Nonce:     pendingAction.Nonce,
 	}))
 
 	// Update list of authorizers.
@@ -139,49 +177,36 @@ func (app *vaultApplication) authorizeAction(ctx *api.Context, authAction *vault
 	}
 
 	// Check if action has become executable.
-	var canExecute bool
-	for _, auth := range pendingAction.Action.Authorities(vlt) {
-		if auth.Verify(pendingAction.AuthorizedBy) {
-			canExecute = true
-			break
-		}
-	}
+	delay, canExecute := pendingAction.Action.ExecutionDelay(vlt, pendingAction.AuthorizedBy)
 	if !canExecute {
 		ctx.Commit()
 		return nil
 	}
 
-	// Execute action.
-	evExec := &vault.ActionExecutedEvent{
-		Vault: authAction.Vault,
-		Nonce: authAction.Nonce,
-	}
-	err = app.executeAction(ctx, vlt, &pendingAction.Action)
-	switch {
-	case api.IsUnavailableStateError(err):
-		// Propagate state unavailability errors.
-		return err
-	default:
-		// Record other errors (or success) in the execution event.
-		evExec.Result.Module, evExec.Result.Code = errors.Code(err)
+	// Queue the action in case the authority requires a delay before execution.
+	if delay > 0 {
+		var epoch beacon.EpochTime
+		epoch, err = app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+		if err != nil {
+			return fmt.Errorf("failed to get current epoch: %w", err)
+		}
 
-		ctx.Logger().Debug("vault executed action",
-			"err", err,
-			"vault", authAction.Vault,
-			"nonce", authAction.Nonce,
-			"action", pendingAction.Action,
-		)
-	}
+		pendingAction.ExecuteAt = epoch + delay
+		if err = state.SetPendingAction(ctx, authAction.Vault, pendingAction); err != nil {
+			return err
+		}
 
-	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(evExec))
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&vault.ActionQueuedEvent{
+			Vault:     authAction.Vault,
+			Nonce:     pendingAction.Nonce,
+			ExecuteAt: pendingAction.ExecuteAt,
+		}))
 
-	// Remove pending action as it has been executed.
-	if err = state.RemovePendingAction(ctx, authAction.Vault, authAction.Nonce); err != nil {
-		return err
+		ctx.Commit()
+		return nil
 	}
 
-	vlt.Nonce++
-	if err = state.SetVault(ctx, vlt); err != nil {
+	if err = app.executePendingAction(ctx, vlt, pendingAction); err != nil {
 		return err
 	}
 
@@ -243,21 +268,11 @@ func (app *vaultApplication) cancelAction(ctx *api.Context, cancelAction *vault.
 	}
 
 	// Perform action-specific authority check now that we know what the action is.
-	if !pendingAction.Action.IsAuthorized(vlt, ctx.CallerAddress()) {
+	if !pendingAction.CanCancel(vlt, ctx.CallerAddress()) {
 		return vault.ErrForbidden
 	}
 
-	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&vault.ActionCanceledEvent{
-		Vault: cancelAction.Vault,
-		Nonce: cancelAction.Nonce,
-	}))
-
-	if err = state.RemovePendingAction(ctx, cancelAction.Vault, cancelAction.Nonce); err != nil {
-		return err
-	}
-
-	vlt.Nonce++
-	if err = state.SetVault(ctx, vlt); err != nil {
+	if err = app.cancelPendingAction(ctx, vlt, pendingAction); err != nil {
 		return err
 	}
 
@@ -6,10 +6,16 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	vaultState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/vault/state"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
 )
@@ -690,3 +696,373 @@ func TestAuthorizeCancelAction(t *testing.T) {
 		}
 	}
 }
+
+func TestDelayedAction(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	md := &testMsgDispatcher{}
+	app := &vaultApplication{
+		state: appState,
+		md:    md,
+	}
+
+	state := vaultState.NewMutableState(ctx.State())
+	err := state.SetConsensusParameters(ctx, &vault.ConsensusParameters{
+		MaxAuthorityAddresses: 32,
+		MaxAuthorityDelay:     10,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	ctx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer ctx.Close()
+
+	// Authority delays should be limited.
+	err = app.create(ctx, &vault.Create{
+		AdminAuthority: vault.Authority{
+			Addresses: []staking.Address{testAddrA},
+			Threshold: 1,
+			Delay:     11,
+		},
+		SuspendAuthority: vault.Authority{
+			Addresses: []staking.Address{testAddrC},
+			Threshold: 1,
+		},
+	})
+	require.ErrorIs(err, vault.ErrInvalidArgument, "create should fail with a too large delay")
+
+	// Create a vault with a delayed admin authority.
+	err = app.create(ctx, &vault.Create{
+		AdminAuthority: vault.Authority{
+			Addresses: []staking.Address{
+				testAddrA,
+				testAddrB,
+			},
+			Threshold: 2,
+			Delay:     2,
+		},
+		SuspendAuthority: vault.Authority{
+			Addresses: []staking.Address{
+				testAddrC,
+				testAddrD,
+			},
+			Threshold: 2,
+		},
+	})
+	require.NoError(err, "create")
+	vaultAddr := vault.NewVaultAddress(ctx.CallerAddress(), 0)
+
+	authorize := func(caller staking.Address, nonce uint64) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx).WithCallerAddress(caller)
+		defer txCtx.Close()
+
+		return app.authorizeAction(txCtx, &vault.AuthorizeAction{
+			Vault: vaultAddr,
+			Nonce: nonce,
+			Action: vault.Action{ExecuteMessage: &vault.ActionExecuteMessage{
+				Method: "foo.Bar",
+			}},
+		})
+	}
+	beginBlock := func(epoch beacon.EpochTime) {
+		appState.UpdateMockApplicationStateConfig(&abciAPI.MockApplicationStateConfig{
+			CurrentEpoch: epoch,
+			EpochChanged: true,
+		})
+		bbCtx := appState.NewContext(abciAPI.ContextBeginBlock)
+		defer bbCtx.Close()
+
+		err = app.BeginBlock(bbCtx)
+		require.NoError(err, "BeginBlock")
+	}
+
+	// Reaching the threshold should queue the action.
+	require.NoError(authorize(testAddrA, 0), "authorizeAction")
+	require.NoError(authorize(testAddrB, 0), "authorizeAction")
+	require.Empty(md.delivered, "queued action should not be executed")
+
+	pa, err := state.PendingAction(ctx, vaultAddr, 0)
+	require.NoError(err, "PendingAction")
+	require.True(pa.IsQueued(), "action should be queued")
+	require.EqualValues(12, pa.ExecuteAt, "action should be executed after the delay")
+
+	// Authorizing a queued action should fail.
+	err = authorize(testAddrA, 0)
+	require.ErrorIs(err, vault.ErrActionQueued, "authorizeAction should fail for queued actions")
+
+	// The action should not be executed before the delay expires.
+	beginBlock(11)
+	require.Empty(md.delivered, "queued action should not be executed before the delay expires")
+
+	// The action should be executed once the delay expires.
+	beginBlock(12)
+	require.Len(md.delivered, 1, "queued action should be executed")
+	require.EqualValues("foo.Bar", md.delivered[0].Method)
+	require.EqualValues(vaultAddr, md.delivered[0].Caller)
+
+	vlt, err := state.Vault(ctx, vaultAddr)
+	require.NoError(err, "Vault")
+	require.EqualValues(1, vlt.Nonce, "nonce should advance")
+	_, err = state.PendingAction(ctx, vaultAddr, 0)
+	require.ErrorIs(err, vault.ErrNoSuchAction, "executed action should be removed")
+
+	// Queue another action.
+	require.NoError(authorize(testAddrA, 1), "authorizeAction")
+	require.NoError(authorize(testAddrB, 1), "authorizeAction")
+
+	// The suspend authority should be able to cancel the queued action.
+	cancelCtx := appState.NewContext(abciAPI.ContextDeliverTx).WithCallerAddress(testAddrC)
+	defer cancelCtx.Close()
+	err = app.cancelAction(cancelCtx, &vault.CancelAction{
+		Vault: vaultAddr,
+		Nonce: 1,
+	})
+	require.NoError(err, "cancelAction")
+
+	beginBlock(20)
+	require.Len(md.delivered, 1, "canceled action should not be executed")
+
+	vlt, err = state.Vault(ctx, vaultAddr)
+	require.NoError(err, "Vault")
+	require.EqualValues(2, vlt.Nonce, "nonce should advance")
+	dueActions, err := state.DueActions(ctx, 100)
+	require.NoError(err, "DueActions")
+	require.Empty(dueActions, "there should be no queued actions")
+
+	// Queue another action.
+	require.NoError(authorize(testAddrA, 2), "authorizeAction")
+	require.NoError(authorize(testAddrB, 2), "authorizeAction")
+
+	suspend := func(caller staking.Address, nonce uint64) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx).WithCallerAddress(caller)
+		defer txCtx.Close()
+
+		return app.authorizeAction(txCtx, &vault.AuthorizeAction{
+			Vault:  vaultAddr,
+			Nonce:  nonce,
+			Action: vault.Action{Suspend: &vault.ActionSuspend{}},
+		})
+	}
+
+	// Only the suspend authority should be able to suspend the vault while an action is queued.
+	err = suspend(testAddrA, 2)
+	require.ErrorIs(err, vault.ErrActionQueued, "authorizeAction should fail for queued actions")
+
+	// A suspend authorization below the threshold should not affect the queued action.
+	require.NoError(suspend(testAddrC, 2), "authorizeAction")
+
+	vlt, err = state.Vault(ctx, vaultAddr)
+	require.NoError(err, "Vault")
+	require.True(vlt.IsActive(), "vault should not be suspended")
+	require.EqualValues(2, vlt.Nonce, "nonce should not advance")
+	pa, err = state.PendingAction(ctx, vaultAddr, 2)
+	require.NoError(err, "PendingAction")
+	require.True(pa.IsQueued(), "action should remain queued")
+	require.Equal([]staking.Address{testAddrC}, pa.SuspendAuthorizedBy, "suspend authorization should be recorded")
+
+	// The suspend authority should be able to suspend the vault, canceling the queued action.
+	require.NoError(suspend(testAddrD, 2), "authorizeAction")
+
+	vlt, err = state.Vault(ctx, vaultAddr)
+	require.NoError(err, "Vault")
+	require.False(vlt.IsActive(), "vault should be suspended")
+	require.EqualValues(4, vlt.Nonce, "nonce should advance")
+	dueActions, err = state.DueActions(ctx, 100)
+	require.NoError(err, "DueActions")
+	require.Empty(dueActions, "there should be no queued actions")
+
+	// Queued actions of a suspended vault should be canceled instead of executed.
+	require.NoError(authorize(testAddrA, 4), "authorizeAction")
+	require.NoError(authorize(testAddrB, 4), "authorizeAction")
+
+	beginBlock(30)
+	require.Len(md.delivered, 1, "queued action of a suspended vault should not be executed")
+
+	vlt, err = state.Vault(ctx, vaultAddr)
+	require.NoError(err, "Vault")
+	require.EqualValues(5, vlt.Nonce, "nonce should advance")
+	_, err = state.PendingAction(ctx, vaultAddr, 4)
+	require.ErrorIs(err, vault.ErrNoSuchAction, "canceled action should be removed")
+}
+
+func TestSpendingPolicy(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	md := &testMsgDispatcher{}
+	app := &vaultApplication{
+		state: appState,
+		md:    md,
+	}
+
+	state := vaultState.NewMutableState(ctx.State())
+	err := state.SetConsensusParameters(ctx, &vault.ConsensusParameters{
+		MaxAuthorityAddresses: 32,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	ctx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer ctx.Close()
+
+	// Create a vault with a spending policy.
+	err = app.create(ctx, &vault.Create{
+		AdminAuthority: vault.Authority{
+			Addresses: []staking.Address{testAddrA},
+			Threshold: 1,
+		},
+		SuspendAuthority: vault.Authority{
+			Addresses: []staking.Address{testAddrC},
+			Threshold: 1,
+		},
+		SpendingPolicy: &vault.SpendingPolicy{
+			AllowedDestinations: []staking.Address{testAddrD},
+			MethodLimits: map[transaction.MethodName]*vault.SpendingLimit{
+				staking.MethodTransfer: {
+					LimitAmount:   *quantity.NewFromUint64(100),
+					LimitInterval: 10,
+				},
+			},
+		},
+	})
+	require.NoError(err, "create")
+	vaultAddr := vault.NewVaultAddress(ctx.CallerAddress(), 0)
+
+	authorize := func(caller staking.Address, nonce uint64, action vault.Action) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx).WithCallerAddress(caller)
+		defer txCtx.Close()
+
+		return app.authorizeAction(txCtx, &vault.AuthorizeAction{
+			Vault:  vaultAddr,
+			Nonce:  nonce,
+			Action: action,
+		})
+	}
+	transfer := func(to staking.Address, amount uint64) vault.Action {
+		return vault.Action{ExecuteMessage: &vault.ActionExecuteMessage{
+			Method: staking.MethodTransfer,
+			Body: cbor.Marshal(&staking.Transfer{
+				To:     to,
+				Amount: *quantity.NewFromUint64(amount),
+			}),
+		}}
+	}
+
+	// Transfers to destinations that are not allowed should be rejected.
+	err = authorize(testAddrA, 0, transfer(testAddrE, 10))
+	require.ErrorIs(err, vault.ErrSpendingPolicyViolation, "authorizeAction should fail for disallowed destinations")
+
+	// Methods with unknown spending effects should be rejected.
+	err = authorize(testAddrA, 0, vault.Action{ExecuteMessage: &vault.ActionExecuteMessage{
+		Method: "foo.Bar",
+	}})
+	require.ErrorIs(err, vault.ErrSpendingPolicyViolation, "authorizeAction should fail for unknown methods")
+
+	// Transfers within the limit should be executed.
+	require.NoError(authorize(testAddrA, 0, transfer(testAddrD, 60)), "authorizeAction")
+	require.Len(md.delivered, 1, "action should be executed")
+	require.EqualValues(staking.MethodTransfer, md.delivered[0].Method)
+
+	// Transfers exceeding the limit should be rejected.
+	err = authorize(testAddrA, 1, transfer(testAddrD, 60))
+	require.ErrorIs(err, vault.ErrSpendingPolicyViolation, "authorizeAction should fail when exceeding the limit")
+	require.NoError(authorize(testAddrA, 1, transfer(testAddrD, 40)), "authorizeAction")
+	require.Len(md.delivered, 2, "action should be executed")
+
+	vlt, err := state.Vault(ctx, vaultAddr)
+	require.NoError(err, "Vault")
+	require.EqualValues(*quantity.NewFromUint64(100), vlt.SpendingAccounts[staking.MethodTransfer].CurrentAmount)
+
+	// The admin authority should not be able to update the spending policy.
+	removePolicy := vault.Action{UpdateSpendingPolicy: &vault.ActionUpdateSpendingPolicy{}}
+	err = authorize(testAddrA, 2, removePolicy)
+	require.ErrorIs(err, vault.ErrForbidden, "admin authority should not be able to update the spending policy")
+
+	// The suspend authority should be able to remove the spending policy.
+	require.NoError(authorize(testAddrC, 2, removePolicy), "authorizeAction")
+
+	vlt, err = state.Vault(ctx, vaultAddr)
+	require.NoError(err, "Vault")
+	require.Nil(vlt.SpendingPolicy, "spending policy should be removed")
+	require.Nil(vlt.SpendingAccounts, "spending accounts should be removed")
+
+	require.NoError(authorize(testAddrA, 3, transfer(testAddrE, 1000)), "authorizeAction")
+	require.Len(md.delivered, 3, "action should be executed")
+}
+
+func TestRuntimeMessages(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	md := &testMsgDispatcher{}
+	app := &vaultApplication{
+		state: appState,
+		md:    md,
+	}
+
+	state := vaultState.NewMutableState(ctx.State())
+	err := state.SetConsensusParameters(ctx, &vault.ConsensusParameters{
+		MaxAuthorityAddresses: 32,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	var runtimeID common.Namespace
+	runtimeAddr := staking.NewRuntimeAddress(runtimeID)
+
+	execute := func(msg *message.VaultMessage) (interface{}, error) {
+		msgCtx := appState.NewContext(abciAPI.ContextDeliverTx).WithMessageExecution().WithCallerAddress(runtimeAddr)
+		defer msgCtx.Close()
+
+		return app.ExecuteMessage(msgCtx, roothashApi.RuntimeMessageVault, msg)
+	}
+
+	// Runtimes should be able to create multiple vaults.
+	create := &vault.Create{
+		AdminAuthority: vault.Authority{
+			Addresses: []staking.Address{runtimeAddr},
+			Threshold: 1,
+		},
+		SuspendAuthority: vault.Authority{
+			Addresses: []staking.Address{runtimeAddr},
+			Threshold: 1,
+		},
+	}
+	_, err = execute(&message.VaultMessage{Create: create})
+	require.NoError(err, "create")
+	_, err = execute(&message.VaultMessage{Create: create})
+	require.NoError(err, "create")
+
+	vaultAddr := vault.NewVaultAddress(runtimeAddr, 0)
+	_, err = state.Vault(ctx, vaultAddr)
+	require.NoError(err, "Vault")
+	_, err = state.Vault(ctx, vault.NewVaultAddress(runtimeAddr, 1))
+	require.NoError(err, "Vault")
+
+	// Runtimes should be able to authorize actions.
+	_, err = execute(&message.VaultMessage{AuthorizeAction: &vault.AuthorizeAction{
+		Vault: vaultAddr,
+		Nonce: 0,
+		Action: vault.Action{ExecuteMessage: &vault.ActionExecuteMessage{
+			Method: "foo.Bar",
+		}},
+	}})
+	require.NoError(err, "authorizeAction")
+	require.Len(md.delivered, 1, "action should be executed")
+	require.EqualValues(vaultAddr, md.delivered[0].Caller)
+
+	// Invalid messages should be rejected.
+	_, err = execute(&message.VaultMessage{})
+	require.ErrorIs(err, vault.ErrInvalidArgument, "empty message should be rejected")
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/api"
+	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
 	stakingapp "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking"
 	stakingApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/api"
 	vaultState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/vault/state"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
 )
 
@@ -62,6 +64,7 @@ func (app *vaultApplication) OnRegister(state api.ApplicationState, md api.Messa
 	md.Subscribe(stakingApi.MessageAccountHook, app)
 	md.Subscribe(governanceApi.MessageChangeParameters, app)
 	md.Subscribe(governanceApi.MessageValidateParameterChanges, app)
+	md.Subscribe(roothashApi.RuntimeMessageVault, app)
 }
 
 func (app *vaultApplication) OnCleanup() {
@@ -99,6 +102,18 @@ func (app *vaultApplication) ExecuteMessage(ctx *api.Context, kind, msg interfac
 	case stakingApi.MessageAccountHook:
 		// Account hook invocation.
 		return app.invokeAccountHook(ctx, msg)
+	case roothashApi.RuntimeMessageVault:
+		m := msg.(*message.VaultMessage)
+		switch {
+		case m.Create != nil:
+			return nil, app.create(ctx, m.Create)
+		case m.AuthorizeAction != nil:
+			return nil, app.authorizeAction(ctx, m.AuthorizeAction)
+		case m.CancelAction != nil:
+			return nil, app.cancelAction(ctx, m.CancelAction)
+		default:
+			return nil, vault.ErrInvalidArgument
+		}
 	case governanceApi.MessageValidateParameterChanges:
 		// A change parameters proposal is about to be submitted. Validate changes.
 		return app.changeParameters(ctx, msg, false)
@@ -111,7 +126,11 @@ func (app *vaultApplication) ExecuteMessage(ctx *api.Context, kind, msg interfac
 	}
 }
 
-func (app *vaultApplication) BeginBlock(_ *api.Context) error {
+func (app *vaultApplication) BeginBlock(ctx *api.Context) error {
+	// Execute queued actions on epoch transitions.
+	if changed, epoch := app.state.EpochChanged(ctx); changed {
+		return app.executeDueActions(ctx, epoch)
+	}
 	return nil
 }
 
@@ -4,6 +4,8 @@ package config
 import (
 	"fmt"
 	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 )
 
 // Config is the CometBFT configuration structure.
@@ -27,6 +29,10 @@ type Config struct {
 	// Minimum gas price for this validator.
 	MinGasPrice uint64 `yaml:"min_gas_price,omitempty"`
 
+	// Maximum number of transactions of the same account (either as the signer or the fee payer)
+	// included in a block proposal (zero means no limit).
+	MaxProposalTxsPerSigner uint64 `yaml:"max_proposal_txs_per_signer,omitempty"`
+
 	// Transaction submission configuration.
 	Submission SubmissionConfig `yaml:"submission,omitempty"`
 
@@ -112,6 +118,11 @@ type CheckpointerConfig struct {
 	Disabled bool `yaml:"disabled"`
 	// ABCI state checkpointer check interval.
 	CheckInterval time.Duration `yaml:"check_interval"`
+	// Maximum number of consecutive delta checkpoints between full checkpoints (zero disables
+	// delta checkpoints).
+	MaxDeltaCheckpoints uint64 `yaml:"max_delta_checkpoints,omitempty"`
+	// Codec used to compress checkpoint chunks (snappy or zstd, defaults to snappy).
+	ChunkCodec string `yaml:"chunk_codec,omitempty"`
 }
 
 // StateSyncConfig is the consensus state sync configuration structure.
@@ -177,6 +188,10 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if _, err := checkpoint.ParseChunkCodec(c.Checkpointer.ChunkCodec); err != nil {
+		return fmt.Errorf("checkpointer.chunk_codec: %w", err)
+	}
+
 	if c.SupplementarySanity.Enabled && c.SupplementarySanity.Interval < 1 {
 		return fmt.Errorf("supplementary_sanity.interval must be >= 1")
 	}
@@ -201,6 +216,7 @@ func DefaultConfig() Config {
 		},
 		SentryUpstreamAddresses: []string{},
 		MinGasPrice:             0,
+		MaxProposalTxsPerSigner: 64,
 		Submission: SubmissionConfig{
 			GasPrice: 0,
 			MaxFee:   10_000_000_000,
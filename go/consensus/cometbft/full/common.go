@@ -945,6 +945,11 @@ func (n *commonNode) SubmitTxWithProof(context.Context, *transaction.SignedTrans
 	return nil, consensusAPI.ErrUnsupported
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) SubmitMultiSignedTx(context.Context, *transaction.MultiSignedTransaction) error {
+	return consensusAPI.ErrUnsupported
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) GetUnconfirmedTransactions(context.Context) ([][]byte, error) {
 	return nil, consensusAPI.ErrUnsupported
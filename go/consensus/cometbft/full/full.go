@@ -54,6 +54,7 @@ import (
 	p2pAPI "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
 	stakingAPI "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	upgradeAPI "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -188,7 +189,7 @@ func (t *fullService) SupportedFeatures() consensusAPI.FeatureMask {
 
 // Implements consensusAPI.Backend.
 func (t *fullService) SubmitTx(ctx context.Context, tx *transaction.SignedTransaction) error {
-	if _, err := t.submitTx(ctx, tx); err != nil {
+	if _, err := t.submitTx(ctx, cbor.Marshal(tx)); err != nil {
 		return err
 	}
 	return nil
@@ -201,7 +202,7 @@ func (t *fullService) SubmitTxNoWait(_ context.Context, tx *transaction.SignedTr
 
 // Implements consensusAPI.Backend.
 func (t *fullService) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error) {
-	data, err := t.submitTx(ctx, tx)
+	data, err := t.submitTx(ctx, cbor.Marshal(tx))
 	if err != nil {
 		return nil, err
 	}
@@ -221,9 +222,16 @@ func (t *fullService) SubmitTxWithProof(ctx context.Context, tx *transaction.Sig
 	}, nil
 }
 
-func (t *fullService) submitTx(ctx context.Context, tx *transaction.SignedTransaction) (*cmttypes.EventDataTx, error) {
+// Implements consensusAPI.Backend.
+func (t *fullService) SubmitMultiSignedTx(ctx context.Context, tx *transaction.MultiSignedTransaction) error {
+	if _, err := t.submitTx(ctx, cbor.Marshal(tx)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *fullService) submitTx(ctx context.Context, data []byte) (*cmttypes.EventDataTx, error) {
 	// Subscribe to the transaction being included in a block.
-	data := cbor.Marshal(tx)
 	query := cmttypes.EventQueryTxFor(data)
 	subID := t.newSubscriberID()
 	txSub, err := t.subscribe(subID, query)
@@ -528,18 +536,26 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 	pruneCfg.NumKept = config.GlobalConfig.Consensus.Prune.NumKept
 	pruneCfg.PruneInterval = max(config.GlobalConfig.Consensus.Prune.Interval, time.Second)
 
+	cpChunkCodec, err := checkpoint.ParseChunkCodec(config.GlobalConfig.Consensus.Checkpointer.ChunkCodec)
+	if err != nil {
+		return err
+	}
+
 	appConfig := &abci.ApplicationConfig{
-		DataDir:                   filepath.Join(t.dataDir, tmcommon.StateDir),
-		StorageBackend:            config.GlobalConfig.Storage.Backend,
-		Pruning:                   pruneCfg,
-		HaltEpoch:                 beaconAPI.EpochTime(config.GlobalConfig.Consensus.HaltEpoch),
-		HaltHeight:                config.GlobalConfig.Consensus.HaltHeight,
-		MinGasPrice:               config.GlobalConfig.Consensus.MinGasPrice,
-		Identity:                  t.identity,
-		DisableCheckpointer:       config.GlobalConfig.Consensus.Checkpointer.Disabled,
-		CheckpointerCheckInterval: config.GlobalConfig.Consensus.Checkpointer.CheckInterval,
-		InitialHeight:             uint64(t.genesis.Height),
-		ChainContext:              t.genesis.ChainContext(),
+		DataDir:                         filepath.Join(t.dataDir, tmcommon.StateDir),
+		StorageBackend:                  config.GlobalConfig.Storage.Backend,
+		Pruning:                         pruneCfg,
+		HaltEpoch:                       beaconAPI.EpochTime(config.GlobalConfig.Consensus.HaltEpoch),
+		HaltHeight:                      config.GlobalConfig.Consensus.HaltHeight,
+		MinGasPrice:                     config.GlobalConfig.Consensus.MinGasPrice,
+		MaxProposalTxsPerSigner:         config.GlobalConfig.Consensus.MaxProposalTxsPerSigner,
+		Identity:                        t.identity,
+		DisableCheckpointer:             config.GlobalConfig.Consensus.Checkpointer.Disabled,
+		CheckpointerCheckInterval:       config.GlobalConfig.Consensus.Checkpointer.CheckInterval,
+		CheckpointerMaxDeltaCheckpoints: config.GlobalConfig.Consensus.Checkpointer.MaxDeltaCheckpoints,
+		CheckpointerChunkCodec:          cpChunkCodec,
+		InitialHeight:                   uint64(t.genesis.Height),
+		ChainContext:                    t.genesis.ChainContext(),
 	}
 	t.mux, err = abci.NewApplicationServer(t.ctx, t.upgrader, appConfig)
 	if err != nil {
@@ -251,6 +251,16 @@ func EventsFromCometBFT(
 
 				evt := &api.Event{Height: height, TxHash: txHash, Vote: &e}
 				events = append(events, evt)
+			case eventsAPI.IsAttributeKind(key, &api.VoteDelegateEvent{}):
+				// Vote delegate event.
+				var e api.VoteDelegateEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("governance: corrupt VoteDelegate event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, VoteDelegate: &e}
+				events = append(events, evt)
 			default:
 				errs = errors.Join(errs, fmt.Errorf("governance: unknown event type: key: %s, val: %s", key, val))
 			}
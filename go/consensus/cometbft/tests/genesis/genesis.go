@@ -98,6 +98,8 @@ func NewTestNodeGenesisProvider(identity *identity.Identity, ent *entity.Entity,
 				VotingPeriod:                   10,
 				MinProposalDeposit:             *quantity.NewFromUint64(100),
 				EnableChangeParametersProposal: true,
+				AllowParametersPatch:           true,
+				AllowVoteDelegation:            true,
 			},
 		},
 		RootHash: roothash.Genesis{
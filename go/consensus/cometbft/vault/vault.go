@@ -211,6 +211,15 @@ func EventsFromCometBFT(
 				}
 
 				evt.ActionSubmitted = &e
+			case eventsAPI.IsAttributeKind(key, &api.ActionQueuedEvent{}):
+				// Action queued event.
+				var e api.ActionQueuedEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("vault: corrupt ActionQueued event: %w", err))
+					continue
+				}
+
+				evt.ActionQueued = &e
 			case eventsAPI.IsAttributeKind(key, &api.ActionCanceledEvent{}):
 				// Action canceled event.
 				var e api.ActionCanceledEvent
@@ -256,6 +265,15 @@ func EventsFromCometBFT(
 				}
 
 				evt.AuthorityUpdated = &e
+			case eventsAPI.IsAttributeKind(key, &api.SpendingPolicyUpdatedEvent{}):
+				// Spending policy updated event.
+				var e api.SpendingPolicyUpdatedEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("vault: corrupt SpendingPolicyUpdated event: %w", err))
+					continue
+				}
+
+				evt.SpendingPolicyUpdated = &e
 			default:
 				errs = errors.Join(errs, fmt.Errorf("vault: unknown event type: key: %s, val: %s", key, val))
 				continue
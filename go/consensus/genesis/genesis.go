@@ -41,6 +41,10 @@ type Parameters struct { // nolint: maligned
 	// GasCosts are the base transaction gas costs.
 	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
 
+	// MaxBatchCalls is the maximum number of calls in a batch transaction. Batch transactions are
+	// disabled when set to zero.
+	MaxBatchCalls uint16 `json:"max_batch_calls,omitempty"`
+
 	// PublicKeyBlacklist is the network-wide public key blacklist.
 	PublicKeyBlacklist []signature.PublicKey `json:"public_key_blacklist,omitempty"`
 
@@ -66,37 +70,47 @@ const (
 
 // SanityCheck does basic sanity checking on the genesis state.
 func (g *Genesis) SanityCheck() error {
-	params := g.Parameters
+	if err := g.Parameters.SanityCheck(); err != nil {
+		return err
+	}
+
+	// Check for duplicate entries in the pk blacklist.
+	m := make(map[signature.PublicKey]bool)
+	for _, v := range g.Parameters.PublicKeyBlacklist {
+		if m[v] {
+			return fmt.Errorf("consensus: sanity check failed: redundant blacklisted public key: '%s'", v)
+		}
+		if v.IsBlacklisted() {
+			return fmt.Errorf("consensus: sanity check failed: public key already in blacklist: '%s'", v)
+		}
+		m[v] = true
+	}
 
-	if params.TimeoutCommit < 1*time.Millisecond && !params.SkipTimeoutCommit {
+	return nil
+}
+
+// SanityCheck performs a sanity check on the consensus parameters.
+//
+// The public key blacklist is only checked as part of the genesis sanity check as the blacklist
+// is applied globally on startup.
+func (p *Parameters) SanityCheck() error {
+	if p.TimeoutCommit < 1*time.Millisecond && !p.SkipTimeoutCommit {
 		return fmt.Errorf("consensus: sanity check failed: timeout commit must be >= 1ms")
 	}
 
-	if params.StateCheckpointInterval > 0 && !flags.DebugDontBlameOasis() {
-		if params.StateCheckpointInterval < 1000 {
+	if p.StateCheckpointInterval > 0 && !flags.DebugDontBlameOasis() {
+		if p.StateCheckpointInterval < 1000 {
 			return fmt.Errorf("consensus: sanity check failed: state checkpoint interval must be >= 1000")
 		}
 
-		if params.StateCheckpointNumKept == 0 {
+		if p.StateCheckpointNumKept == 0 {
 			return fmt.Errorf("consensus: sanity check failed: number of kept state checkpoints must be > 0")
 		}
 
-		if params.StateCheckpointChunkSize < 1024*1024 {
+		if p.StateCheckpointChunkSize < 1024*1024 {
 			return fmt.Errorf("consensus: sanity check failed: state checkpoint chunk size must be >= 1 MiB")
 		}
 	}
 
-	// Check for duplicate entries in the pk blacklist.
-	m := make(map[signature.PublicKey]bool)
-	for _, v := range params.PublicKeyBlacklist {
-		if m[v] {
-			return fmt.Errorf("consensus: sanity check failed: redundant blacklisted public key: '%s'", v)
-		}
-		if v.IsBlacklisted() {
-			return fmt.Errorf("consensus: sanity check failed: public key already in blacklist: '%s'", v)
-		}
-		m[v] = true
-	}
-
 	return nil
 }
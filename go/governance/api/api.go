@@ -38,16 +38,21 @@ var (
 	ErrNotEligible = errors.New(ModuleName, 6, "governance: not eligible")
 	// ErrVotingIsClosed is the error returned when a vote is cast for a non-active proposal.
 	ErrVotingIsClosed = errors.New(ModuleName, 7, "governance: voting is closed")
+	// ErrVoteDelegationNotAllowed is the error returned when vote delegation is not allowed.
+	ErrVoteDelegationNotAllowed = errors.New(ModuleName, 8, "governance: vote delegation not allowed")
 
 	// MethodSubmitProposal submits a new consensus layer governance proposal.
 	MethodSubmitProposal = transaction.NewMethodName(ModuleName, "SubmitProposal", ProposalContent{})
 	// MethodCastVote casts a vote for a consensus layer governance proposal.
 	MethodCastVote = transaction.NewMethodName(ModuleName, "CastVote", ProposalVote{})
+	// MethodSetVoteDelegate sets or clears the proxy voter for the caller's delegations.
+	MethodSetVoteDelegate = transaction.NewMethodName(ModuleName, "SetVoteDelegate", VoteDelegate{})
 
 	// Methods is the list of all methods supported by the governance backend.
 	Methods = []transaction.MethodName{
 		MethodSubmitProposal,
 		MethodCastVote,
+		MethodSetVoteDelegate,
 	}
 
 	_ prettyprint.PrettyPrinter = (*ProposalContent)(nil)
@@ -55,8 +60,31 @@ var (
 	_ prettyprint.PrettyPrinter = (*CancelUpgradeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ChangeParametersProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ProposalVote)(nil)
+	_ prettyprint.PrettyPrinter = (*VoteDelegate)(nil)
 )
 
+// ProposalKind is the kind of a governance proposal.
+type ProposalKind string
+
+const (
+	// ProposalKindUpgrade is the kind of upgrade proposals.
+	ProposalKindUpgrade ProposalKind = "upgrade"
+	// ProposalKindCancelUpgrade is the kind of cancel upgrade proposals.
+	ProposalKindCancelUpgrade ProposalKind = "cancel_upgrade"
+	// ProposalKindChangeParameters is the kind of change parameters proposals.
+	ProposalKindChangeParameters ProposalKind = "change_parameters"
+)
+
+// IsValid checks whether the proposal kind is valid.
+func (k ProposalKind) IsValid() bool {
+	switch k {
+	case ProposalKindUpgrade, ProposalKindCancelUpgrade, ProposalKindChangeParameters:
+		return true
+	default:
+		return false
+	}
+}
+
 // ProposalContent is a consensus layer governance proposal content.
 type ProposalContent struct {
 	// Metadata contains optional proposal metadata which is ignored during proposal execution.
@@ -106,6 +134,9 @@ func (p *ProposalContent) ValidateBasic(params *ConsensusParameters) error {
 	case p.CancelUpgrade != nil:
 		// No validation at this time.
 	case p.ChangeParameters != nil:
+		if p.ChangeParameters.Patch != nil && !params.AllowParametersPatch {
+			return fmt.Errorf("%w: parameters patches are not allowed", ErrInvalidArgument)
+		}
 		if err := p.ChangeParameters.ValidateBasic(); err != nil {
 			return fmt.Errorf("change parameters proposal validation failed: %w", err)
 		}
@@ -139,6 +170,22 @@ func (p *ProposalContent) Equals(other *ProposalContent) bool {
 	return true
 }
 
+// Kind returns the kind of the proposal.
+//
+// Note: this assumes a valid proposal with exactly one field set.
+func (p *ProposalContent) Kind() ProposalKind {
+	switch {
+	case p.Upgrade != nil:
+		return ProposalKindUpgrade
+	case p.CancelUpgrade != nil:
+		return ProposalKindCancelUpgrade
+	case p.ChangeParameters != nil:
+		return ProposalKindChangeParameters
+	default:
+		return ""
+	}
+}
+
 // PrettyPrint writes a pretty-printed representation of ProposalContent to the
 // given writer.
 func (p ProposalContent) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
@@ -275,7 +322,12 @@ type ChangeParametersProposal struct {
 	// Module identifies the consensus backend module to which changes should be applied.
 	Module string `json:"module"`
 	// Changes are consensus parameter changes that should be applied to the module.
-	Changes cbor.RawMessage `json:"changes"`
+	Changes cbor.RawMessage `json:"changes,omitempty"`
+	// Patch is a generic patch against the module's consensus parameters. It is a CBOR map from
+	// top-level consensus parameter field names to their new values.
+	//
+	// Exactly one of Changes and Patch must be set.
+	Patch cbor.RawMessage `json:"patch,omitempty"`
 }
 
 // Equals checks if change parameters proposals are equal.
@@ -292,20 +344,28 @@ func (p *ChangeParametersProposal) Equals(other *ChangeParametersProposal) bool
 	if !bytes.Equal(p.Changes, other.Changes) {
 		return false
 	}
+	if !bytes.Equal(p.Patch, other.Patch) {
+		return false
+	}
 	return true
 }
 
 // PrettyPrint writes a pretty-printed representation of ChangeParametersProposal to the given
 // writer.
 func (p *ChangeParametersProposal) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	raw, title := p.Changes, "Changes"
+	if p.Patch != nil {
+		raw, title = p.Patch, "Patch"
+	}
+
 	var changes map[string]interface{}
-	if err := cbor.Unmarshal(p.Changes, &changes); err != nil {
+	if err := cbor.Unmarshal(raw, &changes); err != nil {
 		fmt.Fprintf(w, "%s  <error: %s>\n", prefix, err)
-		fmt.Fprintf(w, "%s  <malformed: %s>\n", prefix, base64.StdEncoding.EncodeToString(p.Changes))
+		fmt.Fprintf(w, "%s  <malformed: %s>\n", prefix, base64.StdEncoding.EncodeToString(raw))
 		return
 	}
 	fmt.Fprintf(w, "%sModule: %s\n", prefix, p.Module)
-	fmt.Fprintf(w, "%sChanges: \n", prefix)
+	fmt.Fprintf(w, "%s%s: \n", prefix, title)
 	for param, value := range changes {
 		if value == nil {
 			continue
@@ -326,8 +386,19 @@ func (p *ChangeParametersProposal) ValidateBasic() error {
 	if len(p.Module) == 0 {
 		return fmt.Errorf("invalid module name: name should not be empty")
 	}
-	if len(p.Changes) == 0 {
+	switch {
+	case len(p.Changes) == 0 && len(p.Patch) == 0:
 		return fmt.Errorf("invalid parameter changes: changes should not be empty")
+	case len(p.Changes) != 0 && len(p.Patch) != 0:
+		return fmt.Errorf("invalid parameter changes: only one of changes and patch may be set")
+	case len(p.Patch) != 0:
+		var patch map[string]cbor.RawMessage
+		if err := cbor.Unmarshal(p.Patch, &patch); err != nil {
+			return fmt.Errorf("invalid parameters patch: %w", err)
+		}
+		if len(patch) == 0 {
+			return fmt.Errorf("invalid parameters patch: patch should not be empty")
+		}
 	}
 	return nil
 }
@@ -353,6 +424,82 @@ func (pv ProposalVote) PrettyType() (interface{}, error) {
 	return pv, nil
 }
 
+// VoteDelegate is a proxy voter assignment for an account's delegations.
+//
+// In case the delegator does not vote on a proposal, the vote of the delegate is used for the
+// delegator's shares instead of the vote of the validator that the shares are delegated to.
+type VoteDelegate struct {
+	// Delegate is the address of the proxy voter. If nil, the existing assignment is removed.
+	Delegate *staking.Address `json:"delegate,omitempty"`
+	// Kinds is an optional list of proposal kinds the assignment is restricted to. If empty, the
+	// assignment applies to proposals of all kinds.
+	Kinds []ProposalKind `json:"kinds,omitempty"`
+}
+
+// ValidateBasic performs basic vote delegate validity checks.
+func (vd *VoteDelegate) ValidateBasic() error {
+	if vd.Delegate == nil {
+		if len(vd.Kinds) > 0 {
+			return fmt.Errorf("proposal kinds set without a delegate")
+		}
+		return nil
+	}
+	if !vd.Delegate.IsValid() {
+		return fmt.Errorf("invalid delegate address")
+	}
+	kinds := make(map[ProposalKind]struct{}, len(vd.Kinds))
+	for _, kind := range vd.Kinds {
+		if !kind.IsValid() {
+			return fmt.Errorf("invalid proposal kind: %s", kind)
+		}
+		if _, ok := kinds[kind]; ok {
+			return fmt.Errorf("duplicate proposal kind: %s", kind)
+		}
+		kinds[kind] = struct{}{}
+	}
+	return nil
+}
+
+// AppliesTo checks whether the assignment applies to proposals of the given kind.
+func (vd *VoteDelegate) AppliesTo(kind ProposalKind) bool {
+	if vd.Delegate == nil {
+		return false
+	}
+	if len(vd.Kinds) == 0 {
+		return true
+	}
+	for _, k := range vd.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// PrettyPrint writes a pretty-printed representation of VoteDelegate to the
+// given writer.
+func (vd VoteDelegate) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	if vd.Delegate == nil {
+		fmt.Fprintf(w, "%sDelegate: (none)\n", prefix)
+		return
+	}
+	fmt.Fprintf(w, "%sDelegate: %s\n", prefix, vd.Delegate)
+	if len(vd.Kinds) == 0 {
+		fmt.Fprintf(w, "%sKinds:    (all)\n", prefix)
+		return
+	}
+	fmt.Fprintf(w, "%sKinds:\n", prefix)
+	for _, kind := range vd.Kinds {
+		fmt.Fprintf(w, "%s  - %s\n", prefix, kind)
+	}
+}
+
+// PrettyType returns a representation of VoteDelegate that can be used for
+// pretty printing.
+func (vd VoteDelegate) PrettyType() (interface{}, error) {
+	return vd, nil
+}
+
 // Backend is a governance implementation.
 type Backend interface {
 	// ActiveProposals returns a list of all proposals that have not yet closed.
@@ -411,6 +558,9 @@ type Genesis struct {
 
 	// VoteEntries are the governance proposal vote entries.
 	VoteEntries map[uint64][]*VoteEntry `json:"vote_entries,omitempty"`
+
+	// VoteDelegates are the proxy voter assignments by delegator address.
+	VoteDelegates map[staking.Address]*VoteDelegate `json:"vote_delegates,omitempty"`
 }
 
 // ConsensusParameters are the governance consensus parameters.
@@ -448,6 +598,14 @@ type ConsensusParameters struct {
 
 	// AllowProposalMetadata is true iff proposals are allowed to contain metadata.
 	AllowProposalMetadata bool `json:"allow_proposal_metadata,omitempty"`
+
+	// AllowParametersPatch is true iff change parameters proposals are allowed to contain generic
+	// consensus parameter patches.
+	AllowParametersPatch bool `json:"allow_parameters_patch,omitempty"`
+
+	// AllowVoteDelegation is true iff accounts are allowed to assign proxy voters for their
+	// delegations.
+	AllowVoteDelegation bool `json:"allow_vote_delegation,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed governance consensus parameter changes.
@@ -473,6 +631,12 @@ type ConsensusParameterChanges struct {
 
 	// EnableChangeParametersProposal is the new enable change parameters proposal flag.
 	EnableChangeParametersProposal *bool `json:"enable_change_parameters_proposal,omitempty"`
+
+	// AllowParametersPatch is the new allow parameters patch flag.
+	AllowParametersPatch *bool `json:"allow_parameters_patch,omitempty"`
+
+	// AllowVoteDelegation is the new allow vote delegation flag.
+	AllowVoteDelegation *bool `json:"allow_vote_delegation,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -498,6 +662,12 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.EnableChangeParametersProposal != nil {
 		params.EnableChangeParametersProposal = *c.EnableChangeParametersProposal
 	}
+	if c.AllowParametersPatch != nil {
+		params.AllowParametersPatch = *c.AllowParametersPatch
+	}
+	if c.AllowVoteDelegation != nil {
+		params.AllowVoteDelegation = *c.AllowVoteDelegation
+	}
 	return nil
 }
 
@@ -510,6 +680,7 @@ type Event struct {
 	ProposalExecuted  *ProposalExecutedEvent  `json:"proposal_executed,omitempty"`
 	ProposalFinalized *ProposalFinalizedEvent `json:"proposal_finalized,omitempty"`
 	Vote              *VoteEvent              `json:"vote,omitempty"`
+	VoteDelegate      *VoteDelegateEvent      `json:"vote_delegate,omitempty"`
 }
 
 // ProposalSubmittedEvent is the event emitted when a new proposal is submitted.
@@ -564,6 +735,21 @@ func (e *VoteEvent) EventKind() string {
 	return "vote"
 }
 
+// VoteDelegateEvent is the event emitted when a proxy voter assignment is changed.
+type VoteDelegateEvent struct {
+	// Delegator is the staking account address of the delegator.
+	Delegator staking.Address `json:"delegator"`
+	// Delegate is the staking account address of the proxy voter (nil if removed).
+	Delegate *staking.Address `json:"delegate,omitempty"`
+	// Kinds is the list of proposal kinds the assignment is restricted to.
+	Kinds []ProposalKind `json:"kinds,omitempty"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *VoteDelegateEvent) EventKind() string {
+	return "vote_delegate"
+}
+
 // NewSubmitProposalTx creates a new submit proposal transaction.
 func NewSubmitProposalTx(nonce uint64, fee *transaction.Fee, proposal *ProposalContent) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodSubmitProposal, proposal)
@@ -574,15 +760,23 @@ func NewCastVoteTx(nonce uint64, fee *transaction.Fee, vote *ProposalVote) *tran
 	return transaction.NewTransaction(nonce, fee, MethodCastVote, vote)
 }
 
+// NewSetVoteDelegateTx creates a new set vote delegate transaction.
+func NewSetVoteDelegateTx(nonce uint64, fee *transaction.Fee, delegate *VoteDelegate) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodSetVoteDelegate, delegate)
+}
+
 const (
 	// GasOpSubmitProposal is the gas operation identifier for submitting proposal.
 	GasOpSubmitProposal transaction.Op = "submit_proposal"
 	// GasOpCastVote is the gas operation identifier for casting vote.
 	GasOpCastVote transaction.Op = "cast_vote"
+	// GasOpSetVoteDelegate is the gas operation identifier for setting a vote delegate.
+	GasOpSetVoteDelegate transaction.Op = "set_vote_delegate"
 )
 
 // DefaultGasCosts are the "default" gas costs for operations.
 var DefaultGasCosts = transaction.Costs{
-	GasOpSubmitProposal: 1000,
-	GasOpCastVote:       1000,
+	GasOpSubmitProposal:  1000,
+	GasOpCastVote:        1000,
+	GasOpSetVoteDelegate: 1000,
 }
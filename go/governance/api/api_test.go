@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -81,6 +84,56 @@ func TestValidateBasic(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{
+			msg: "parameters patch should fail when patches are not allowed",
+			p: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{
+					Module: "test",
+					Patch:  cbor.Marshal(map[string]uint64{"foo": 1}),
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "parameters patch should not fail when patches are allowed",
+			p: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{
+					Module: "test",
+					Patch:  cbor.Marshal(map[string]uint64{"foo": 1}),
+				},
+			},
+			params: ConsensusParameters{
+				AllowParametersPatch: true,
+			},
+			shouldErr: false,
+		},
+		{
+			msg: "empty parameters patch should fail",
+			p: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{
+					Module: "test",
+					Patch:  cbor.Marshal(map[string]uint64{}),
+				},
+			},
+			params: ConsensusParameters{
+				AllowParametersPatch: true,
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "change parameters proposal with both changes and patch should fail",
+			p: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{
+					Module:  "test",
+					Changes: cbor.Marshal(map[string]uint64{"foo": 1}),
+					Patch:   cbor.Marshal(map[string]uint64{"foo": 1}),
+				},
+			},
+			params: ConsensusParameters{
+				AllowParametersPatch: true,
+			},
+			shouldErr: true,
+		},
 	} {
 		err := tc.p.ValidateBasic(&tc.params) //nolint: gosec
 		if tc.shouldErr {
@@ -206,6 +259,55 @@ func TestProposalContentPrettyPrint(t *testing.T) {
 	}
 }
 
+func TestVoteDelegate(t *testing.T) {
+	require := require.New(t)
+
+	delegate := staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	for _, tc := range []struct {
+		msg     string
+		vd      *VoteDelegate
+		valid   bool
+		applies []ProposalKind
+	}{
+		{"clearing should be valid", &VoteDelegate{}, true, nil},
+		{"kinds without delegate should be invalid", &VoteDelegate{Kinds: []ProposalKind{ProposalKindUpgrade}}, false, nil},
+		{"reserved delegate should be invalid", &VoteDelegate{Delegate: &staking.CommonPoolAddress}, false, nil},
+		{"invalid kind should be invalid", &VoteDelegate{Delegate: &delegate, Kinds: []ProposalKind{"invalid"}}, false, nil},
+		{
+			"duplicate kinds should be invalid",
+			&VoteDelegate{Delegate: &delegate, Kinds: []ProposalKind{ProposalKindUpgrade, ProposalKindUpgrade}},
+			false,
+			nil,
+		},
+		{
+			"delegate without kinds should apply to all kinds",
+			&VoteDelegate{Delegate: &delegate},
+			true,
+			[]ProposalKind{ProposalKindUpgrade, ProposalKindCancelUpgrade, ProposalKindChangeParameters},
+		},
+		{
+			"delegate with kinds should apply to given kinds",
+			&VoteDelegate{Delegate: &delegate, Kinds: []ProposalKind{ProposalKindUpgrade, ProposalKindCancelUpgrade}},
+			true,
+			[]ProposalKind{ProposalKindUpgrade, ProposalKindCancelUpgrade},
+		},
+	} {
+		err := tc.vd.ValidateBasic()
+		switch tc.valid {
+		case true:
+			require.NoError(err, tc.msg)
+		case false:
+			require.Error(err, tc.msg)
+			continue
+		}
+
+		for _, kind := range []ProposalKind{ProposalKindUpgrade, ProposalKindCancelUpgrade, ProposalKindChangeParameters} {
+			require.Equal(slices.Contains(tc.applies, kind), tc.vd.AppliesTo(kind), tc.msg)
+		}
+	}
+}
+
 func TestProposalVoteSerialization(t *testing.T) {
 	require := require.New(t)
 
@@ -232,6 +334,7 @@ func TestProposalContentSerialization(t *testing.T) {
 	require := require.New(t)
 
 	votingPeriod := beacon.EpochTime(123)
+	allow := true
 
 	// NOTE: These cases should be synced with tests in runtime/src/consensus/governance.rs.
 	for _, tc := range []struct {
@@ -268,6 +371,36 @@ func TestProposalContentSerialization(t *testing.T) {
 				},
 			}, "oXFjaGFuZ2VfcGFyYW1ldGVyc6JmbW9kdWxla3Rlc3QtbW9kdWxlZ2NoYW5nZXOhbXZvdGluZ19wZXJpb2QYew==",
 		},
+		{
+			ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{
+					Module: "test-module",
+					Changes: cbor.Marshal(ConsensusParameterChanges{
+						AllowParametersPatch: &allow,
+					}),
+				},
+			}, "oXFjaGFuZ2VfcGFyYW1ldGVyc6JmbW9kdWxla3Rlc3QtbW9kdWxlZ2NoYW5nZXOhdmFsbG93X3BhcmFtZXRlcnNfcGF0Y2j1",
+		},
+		{
+			ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{
+					Module: "test-module",
+					Changes: cbor.Marshal(ConsensusParameterChanges{
+						AllowVoteDelegation: &allow,
+					}),
+				},
+			}, "oXFjaGFuZ2VfcGFyYW1ldGVyc6JmbW9kdWxla3Rlc3QtbW9kdWxlZ2NoYW5nZXOhdWFsbG93X3ZvdGVfZGVsZWdhdGlvbvU=",
+		},
+		{
+			ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{
+					Module: "test-module",
+					Patch: cbor.Marshal(ConsensusParameterChanges{
+						VotingPeriod: &votingPeriod,
+					}),
+				},
+			}, "oXFjaGFuZ2VfcGFyYW1ldGVyc6JlcGF0Y2ihbXZvdGluZ19wZXJpb2QYe2Ztb2R1bGVrdGVzdC1tb2R1bGU=",
+		},
 	} {
 		enc := cbor.Marshal(tc.content)
 		require.Equal(tc.expectedBase64, base64.StdEncoding.EncodeToString(enc), "serialization should match")
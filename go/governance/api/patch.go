@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+// ParameterChanges is the interface implemented by module-specific consensus parameter changes.
+type ParameterChanges[P any] interface {
+	// SanityCheck performs a sanity check on the consensus parameter changes.
+	SanityCheck() error
+
+	// Apply applies changes to the given consensus parameters.
+	Apply(params *P) error
+}
+
+// Parameters is the interface implemented by module consensus parameters.
+type Parameters[P any] interface {
+	*P
+
+	// SanityCheck performs a sanity check on the consensus parameters.
+	SanityCheck() error
+}
+
+// ApplyParametersPatch applies a generic consensus parameters patch to the given module
+// consensus parameters.
+//
+// The patch is a CBOR map from top-level consensus parameter field names to their new values.
+// Each value replaces the corresponding field as a whole. The patched parameters are validated
+// against the schema of the parameters type, so patches with unknown fields or values of an
+// invalid type are rejected. Patches changing any of the given immutable fields are rejected as
+// well. The parameters are only modified in case the patch is valid.
+func ApplyParametersPatch[P any](params *P, patch cbor.RawMessage, immutable []string) error {
+	var changes map[string]cbor.RawMessage
+	if err := cbor.Unmarshal(patch, &changes); err != nil {
+		return fmt.Errorf("malformed parameters patch: %w", err)
+	}
+	if len(changes) == 0 {
+		return fmt.Errorf("parameters patch should not be empty")
+	}
+	for _, name := range immutable {
+		if _, ok := changes[name]; ok {
+			return fmt.Errorf("parameter '%s' cannot be changed", name)
+		}
+	}
+
+	var fields map[string]cbor.RawMessage
+	if err := cbor.Unmarshal(cbor.Marshal(params), &fields); err != nil {
+		return fmt.Errorf("failed to decode consensus parameters: %w", err)
+	}
+	if fields == nil {
+		fields = make(map[string]cbor.RawMessage)
+	}
+	for name, value := range changes {
+		fields[name] = value
+	}
+
+	// Decode into a fresh instance so that the schema is enforced (unknown fields are rejected).
+	var patched P
+	if err := cbor.Unmarshal(cbor.Marshal(fields), &patched); err != nil {
+		return fmt.Errorf("invalid parameters patch: %w", err)
+	}
+	*params = patched
+
+	return nil
+}
+
+// ApplyChangeParametersProposal applies the consensus parameter changes from the given proposal to
+// the given module consensus parameters.
+//
+// In case the proposal contains a generic parameters patch, the patch is applied, rejecting any
+// changes to the given immutable fields. Otherwise, the module-specific changes are decoded into
+// changes, sanity checked and applied. Modules that do not define module-specific changes should
+// pass nil changes in which case only patches are supported.
+//
+// In both cases the resulting parameters must pass the parameters sanity check, otherwise the
+// parameters are left unchanged.
+func ApplyChangeParametersProposal[P any, PP Parameters[P]](
+	p *ChangeParametersProposal,
+	params PP,
+	changes ParameterChanges[P],
+	immutable []string,
+) error {
+	// Work on a copy so that the parameters are only modified in case all changes are valid.
+	var patched P
+	if err := cbor.Unmarshal(cbor.Marshal(params), &patched); err != nil {
+		return fmt.Errorf("failed to copy consensus parameters: %w", err)
+	}
+	switch {
+	case p.Patch != nil:
+		if err := ApplyParametersPatch(&patched, p.Patch, immutable); err != nil {
+			return fmt.Errorf("failed to apply consensus parameters patch: %w", err)
+		}
+	case changes == nil:
+		return fmt.Errorf("module-specific parameter changes are not supported")
+	default:
+		if err := cbor.Unmarshal(p.Changes, changes); err != nil {
+			return fmt.Errorf("failed to unmarshal consensus parameter changes: %w", err)
+		}
+		if err := changes.SanityCheck(); err != nil {
+			return fmt.Errorf("failed to validate consensus parameter changes: %w", err)
+		}
+		if err := changes.Apply(&patched); err != nil {
+			return fmt.Errorf("failed to apply consensus parameter changes: %w", err)
+		}
+	}
+
+	if err := PP(&patched).SanityCheck(); err != nil {
+		return fmt.Errorf("failed to validate consensus parameters: %w", err)
+	}
+	*params = patched
+
+	return nil
+}
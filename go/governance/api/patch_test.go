@@ -0,0 +1,139 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+type testParameters struct {
+	Flag    bool              `json:"flag,omitempty"`
+	Count   uint64            `json:"count,omitempty"`
+	Deposit quantity.Quantity `json:"deposit,omitempty"`
+	Costs   map[string]uint64 `json:"costs,omitempty"`
+}
+
+func (p *testParameters) SanityCheck() error {
+	if p.Count > 100 {
+		return fmt.Errorf("count should be at most 100")
+	}
+	return nil
+}
+
+type testParameterChanges struct {
+	Count *uint64 `json:"count,omitempty"`
+}
+
+func (c *testParameterChanges) SanityCheck() error {
+	if c.Count == nil {
+		return fmt.Errorf("consensus parameter changes should not be empty")
+	}
+	return nil
+}
+
+func (c *testParameterChanges) Apply(params *testParameters) error {
+	params.Count = *c.Count
+	return nil
+}
+
+func TestApplyParametersPatch(t *testing.T) {
+	require := require.New(t)
+
+	original := testParameters{
+		Count:   10,
+		Deposit: *quantity.NewFromUint64(100),
+		Costs:   map[string]uint64{"a": 1, "b": 2},
+	}
+
+	// Valid patch.
+	params := original
+	err := ApplyParametersPatch(&params, cbor.Marshal(map[string]any{
+		"flag":  true,
+		"count": uint64(0),
+		"costs": map[string]uint64{"c": 3},
+	}), []string{"deposit"})
+	require.NoError(err, "ApplyParametersPatch")
+	require.Equal(testParameters{
+		Flag:    true,
+		Deposit: *quantity.NewFromUint64(100),
+		Costs:   map[string]uint64{"c": 3},
+	}, params, "patch should replace the given fields only")
+
+	// Invalid patches.
+	for _, tc := range []struct {
+		msg   string
+		patch cbor.RawMessage
+	}{
+		{"malformed patch", cbor.RawMessage{0xff}},
+		{"empty patch", cbor.Marshal(map[string]any{})},
+		{"unknown field", cbor.Marshal(map[string]any{"count": uint64(1), "unknown": uint64(1)})},
+		{"invalid type", cbor.Marshal(map[string]any{"count": "foo"})},
+		{"immutable field", cbor.Marshal(map[string]any{"count": uint64(1), "deposit": uint64(1)})},
+	} {
+		params = original
+		err = ApplyParametersPatch(&params, tc.patch, []string{"deposit"})
+		require.Error(err, tc.msg)
+		require.Equal(original, params, "parameters should not change on invalid patch (%s)", tc.msg)
+	}
+}
+
+func TestApplyChangeParametersProposal(t *testing.T) {
+	require := require.New(t)
+
+	count := uint64(42)
+	proposal := ChangeParametersProposal{
+		Module:  "test",
+		Changes: cbor.Marshal(testParameterChanges{Count: &count}),
+	}
+
+	// Module-specific changes.
+	var params testParameters
+	err := ApplyChangeParametersProposal(&proposal, &params, &testParameterChanges{}, nil)
+	require.NoError(err, "ApplyChangeParametersProposal")
+	require.EqualValues(42, params.Count)
+
+	err = ApplyChangeParametersProposal(&proposal, &params, nil, nil)
+	require.Error(err, "module-specific changes should fail when not supported")
+
+	proposal.Changes = cbor.Marshal(testParameterChanges{})
+	err = ApplyChangeParametersProposal(&proposal, &params, &testParameterChanges{}, nil)
+	require.EqualError(err, "failed to validate consensus parameter changes: consensus parameter changes should not be empty")
+
+	count = 101
+	proposal.Changes = cbor.Marshal(testParameterChanges{Count: &count})
+	err = ApplyChangeParametersProposal(&proposal, &params, &testParameterChanges{}, nil)
+	require.EqualError(err, "failed to validate consensus parameters: count should be at most 100")
+	require.EqualValues(42, params.Count, "parameters should not change on invalid changes")
+
+	// Generic patch.
+	proposal = ChangeParametersProposal{
+		Module: "test",
+		Patch:  cbor.Marshal(map[string]any{"count": uint64(7)}),
+	}
+	err = ApplyChangeParametersProposal(&proposal, &params, nil, nil)
+	require.NoError(err, "ApplyChangeParametersProposal with patch")
+	require.EqualValues(7, params.Count)
+
+	// Patches may change fields that module-specific changes do not support.
+	proposal.Patch = cbor.Marshal(map[string]any{"count": uint64(8), "flag": true})
+	err = ApplyChangeParametersProposal(&proposal, &params, &testParameterChanges{}, nil)
+	require.NoError(err, "ApplyChangeParametersProposal with patch")
+	require.EqualValues(8, params.Count)
+	require.True(params.Flag)
+
+	// Patches must not change immutable fields.
+	proposal.Patch = cbor.Marshal(map[string]any{"flag": false})
+	err = ApplyChangeParametersProposal(&proposal, &params, &testParameterChanges{}, []string{"flag"})
+	require.Error(err, "patching immutable fields should fail")
+	require.True(params.Flag, "parameters should not change on invalid patch")
+
+	// Patched parameters must pass the sanity check.
+	proposal.Patch = cbor.Marshal(map[string]any{"count": uint64(101)})
+	err = ApplyChangeParametersProposal(&proposal, &params, nil, nil)
+	require.EqualError(err, "failed to validate consensus parameters: count should be at most 100")
+	require.EqualValues(8, params.Count, "parameters should not change on invalid patch")
+}
@@ -6,6 +6,7 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -41,7 +42,9 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.StakeThreshold == nil &&
 		c.UpgradeMinEpochDiff == nil &&
 		c.UpgradeCancelMinEpochDiff == nil &&
-		c.EnableChangeParametersProposal == nil {
+		c.EnableChangeParametersProposal == nil &&
+		c.AllowParametersPatch == nil &&
+		c.AllowVoteDelegation == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
@@ -153,9 +156,31 @@ func (g *Genesis) SanityCheck(now beacon.EpochTime, governanceDeposits *quantity
 			return fmt.Errorf("governance: votes sanity check failed: %w", err)
 		}
 	}
+	if err := SanityCheckVoteDelegates(g.VoteDelegates); err != nil {
+		return fmt.Errorf("governance: vote delegates sanity check failed: %w", err)
+	}
 	upgrades, _ := PendingUpgradesFromProposals(g.Proposals, now)
 	if err := SanityCheckPendingUpgrades(upgrades, now, &g.Parameters); err != nil {
 		return fmt.Errorf("governance: pending upgrades sanity check failed: %w", err)
 	}
 	return nil
 }
+
+// SanityCheckVoteDelegates sanity checks proxy voter assignments.
+func SanityCheckVoteDelegates(delegates map[staking.Address]*VoteDelegate) error {
+	for delegator, vd := range delegates {
+		if !delegator.IsValid() {
+			return fmt.Errorf("invalid delegator address: %s", delegator)
+		}
+		if vd == nil || vd.Delegate == nil {
+			return fmt.Errorf("missing delegate for delegator %s", delegator)
+		}
+		if err := vd.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid vote delegate for delegator %s: %w", delegator, err)
+		}
+		if vd.Delegate.Equal(delegator) {
+			return fmt.Errorf("delegator %s delegates votes to itself", delegator)
+		}
+	}
+	return nil
+}
@@ -8,6 +8,12 @@ import (
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
+// SanityCheck performs a sanity check on the consensus parameters.
+func (p *ConsensusParameters) SanityCheck() error {
+	// No validation at this time.
+	return nil
+}
+
 // AddStakeClaims adds stake claims for the given schemes.
 func AddStakeClaims(statuses []*Status, runtimes []*registry.Runtime, escrows map[staking.Address]*staking.EscrowAccount) error {
 	churps := make(map[common.Namespace][]uint8)
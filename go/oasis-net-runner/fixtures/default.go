@@ -67,7 +67,8 @@ func newDefaultFixture() (*oasis.NetworkFixture, error) {
 	// mainnet and testnet setting.
 	stakingGenesis := staking.Genesis{
 		Parameters: staking.ConsensusParameters{
-			MaxAllowances: 16,
+			MaxAllowances:     16,
+			MaxBatchTransfers: 64,
 		},
 	}
 	if genesis := viper.GetString(cfgStakingGenesis); genesis != "" {
@@ -32,6 +32,13 @@ const (
 	// CfgTxFeeGas configures the maximum gas limit.
 	CfgTxFeeGas = "transaction.fee.gas"
 
+	// CfgTxValidUntilHeight configures the last consensus height at which the transaction can be
+	// executed.
+	CfgTxValidUntilHeight = "transaction.valid_until.height"
+
+	// CfgTxValidUntilEpoch configures the last epoch in which the transaction can be executed.
+	CfgTxValidUntilEpoch = "transaction.valid_until.epoch"
+
 	// CfgTxFile configures the filename for the transaction.
 	CfgTxFile = "transaction.file"
 
@@ -92,7 +99,29 @@ func GetTxNonceAndFee() (uint64, *transaction.Fee) {
 	return nonce, &fee
 }
 
+// GetTxValidUntil returns the configured transaction validity bound, if any.
+func GetTxValidUntil() *transaction.ValidUntil {
+	vu := transaction.ValidUntil{
+		Height: viper.GetInt64(CfgTxValidUntilHeight),
+		Epoch:  viper.GetUint64(CfgTxValidUntilEpoch),
+	}
+	if vu.Height == 0 && vu.Epoch == 0 {
+		return nil
+	}
+	if err := vu.SanityCheck(); err != nil {
+		logger.Error("invalid transaction validity bound",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return &vu
+}
+
 func SignAndSaveTx(ctx context.Context, tx *transaction.Transaction, signer signature.Signer) {
+	if tx.ValidUntil == nil {
+		tx.ValidUntil = GetTxValidUntil()
+	}
+
 	if viper.GetBool(CfgTxUnsigned) {
 		rawUnsignedTx := cbor.Marshal(tx)
 		if err := os.WriteFile(viper.GetString(CfgTxFile), rawUnsignedTx, 0o600); err != nil {
@@ -162,6 +191,8 @@ func init() {
 	TxFlags.Uint64(CfgTxNonce, 0, "nonce of the signing account")
 	TxFlags.Uint64(CfgTxFeeAmount, 0, "transaction fee in base units")
 	TxFlags.String(CfgTxFeeGas, "0", "maximum transaction gas limit")
+	TxFlags.Int64(CfgTxValidUntilHeight, 0, "last consensus height at which the transaction can be executed (0 for no bound)")
+	TxFlags.Uint64(CfgTxValidUntilEpoch, 0, "last epoch in which the transaction can be executed (0 for no bound)")
 	TxFlags.Bool(CfgTxUnsigned, false, "generate an unsigned transaction")
 	_ = viper.BindPFlags(TxFlags)
 	TxFlags.AddFlagSet(TxFileFlags)
@@ -81,6 +81,8 @@ const (
 	CfgGovernanceUpgradeMinEpochDiff            = "governance.upgrade_min_epoch_diff"
 	CfgGovernanceVotingPeriod                   = "governance.voting_period"
 	CfgGovernanceEnableChangeParametersProposal = "governance.enable_change_parameters_proposal"
+	CfgGovernanceAllowParametersPatch           = "governance.allow_parameters_patch"
+	CfgGovernanceAllowVoteDelegation            = "governance.allow_vote_delegation"
 
 	// Beacon config flags.
 	CfgBeaconBackend                  = "beacon.backend"
@@ -114,6 +116,7 @@ const (
 	CfgConsensusStateCheckpointNumKept   = "consensus.state_checkpoint.num_kept"
 	CfgConsensusStateCheckpointChunkSize = "consensus.state_checkpoint.chunk_size"
 	CfgConsensusGasCostsTxByte           = "consensus.gas_costs.tx_byte"
+	CfgConsensusMaxBatchCalls            = "consensus.max_batch_calls"
 	cfgConsensusBlacklistPublicKey       = "consensus.blacklist_public_key"
 	CfgConsensusFeatureVersion           = "consensus.feature_version"
 
@@ -249,6 +252,8 @@ func doInitGenesis(*cobra.Command, []string) {
 			UpgradeMinEpochDiff:            beacon.EpochTime(viper.GetUint64(CfgGovernanceUpgradeMinEpochDiff)),
 			VotingPeriod:                   beacon.EpochTime(viper.GetUint64(CfgGovernanceVotingPeriod)),
 			EnableChangeParametersProposal: viper.GetBool(CfgGovernanceEnableChangeParametersProposal),
+			AllowParametersPatch:           viper.GetBool(CfgGovernanceAllowParametersPatch),
+			AllowVoteDelegation:            viper.GetBool(CfgGovernanceAllowVoteDelegation),
 		},
 	}
 
@@ -313,6 +318,7 @@ func doInitGenesis(*cobra.Command, []string) {
 			GasCosts: transaction.Costs{
 				consensusGenesis.GasOpTxByte: transaction.Gas(viper.GetUint64(CfgConsensusGasCostsTxByte)),
 			},
+			MaxBatchCalls:      viper.GetUint16(CfgConsensusMaxBatchCalls),
 			PublicKeyBlacklist: pkBlacklist,
 			FeatureVersion:     featureVersion,
 		},
@@ -834,6 +840,8 @@ func init() {
 	initGenesisFlags.Uint64(CfgGovernanceUpgradeMinEpochDiff, 300, "minimum number of epochs the upgrade needs to be scheduled in advance")
 	initGenesisFlags.Uint64(CfgGovernanceVotingPeriod, 100, "voting period (in epochs)")
 	initGenesisFlags.Bool(CfgGovernanceEnableChangeParametersProposal, true, "enable change parameters proposals")
+	initGenesisFlags.Bool(CfgGovernanceAllowParametersPatch, false, "allow generic consensus parameter patches in change parameters proposals")
+	initGenesisFlags.Bool(CfgGovernanceAllowVoteDelegation, false, "allow accounts to assign proxy voters for their delegations")
 
 	// Beacon config flags.
 	initGenesisFlags.String(CfgBeaconBackend, "insecure", "beacon backend")
@@ -871,6 +879,7 @@ func init() {
 	initGenesisFlags.Uint64(CfgConsensusStateCheckpointNumKept, 2, "number of kept consensus state checkpoints")
 	initGenesisFlags.String(CfgConsensusStateCheckpointChunkSize, "8mb", "consensus state checkpoint chunk size (in bytes)")
 	initGenesisFlags.Uint64(CfgConsensusGasCostsTxByte, 1, "consensus gas costs: each transaction byte")
+	initGenesisFlags.Uint16(CfgConsensusMaxBatchCalls, 0, "maximum number of calls in a batch transaction (0 disables batches)")
 	initGenesisFlags.StringSlice(cfgConsensusBlacklistPublicKey, nil, "blacklist public key")
 	initGenesisFlags.String(CfgConsensusFeatureVersion, "", "latest consensus breaking software feature version")
 
@@ -3,6 +3,7 @@ package stake
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/big"
 	"os"
 
@@ -10,6 +11,7 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -18,6 +20,7 @@ import (
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 const (
@@ -192,6 +195,20 @@ func doAccountInfo(cmd *cobra.Command, _ []string) {
 	prettyPrintAccountBalanceAndDelegationsFrom(ctx, addr, acct.General, outgoingDelegationInfos, outgoingDebondingDelegationInfos, "  ", os.Stdout)
 	fmt.Println()
 
+	if acct.General.Vesting != nil {
+		epoch, err := consensusClient.Beacon().GetEpoch(ctx, height)
+		if err != nil {
+			logger.Error("failed to fetch epoch",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		fmt.Println("Vesting Schedule:")
+		prettyPrintVesting(ctx, acct.General, epoch, "  ", os.Stdout)
+		fmt.Println()
+	}
+
 	if len(acct.General.Allowances) > 0 {
 		fmt.Println("Allowances for this Account:")
 		prettyPrintAllowances(ctx, addr, acct.General.Allowances, "  ", os.Stdout)
@@ -227,6 +244,36 @@ func doAccountInfo(cmd *cobra.Command, _ []string) {
 	fmt.Printf("Nonce: %d\n", acct.General.Nonce)
 }
 
+// prettyPrintVesting pretty-prints the vesting schedule of the given general
+// account together with its locked and unlocked general balance at the given
+// epoch.
+func prettyPrintVesting(
+	ctx context.Context,
+	generalAccount api.GeneralAccount,
+	epoch beacon.EpochTime,
+	prefix string,
+	w io.Writer,
+) {
+	generalAccount.Vesting.PrettyPrint(ctx, prefix, w)
+
+	// Locked tokens may be escrowed, so only the part covered by the general
+	// balance is reported as locked here.
+	locked := generalAccount.LockedBalance(epoch)
+	if locked.Cmp(&generalAccount.Balance) > 0 {
+		locked = generalAccount.Balance.Clone()
+	}
+	unlocked := generalAccount.Balance.Clone()
+	_ = unlocked.Sub(locked)
+
+	fmt.Fprintf(w, "%sLocked:   ", prefix)
+	token.PrettyPrintAmount(ctx, *locked, w)
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%sUnlocked: ", prefix)
+	token.PrettyPrintAmount(ctx, *unlocked, w)
+	fmt.Fprintln(w)
+}
+
 func doAccountNonce(cmd *cobra.Command, _ []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
@@ -0,0 +1,306 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci"
+	cmtCommon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage"
+)
+
+const (
+	// CfgCheckpointVersion configures the root version of the checkpoint to export.
+	CfgCheckpointVersion = "version"
+	// CfgCheckpointOut configures the path of the exported checkpoint archive.
+	CfgCheckpointOut = "out"
+	// CfgCheckpointIn configures the path of the checkpoint archive to import.
+	CfgCheckpointIn = "in"
+	// CfgCheckpointRound configures the runtime round of the checkpoint archive to import.
+	CfgCheckpointRound = "round"
+	// CfgCheckpointStateRoot configures the trusted state root hash of the checkpoint archive to
+	// import.
+	CfgCheckpointStateRoot = "state_root"
+	// CfgCheckpointIORoot configures the trusted IO root hash of the checkpoint archive to import.
+	CfgCheckpointIORoot = "io_root"
+
+	// checkpointTargetConsensus is the argument selecting the consensus state.
+	checkpointTargetConsensus = "consensus"
+)
+
+var (
+	checkpointExportFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	checkpointImportFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	storageCheckpointCmd = &cobra.Command{
+		Use:   "checkpoint",
+		Short: "storage checkpoint utilities",
+	}
+
+	storageCheckpointExportCmd = &cobra.Command{
+		Use:   "export <runtime|consensus>",
+		Args:  cobra.ExactArgs(1),
+		Short: "export an existing checkpoint into a portable archive file",
+		RunE:  doCheckpointExport,
+	}
+
+	storageCheckpointImportCmd = &cobra.Command{
+		Use:   "import <runtime>",
+		Args:  cobra.ExactArgs(1),
+		Short: "import a runtime checkpoint archive file into a fresh node database",
+		Long: "Import a runtime checkpoint archive file into a fresh node database.\n\n" +
+			"Runtime checkpoints cover both the state and the IO root of a round, so the trusted " +
+			"state and IO root hashes of the round (e.g., as obtained from the runtime block " +
+			"header) must be given. Importing consensus checkpoints is not supported as it " +
+			"would not restore the CometBFT block store and state.",
+		RunE: doCheckpointImport,
+	}
+)
+
+// openCheckpointStorage opens the local storage backend for the given runtime or the consensus
+// state in case the target is "consensus".
+func openCheckpointStorage(target string, create bool) (storageApi.LocalBackend, common.Namespace, error) {
+	dataDir := cmdCommon.DataDir()
+
+	if target == checkpointTargetConsensus {
+		ldb, _, _, err := abci.InitStateStorage(&abci.ApplicationConfig{
+			DataDir:             filepath.Join(dataDir, cmtCommon.StateDir),
+			StorageBackend:      config.GlobalConfig.Storage.Backend,
+			DisableCheckpointer: true,
+		})
+		if err != nil {
+			return nil, common.Namespace{}, fmt.Errorf("failed to initialize consensus state storage: %w", err)
+		}
+		return ldb, common.Namespace{}, nil
+	}
+
+	runtimes, err := parseRuntimes([]string{target})
+	if err != nil {
+		return nil, common.Namespace{}, err
+	}
+	rt := runtimes[0]
+
+	runtimeDir := registry.GetRuntimeStateDir(dataDir, rt)
+	switch create {
+	case true:
+		if err = common.Mkdir(runtimeDir); err != nil {
+			return nil, common.Namespace{}, fmt.Errorf("failed to create runtime state directory: %w", err)
+		}
+	case false:
+		if _, err = os.Stat(runtimeDir); err != nil {
+			return nil, common.Namespace{}, fmt.Errorf("failed to access runtime state directory: %w", err)
+		}
+	}
+	ldb, err := workerStorage.NewLocalBackend(runtimeDir, rt)
+	if err != nil {
+		return nil, common.Namespace{}, fmt.Errorf("failed to initialize runtime storage: %w", err)
+	}
+	return ldb, rt, nil
+}
+
+// collectCheckpoints returns all checkpoints for the given root version (or the latest version in
+// case version is zero) together with any checkpoints that they depend on, in restoration order.
+func collectCheckpoints(ctx context.Context, ldb storageApi.LocalBackend, ns common.Namespace, version uint64) ([]*checkpoint.Metadata, error) {
+	cps, err := checkpoint.GetCheckpointsAllVersions(ctx, ldb.Checkpointer(), &checkpoint.GetCheckpointsRequest{
+		Namespace:     ns,
+		IncludeDeltas: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoints: %w", err)
+	}
+
+	latest := version == 0
+	cpsByRoot := make(map[node.Root]*checkpoint.Metadata)
+	for _, cp := range cps {
+		cpsByRoot[cp.Root] = cp
+		if latest {
+			version = max(version, cp.Root.Version)
+		}
+	}
+
+	selected := make(map[node.Root]*checkpoint.Metadata)
+	for _, cp := range cps {
+		if cp.Root.Version != version {
+			continue
+		}
+
+		// Include the whole delta chain.
+		for cp != nil && selected[cp.Root] == nil {
+			selected[cp.Root] = cp
+			if !cp.IsDelta() {
+				break
+			}
+
+			base := cpsByRoot[*cp.Base]
+			if base == nil {
+				return nil, fmt.Errorf("base checkpoint %v of checkpoint %v not found", *cp.Base, cp.Root)
+			}
+			cp = base
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no checkpoints found for version %d", version)
+	}
+
+	result := make([]*checkpoint.Metadata, 0, len(selected))
+	for _, cp := range selected {
+		result = append(result, cp)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Root.Version == result[j].Root.Version {
+			return result[i].Root.Type < result[j].Root.Type
+		}
+		return result[i].Root.Version < result[j].Root.Version
+	})
+	return result, nil
+}
+
+func doCheckpointExport(_ *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	out := viper.GetString(CfgCheckpointOut)
+	if out == "" {
+		return fmt.Errorf("output file must be set")
+	}
+
+	ldb, ns, err := openCheckpointStorage(args[0], false)
+	if err != nil {
+		return err
+	}
+	defer ldb.Cleanup()
+
+	cps, err := collectCheckpoints(ctx, ldb, ns, viper.GetUint64(CfgCheckpointVersion))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer f.Close()
+
+	if err = checkpoint.ExportArchive(ctx, ldb.Checkpointer(), cps, f); err != nil {
+		_ = os.Remove(out)
+		return fmt.Errorf("failed to export checkpoints: %w", err)
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync output file: %w", err)
+	}
+
+	for _, cp := range cps {
+		logger.Info("exported checkpoint",
+			"root", cp.Root,
+			"delta", cp.IsDelta(),
+			"chunks", len(cp.Chunks),
+		)
+		if pretty {
+			fmt.Printf("Exported checkpoint for root %v (%d chunks)\n", cp.Root, len(cp.Chunks))
+		}
+	}
+	return nil
+}
+
+func doCheckpointImport(_ *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	in := viper.GetString(CfgCheckpointIn)
+	if in == "" {
+		return fmt.Errorf("input file must be set")
+	}
+
+	if args[0] == checkpointTargetConsensus {
+		return fmt.Errorf("importing consensus checkpoints is not supported")
+	}
+
+	var stateRoot, ioRoot hash.Hash
+	if err := stateRoot.UnmarshalHex(viper.GetString(CfgCheckpointStateRoot)); err != nil {
+		return fmt.Errorf("malformed trusted state root: %w", err)
+	}
+	if err := ioRoot.UnmarshalHex(viper.GetString(CfgCheckpointIORoot)); err != nil {
+		return fmt.Errorf("malformed trusted IO root: %w", err)
+	}
+
+	f, err := os.Open(in)
+	if err != nil {
+		return fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer f.Close()
+
+	ldb, ns, err := openCheckpointStorage(args[0], true)
+	if err != nil {
+		return err
+	}
+	defer ldb.Cleanup()
+
+	round := viper.GetUint64(CfgCheckpointRound)
+	trustedRoots := []node.Root{
+		{
+			Namespace: ns,
+			Version:   round,
+			Type:      node.RootTypeState,
+			Hash:      stateRoot,
+		},
+		{
+			Namespace: ns,
+			Version:   round,
+			Type:      node.RootTypeIO,
+			Hash:      ioRoot,
+		},
+	}
+
+	if latest, ok := ldb.NodeDB().GetLatestVersion(); ok {
+		return fmt.Errorf("node database is not empty (latest version: %d)", latest)
+	}
+
+	cps, err := checkpoint.ImportArchive(ctx, ldb.NodeDB(), f, trustedRoots)
+	if err != nil {
+		return fmt.Errorf("failed to import checkpoints: %w", err)
+	}
+
+	for _, cp := range cps {
+		logger.Info("imported checkpoint",
+			"root", cp.Root,
+			"delta", cp.IsDelta(),
+		)
+		if pretty {
+			fmt.Printf("Imported checkpoint for root %v\n", cp.Root)
+		}
+	}
+	return nil
+}
+
+func registerCheckpointCmds(parentCmd *cobra.Command) {
+	storageCheckpointExportCmd.Flags().AddFlagSet(checkpointExportFlags)
+	storageCheckpointImportCmd.Flags().AddFlagSet(checkpointImportFlags)
+	storageCheckpointCmd.AddCommand(storageCheckpointExportCmd)
+	storageCheckpointCmd.AddCommand(storageCheckpointImportCmd)
+	parentCmd.AddCommand(storageCheckpointCmd)
+}
+
+func init() {
+	checkpointExportFlags.Uint64(CfgCheckpointVersion, 0, "root version of the checkpoint to export (0 = latest)")
+	checkpointExportFlags.String(CfgCheckpointOut, "", "path to the output archive file")
+	_ = viper.BindPFlags(checkpointExportFlags)
+
+	checkpointImportFlags.String(CfgCheckpointIn, "", "path to the input archive file")
+	checkpointImportFlags.Uint64(CfgCheckpointRound, 0, "runtime round that the archive must restore")
+	checkpointImportFlags.String(CfgCheckpointStateRoot, "", "hex-encoded trusted state root hash of the round (required)")
+	checkpointImportFlags.String(CfgCheckpointIORoot, "", "hex-encoded trusted IO root hash of the round (required)")
+	_ = viper.BindPFlags(checkpointImportFlags)
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/localstorage"
+	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+)
+
+// CfgLocalStorageFile configures the path of the local storage export file.
+const CfgLocalStorageFile = "file"
+
+var (
+	localStorageFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	storageLocalStorageCmd = &cobra.Command{
+		Use:   "local-storage",
+		Short: "runtime local storage utilities",
+	}
+
+	storageLocalStorageExportCmd = &cobra.Command{
+		Use:   "export <runtime>",
+		Args:  cobra.ExactArgs(1),
+		Short: "export the local storage of a runtime into a portable file",
+		RunE:  doLocalStorageExport,
+	}
+
+	storageLocalStorageImportCmd = &cobra.Command{
+		Use:   "import <runtime>",
+		Args:  cobra.ExactArgs(1),
+		Short: "import a local storage file into the local storage of a runtime",
+		RunE:  doLocalStorageImport,
+	}
+)
+
+// openLocalStorage opens the local storage of the given runtime. The node must not be running.
+func openLocalStorage(target string, create bool) (localstorage.LocalStorage, common.Namespace, error) {
+	runtimes, err := parseRuntimes([]string{target})
+	if err != nil {
+		return nil, common.Namespace{}, err
+	}
+	rt := runtimes[0]
+
+	runtimeDir := registry.GetRuntimeStateDir(cmdCommon.DataDir(), rt)
+	switch create {
+	case true:
+		if err = common.Mkdir(runtimeDir); err != nil {
+			return nil, common.Namespace{}, fmt.Errorf("failed to create runtime state directory: %w", err)
+		}
+	case false:
+		if _, err = os.Stat(filepath.Join(runtimeDir, registry.LocalStorageFile)); err != nil {
+			return nil, common.Namespace{}, fmt.Errorf("failed to access local storage: %w", err)
+		}
+	}
+
+	ls, err := localstorage.New(runtimeDir, registry.LocalStorageFile, rt)
+	if err != nil {
+		return nil, common.Namespace{}, err
+	}
+	return ls, rt, nil
+}
+
+func doLocalStorageExport(_ *cobra.Command, args []string) error {
+	out := viper.GetString(CfgLocalStorageFile)
+	if out == "" {
+		return fmt.Errorf("output file must be set")
+	}
+
+	ls, rt, err := openLocalStorage(args[0], false)
+	if err != nil {
+		return err
+	}
+	defer ls.Stop()
+
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer f.Close()
+
+	count, err := localstorage.Export(ls, rt, f)
+	if err != nil {
+		_ = os.Remove(out)
+		return fmt.Errorf("failed to export local storage: %w", err)
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync output file: %w", err)
+	}
+
+	logger.Info("exported local storage",
+		"runtime_id", rt,
+		"entries", count,
+	)
+	if pretty {
+		fmt.Printf("Exported %d local storage entries for runtime %v\n", count, rt)
+	}
+	return nil
+}
+
+func doLocalStorageImport(_ *cobra.Command, args []string) error {
+	in := viper.GetString(CfgLocalStorageFile)
+	if in == "" {
+		return fmt.Errorf("input file must be set")
+	}
+
+	f, err := os.Open(in)
+	if err != nil {
+		return fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer f.Close()
+
+	ls, rt, err := openLocalStorage(args[0], true)
+	if err != nil {
+		return err
+	}
+	defer ls.Stop()
+
+	count, err := localstorage.Import(ls, rt, f)
+	if err != nil {
+		return fmt.Errorf("failed to import local storage (%d entries imported): %w", count, err)
+	}
+
+	logger.Info("imported local storage",
+		"runtime_id", rt,
+		"entries", count,
+	)
+	if pretty {
+		fmt.Printf("Imported %d local storage entries for runtime %v\n", count, rt)
+	}
+	return nil
+}
+
+func registerLocalStorageCmds(parentCmd *cobra.Command) {
+	storageLocalStorageExportCmd.Flags().AddFlagSet(localStorageFlags)
+	storageLocalStorageImportCmd.Flags().AddFlagSet(localStorageFlags)
+	storageLocalStorageCmd.AddCommand(storageLocalStorageExportCmd)
+	storageLocalStorageCmd.AddCommand(storageLocalStorageImportCmd)
+	parentCmd.AddCommand(storageLocalStorageCmd)
+}
+
+func init() {
+	localStorageFlags.String(CfgLocalStorageFile, "", "path to the local storage export file")
+	_ = viper.BindPFlags(localStorageFlags)
+}
@@ -166,7 +166,7 @@ func doMigrate(_ *cobra.Command, args []string) error {
 			runtimeDir := registry.GetRuntimeStateDir(dataDir, rt)
 
 			prunerFactory := history.NewNonePrunerFactory()
-			history, err := history.New(rt, runtimeDir, prunerFactory, false)
+			history, err := history.New(rt, runtimeDir, prunerFactory, false, false, false)
 			if err != nil {
 				return fmt.Errorf("error creating history provider: %w", err)
 			}
@@ -290,5 +290,7 @@ func Register(parentCmd *cobra.Command) {
 	storageCmd.AddCommand(storageMigrateCmd)
 	storageCmd.AddCommand(storageCheckCmd)
 	storageCmd.AddCommand(storageRenameNsCmd)
+	registerCheckpointCmds(storageCmd)
+	registerLocalStorageCmds(storageCmd)
 	parentCmd.AddCommand(storageCmd)
 }
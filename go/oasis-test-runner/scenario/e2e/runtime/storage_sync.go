@@ -113,7 +113,7 @@ func (sc *storageSyncImpl) Run(ctx context.Context, childEnv *env.Env) error { /
 		return fmt.Errorf("failed to connect with the first compute node: %w", err)
 	}
 
-	cps, err := ctrl.Storage.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{Version: 1, Namespace: KeyValueRuntimeID})
+	cps, err := ctrl.Storage.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{Version: checkpoint.LatestVersion, Namespace: KeyValueRuntimeID})
 	if err != nil {
 		return fmt.Errorf("failed to get checkpoints: %w", err)
 	}
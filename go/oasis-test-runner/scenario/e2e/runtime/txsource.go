@@ -250,8 +250,10 @@ func (sc *txSourceImpl) Fixture() (*oasis.NetworkFixture, error) {
 				staking.GasOpReclaimEscrow: 10,
 				staking.GasOpAllow:         10,
 				staking.GasOpWithdraw:      10,
+				staking.GasOpTransferBatch: 10,
 			},
 			MaxAllowances:             32,
+			MaxBatchTransfers:         32,
 			FeeSplitWeightPropose:     *quantity.NewFromUint64(2),
 			FeeSplitWeightVote:        *quantity.NewFromUint64(1),
 			FeeSplitWeightNextPropose: *quantity.NewFromUint64(1),
@@ -7,8 +7,10 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/churp"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
 )
 
 // Message is a message that can be sent by a runtime.
@@ -16,6 +18,8 @@ type Message struct {
 	Staking    *StakingMessage    `json:"staking,omitempty"`
 	Registry   *RegistryMessage   `json:"registry,omitempty"`
 	Governance *GovernanceMessage `json:"governance,omitempty"`
+	Vault      *VaultMessage      `json:"vault,omitempty"`
+	KeyManager *KeyManagerMessage `json:"keymanager,omitempty"`
 }
 
 // ValidateBasic performs basic validation of the runtime message.
@@ -27,6 +31,10 @@ func (m *Message) ValidateBasic() error {
 		return m.Registry.ValidateBasic()
 	case m.Governance != nil:
 		return m.Governance.ValidateBasic()
+	case m.Vault != nil:
+		return m.Vault.ValidateBasic()
+	case m.KeyManager != nil:
+		return m.KeyManager.ValidateBasic()
 	default:
 		return fmt.Errorf("runtime message has no fields set")
 	}
@@ -125,3 +133,62 @@ func (gm *GovernanceMessage) ValidateBasic() error {
 		return fmt.Errorf("governance runtime message has no fields set")
 	}
 }
+
+// VaultMessage is a runtime message that allows a runtime to perform vault operations.
+type VaultMessage struct {
+	cbor.Versioned
+
+	Create          *vault.Create          `json:"create,omitempty"`
+	AuthorizeAction *vault.AuthorizeAction `json:"authorize_action,omitempty"`
+	CancelAction    *vault.CancelAction    `json:"cancel_action,omitempty"`
+}
+
+// ValidateBasic performs basic validation of a vault message.
+func (vm *VaultMessage) ValidateBasic() error {
+	var setFields uint8
+	if vm.Create != nil {
+		// The vault authorities depend on the consensus parameters so they will be validated
+		// in the vault app when it processes the message.
+		setFields++
+	}
+	if vm.AuthorizeAction != nil {
+		// The action depends on the consensus parameters so it will be validated in the vault
+		// app when it processes the message.
+		setFields++
+	}
+	if vm.CancelAction != nil {
+		// No validation at this time.
+		setFields++
+	}
+	switch setFields {
+	case 0:
+		return fmt.Errorf("vault runtime message has no fields set")
+	case 1:
+		// Ok.
+		return nil
+	default:
+		return fmt.Errorf("vault runtime message has multiple fields set")
+	}
+}
+
+// KeyManagerMessage is a runtime message that allows a runtime to perform key manager operations.
+type KeyManagerMessage struct {
+	cbor.Versioned
+
+	CreateChurp *churp.CreateRequest `json:"create_churp,omitempty"`
+	UpdateChurp *churp.UpdateRequest `json:"update_churp,omitempty"`
+}
+
+// ValidateBasic performs basic validation of a key manager message.
+func (km *KeyManagerMessage) ValidateBasic() error {
+	switch {
+	case km.CreateChurp != nil && km.UpdateChurp != nil:
+		return fmt.Errorf("keymanager runtime message has multiple fields set")
+	case km.CreateChurp != nil, km.UpdateChurp != nil:
+		// The request will already be validated in the key manager app when it processes the
+		// message, so we don't have to do any validation here.
+		return nil
+	default:
+		return fmt.Errorf("keymanager runtime message has no fields set")
+	}
+}
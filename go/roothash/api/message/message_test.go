@@ -14,9 +14,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/churp"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
 )
 
 func TestMessageHash(t *testing.T) {
@@ -24,6 +26,7 @@ func TestMessageHash(t *testing.T) {
 
 	rt := newTestRuntime()
 	require.NotNil(rt, "newTestRuntime")
+	extraShares := uint8(2)
 
 	// NOTE: These cases should be synced with tests in runtime/src/consensus/roothash/messages.rs.
 	for _, tc := range []struct {
@@ -58,6 +61,23 @@ func TestMessageHash(t *testing.T) {
 				},
 			},
 		}, "03312ddb5c41a30fbd29fb91cf6bf26d58073996f89657ca4f3b3a43a98bfd0b"},
+		{[]Message{
+			{
+				Vault: &VaultMessage{
+					CancelAction: &vault.CancelAction{Nonce: 1},
+				},
+			},
+		}, "d79488bd38722bb37e879e062c60d23d281412bf3d35e6ed226e2e2b242eb970"},
+		{[]Message{
+			{
+				KeyManager: &KeyManagerMessage{
+					UpdateChurp: &churp.UpdateRequest{
+						Identity:    churp.Identity{ID: 1},
+						ExtraShares: &extraShares,
+					},
+				},
+			},
+		}, "38e078ef19928f9d8c482af42c6eacf2ea4593fb90c12802b143dfb4940a7cff"},
 	} {
 		var h hash.Hash
 		err := h.UnmarshalHex(tc.expectedHash)
@@ -86,6 +106,12 @@ func TestMessageValidateBasic(t *testing.T) {
 		{"GovernanceNoFieldsSet", Message{Governance: &GovernanceMessage{}}, false},
 		{"GovernanceInvalid", Message{Governance: &GovernanceMessage{CastVote: &api.ProposalVote{}, SubmitProposal: &api.ProposalContent{}}}, false},
 		{"GovernanceValid", Message{Governance: &GovernanceMessage{CastVote: &api.ProposalVote{}}}, true},
+		{"VaultNoFieldsSet", Message{Vault: &VaultMessage{}}, false},
+		{"VaultMultipleFieldsSet", Message{Vault: &VaultMessage{Create: &vault.Create{}, CancelAction: &vault.CancelAction{}}}, false},
+		{"VaultValid", Message{Vault: &VaultMessage{AuthorizeAction: &vault.AuthorizeAction{}}}, true},
+		{"KeyManagerNoFieldsSet", Message{KeyManager: &KeyManagerMessage{}}, false},
+		{"KeyManagerMultipleFieldsSet", Message{KeyManager: &KeyManagerMessage{CreateChurp: &churp.CreateRequest{}, UpdateChurp: &churp.UpdateRequest{}}}, false},
+		{"KeyManagerValid", Message{KeyManager: &KeyManagerMessage{CreateChurp: &churp.CreateRequest{}}}, true},
 	} {
 		err := tc.msg.ValidateBasic()
 		if tc.valid {
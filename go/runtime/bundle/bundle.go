@@ -10,7 +10,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/sigstruct"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
@@ -131,8 +133,9 @@ func (bnd *Bundle) Validate() error {
 		if !ok {
 			// Ignore the manifest not having a digest entry, though
 			// it having one and being valid (while quite a feat) is
-			// also ok.
-			if fn == manifestName {
+			// also ok. The same holds for the detached signatures
+			// which can't be covered by the manifest they sign.
+			if fn == manifestName || fn == signatureName {
 				continue
 			}
 			return fmt.Errorf("runtime/bundle: missing digest: '%s'", fn)
@@ -149,6 +152,11 @@ func (bnd *Bundle) Validate() error {
 		}
 	}
 
+	// Make sure the publisher signatures are valid if they exist.
+	if err := bnd.verifySignatures(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -316,9 +324,11 @@ func (bnd *Bundle) verifySgxSignature(comp *Component) error {
 // ResetManifest removes the serialized manifest from the bundle so that it can be regenerated on
 // the next call to Write.
 //
-// This needs to be used after doing modifications to bundles.
+// This needs to be used after doing modifications to bundles. As any modification of the
+// manifest invalidates the publisher signatures, those are removed as well.
 func (bnd *Bundle) ResetManifest() {
 	delete(bnd.Data, manifestName)
+	delete(bnd.Data, signatureName)
 }
 
 // Write serializes a runtime bundle to the on-disk representation.
@@ -424,6 +434,11 @@ func (bnd *Bundle) WriteExploded(dir string) error {
 
 	// Extract the bundle to disk.
 	for fn, data := range bnd.Data {
+		// Signatures are not covered by the manifest hash so they are handled separately.
+		if fn == signatureName {
+			continue
+		}
+
 		path := filepath.Join(dir, fn)
 
 		// Check to see if we have done this before, and be nice to SSDs by
@@ -473,6 +488,10 @@ func (bnd *Bundle) WriteExploded(dir string) error {
 		}
 	}
 
+	if err := bnd.writeExplodedSignatures(dir); err != nil {
+		return err
+	}
+
 	// Fix executable permissions.
 	for id, comp := range bnd.Manifest.GetAvailableComponents() {
 		if comp.ELF != nil {
@@ -528,7 +547,7 @@ func Open(fn string, opts ...OpenOption) (_ *Bundle, err error) {
 				return nil, fmt.Errorf("runtime/bundle: invalid manifest file name: '%s'", v.Name)
 			}
 		default:
-			if filepath.Dir(v.Name) != "." {
+			if v.Name != signatureName && filepath.Dir(v.Name) != "." {
 				return nil, fmt.Errorf("runtime/bundle: failed to sanitize path '%s'", v.Name)
 			}
 		}
@@ -567,6 +586,13 @@ func Open(fn string, opts ...OpenOption) (_ *Bundle, err error) {
 		return nil, err
 	}
 
+	// Verify the publisher, if requested.
+	if trusted := options.trustedPublishers[manifest.ID]; len(trusted) > 0 {
+		if err = bnd.VerifyPublisher(trusted); err != nil {
+			return nil, err
+		}
+	}
+
 	return bnd, nil
 }
 
@@ -622,7 +648,8 @@ func HashAllData(d Data) (hash.Hash, error) {
 
 // OpenOptions are options for opening bundle files.
 type OpenOptions struct {
-	manifestHash *hash.Hash
+	manifestHash      *hash.Hash
+	trustedPublishers map[common.Namespace][]signature.PublicKey
 }
 
 // NewOpenOptions creates options using default and given values.
@@ -643,3 +670,13 @@ func WithManifestHash(manifestHash hash.Hash) OpenOption {
 		o.manifestHash = &manifestHash
 	}
 }
+
+// WithTrustedPublishers sets the per-runtime trusted publishers for verification.
+//
+// Bundles of runtimes with configured trusted publishers must be signed by at least one
+// of them, while bundles of other runtimes are not verified.
+func WithTrustedPublishers(publishers map[common.Namespace][]signature.PublicKey) OpenOption {
+	return func(o *OpenOptions) {
+		o.trustedPublishers = publishers
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 )
@@ -189,6 +192,118 @@ func TestDetachedBundle(t *testing.T) {
 	})
 }
 
+func TestSignedBundle(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir := t.TempDir()
+	bundleFn := filepath.Join(tmpDir, "signed-bundle.orc")
+	manifest := &Manifest{
+		Name: "test-runtime",
+		ID: func() common.Namespace {
+			var id common.Namespace
+			if err := id.UnmarshalHex("c000000000000000ffffffffffffffffffffffffffffffffffffffffffffffff"); err != nil {
+				panic("failed to unmarshal id")
+			}
+			return id
+		}(),
+		Components: []*Component{
+			{
+				Kind: component.RONL,
+				ELF: &ELFMetadata{
+					Executable: "runtime.bin",
+				},
+			},
+		},
+	}
+	bundle := &Bundle{
+		Manifest: manifest,
+	}
+	err := bundle.Add(manifest.Components[0].ELF.Executable, NewBytesData(randBuffer(1234)))
+	require.NoError(err, "bundle.Add(elf)")
+
+	publisher := memorySigner.NewTestSigner("runtime/bundle: publisher")
+	untrusted := memorySigner.NewTestSigner("runtime/bundle: untrusted publisher")
+	trusted := map[common.Namespace][]signature.PublicKey{
+		manifest.ID: {publisher.Public()},
+	}
+
+	// Unsigned bundles should be rejected when trusted publishers are configured.
+	err = bundle.Write(bundleFn)
+	require.NoError(err, "bundle.Write")
+
+	_, err = Open(bundleFn)
+	require.NoError(err, "Open")
+	_, err = Open(bundleFn, WithTrustedPublishers(trusted))
+	require.ErrorIs(err, ErrBundleNotSigned)
+
+	// Bundles signed by untrusted publishers should be rejected.
+	err = bundle.Sign(untrusted)
+	require.NoError(err, "bundle.Sign")
+	err = bundle.Write(bundleFn)
+	require.NoError(err, "bundle.Write")
+
+	_, err = Open(bundleFn, WithTrustedPublishers(trusted))
+	require.ErrorIs(err, ErrUntrustedPublisher)
+
+	// Keep a copy of the bundle signed only by the untrusted publisher.
+	bundle1Fn := filepath.Join(tmpDir, "signed-bundle-1.orc")
+	err = bundle.Write(bundle1Fn)
+	require.NoError(err, "bundle.Write")
+	bundle1, err := Open(bundle1Fn)
+	require.NoError(err, "Open")
+	explodedDir := bundle1.ExplodedPath(tmpDir)
+	err = bundle1.WriteExploded(explodedDir)
+	require.NoError(err, "WriteExploded")
+	err = VerifyExplodedPublisher(manifest, explodedDir, trusted[manifest.ID])
+	require.ErrorIs(err, ErrUntrustedPublisher)
+
+	// Bundles signed by at least one trusted publisher should be accepted.
+	err = bundle.Sign(publisher)
+	require.NoError(err, "bundle.Sign")
+	err = bundle.Write(bundleFn)
+	require.NoError(err, "bundle.Write")
+
+	bundle2, err := Open(bundleFn, WithTrustedPublishers(trusted))
+	require.NoError(err, "Open")
+	sigs, err := bundle2.Signatures()
+	require.NoError(err, "Signatures")
+	require.Len(sigs, 2)
+
+	// Signatures should be merged with the signatures of a previously exploded bundle with the
+	// same manifest.
+	require.Equal(explodedDir, bundle2.ExplodedPath(tmpDir))
+	err = bundle2.WriteExploded(explodedDir)
+	require.NoError(err, "WriteExploded")
+	err = VerifyExplodedPublisher(manifest, explodedDir, trusted[manifest.ID])
+	require.NoError(err, "VerifyExplodedPublisher")
+	sigs, err = readSignatures(NewFileData(filepath.Join(explodedDir, signatureName)))
+	require.NoError(err, "readSignatures")
+	require.Len(sigs, 2)
+
+	// Exploding a bundle with fewer signatures should keep the existing ones.
+	err = bundle1.WriteExploded(explodedDir)
+	require.NoError(err, "WriteExploded")
+	err = VerifyExplodedPublisher(manifest, explodedDir, trusted[manifest.ID])
+	require.NoError(err, "VerifyExplodedPublisher")
+
+	// Invalid exploded signatures should be detected.
+	sigs[0].Signature[0] ^= 0xff
+	err = os.WriteFile(filepath.Join(explodedDir, signatureName), cbor.Marshal(sigs), 0o600)
+	require.NoError(err, "WriteFile")
+	err = bundle2.WriteExploded(explodedDir)
+	require.ErrorContains(err, "corrupted signatures")
+
+	// Modifying the manifest should invalidate the signatures.
+	manifest.Name = "modified-runtime"
+	err = bundle.Write(bundleFn)
+	require.ErrorContains(err, "invalid manifest signature")
+
+	bundle.ResetManifest()
+	sigs, err = bundle.Signatures()
+	require.NoError(err, "Signatures")
+	require.Empty(sigs)
+}
+
 func TestBytesData(t *testing.T) {
 	require := require.New(t)
 
@@ -78,6 +78,10 @@ type Component struct {
 	// Disabled specifies whether the component is disabled by default and needs to be explicitly
 	// enabled via node configuration to be used.
 	Disabled bool `json:"disabled,omitempty"`
+
+	// Resources are the (optional) resource limits of the component process. They are only
+	// enforced when the node has a cgroup root configured.
+	Resources *component.ResourceLimits `json:"resources,omitempty"`
 }
 
 // ID returns this component's identifier.
@@ -116,6 +120,12 @@ func (c *Component) Validate() error {
 			return fmt.Errorf("tdx: %w", err)
 		}
 	}
+	if c.Resources != nil {
+		err := c.Resources.Validate()
+		if err != nil {
+			return fmt.Errorf("resources: %w", err)
+		}
+	}
 
 	switch c.Kind {
 	case component.RONL:
@@ -46,3 +46,34 @@ func TestID(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceLimits(t *testing.T) {
+	require := require.New(t)
+
+	var limits *ResourceLimits
+	require.True(limits.IsEmpty(), "nil limits should be empty")
+	require.True((&ResourceLimits{}).IsEmpty(), "zero limits should be empty")
+
+	limits = &ResourceLimits{
+		MemoryMax: 1 << 30,
+		MilliCPUs: 1500,
+	}
+	require.False(limits.IsEmpty())
+	require.NoError(limits.Validate())
+	require.Error((&ResourceLimits{MilliCPUs: 1}).Validate(), "too low CPU limit should be rejected")
+
+	merged := limits.Merge(&ResourceLimits{
+		MilliCPUs: 500,
+		PIDsMax:   64,
+	})
+	require.Equal(&ResourceLimits{
+		MemoryMax: 1 << 30,
+		MilliCPUs: 500,
+		PIDsMax:   64,
+	}, merged)
+	require.EqualValues(1500, limits.MilliCPUs, "merge should not modify the original limits")
+
+	var empty *ResourceLimits
+	require.Equal(limits, limits.Merge(nil))
+	require.Equal(&ResourceLimits{PIDsMax: 64}, empty.Merge(&ResourceLimits{PIDsMax: 64}))
+}
@@ -0,0 +1,55 @@
+package component
+
+import "fmt"
+
+// minMilliCPUs is the minimum CPU limit (in thousandths of a CPU).
+const minMilliCPUs = 10
+
+// ResourceLimits are the resource limits of a component process.
+type ResourceLimits struct {
+	// MemoryMax is the maximum amount of memory (in bytes) that the component can use.
+	MemoryMax uint64 `json:"memory_max,omitempty" yaml:"memory_max,omitempty"`
+
+	// MilliCPUs is the maximum CPU bandwidth (in thousandths of a CPU) that the component can
+	// use, e.g. 1500 means one and a half CPUs.
+	MilliCPUs uint64 `json:"milli_cpus,omitempty" yaml:"milli_cpus,omitempty"`
+
+	// PIDsMax is the maximum number of processes (including threads) that the component can use.
+	PIDsMax uint64 `json:"pids_max,omitempty" yaml:"pids_max,omitempty"`
+}
+
+// Validate validates the resource limits.
+func (rl *ResourceLimits) Validate() error {
+	if rl.MilliCPUs > 0 && rl.MilliCPUs < minMilliCPUs {
+		return fmt.Errorf("CPU limit must be at least %d milli CPUs", minMilliCPUs)
+	}
+	return nil
+}
+
+// IsEmpty returns true iff no resource limits are set.
+func (rl *ResourceLimits) IsEmpty() bool {
+	return rl == nil || *rl == ResourceLimits{}
+}
+
+// Merge returns the resource limits where all limits set in the other resource limits take
+// precedence over the limits set in these resource limits.
+func (rl *ResourceLimits) Merge(other *ResourceLimits) *ResourceLimits {
+	var merged ResourceLimits
+	if rl != nil {
+		merged = *rl
+	}
+	if other == nil {
+		return &merged
+	}
+
+	if other.MemoryMax > 0 {
+		merged.MemoryMax = other.MemoryMax
+	}
+	if other.MilliCPUs > 0 {
+		merged.MilliCPUs = other.MilliCPUs
+	}
+	if other.PIDsMax > 0 {
+		merged.PIDsMax = other.PIDsMax
+	}
+	return &merged
+}
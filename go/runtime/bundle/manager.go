@@ -17,6 +17,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	cmSync "github.com/oasisprotocol/oasis-core/go/common/sync"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
@@ -69,6 +70,8 @@ type Manager struct {
 	runtimeBaseURLs map[common.Namespace][]string
 	globalBaseURLs  []string
 
+	trustedPublishers map[common.Namespace][]signature.PublicKey
+
 	triggerCh     chan struct{}
 	downloadQueue map[common.Namespace][]hash.Hash
 	cleanupQueue  map[common.Namespace]version.Version
@@ -100,9 +103,14 @@ func NewManager(dataDir string, runtimeIDs []common.Namespace, store ManifestSto
 		return nil, err
 	}
 
-	// Validate each runtime's registry URLs.
+	// Validate each runtime's registry URLs and collect trusted publishers.
 	runtimeBaseURLs := make(map[common.Namespace][]string)
+	trustedPublishers := make(map[common.Namespace][]signature.PublicKey)
 	for _, runtime := range config.GlobalConfig.Runtime.Runtimes {
+		if len(runtime.TrustedPublishers) > 0 {
+			trustedPublishers[runtime.ID] = runtime.TrustedPublishers
+		}
+
 		urls, err := validateAndNormalizeURLs(runtime.Registries)
 		if err != nil {
 			return nil, err
@@ -127,6 +135,7 @@ func NewManager(dataDir string, runtimeIDs []common.Namespace, store ManifestSto
 		runtimeIDs:         runtimes,
 		globalBaseURLs:     globalBaseURLs,
 		runtimeBaseURLs:    runtimeBaseURLs,
+		trustedPublishers:  trustedPublishers,
 		triggerCh:          make(chan struct{}, 1),
 		downloadQueue:      make(map[common.Namespace][]hash.Hash),
 		cleanupQueue:       make(map[common.Namespace]version.Version),
@@ -213,8 +222,11 @@ func (m *Manager) run(ctx context.Context) {
 }
 
 // Add adds bundle from the given path.
+//
+// If the bundle's runtime has trusted publishers configured, the bundle must be signed
+// by at least one of them.
 func (m *Manager) Add(path string) error {
-	manifest, err := m.explodeBundle(path)
+	manifest, err := m.explodeBundle(path, WithTrustedPublishers(m.trustedPublishers))
 	if err != nil {
 		m.logger.Error("failed to explode bundle",
 			"err", err,
@@ -412,7 +424,7 @@ func (m *Manager) tryDownloadBundle(manifestHash hash.Hash, baseURL string) erro
 	}
 	defer os.Remove(src)
 
-	manifest, err := m.explodeBundle(src, WithManifestHash(manifestHash))
+	manifest, err := m.explodeBundle(src, WithManifestHash(manifestHash), WithTrustedPublishers(m.trustedPublishers))
 	if err != nil {
 		m.logger.Error("failed to explode bundle",
 			"err", err,
@@ -546,6 +558,21 @@ func (m *Manager) loadManifests() ([]*ExplodedManifest, error) {
 			continue
 		}
 
+		// Re-verify the publisher of the stored manifest as trusted publishers may have been
+		// configured after the bundle was exploded.
+		if trusted := m.trustedPublishers[manifest.ID]; len(trusted) > 0 {
+			if err = VerifyExplodedPublisher(&manifest, dir, trusted); err != nil {
+				m.logger.Warn("removing bundle not signed by a trusted publisher",
+					"path", dir,
+					"err", err,
+				)
+				if err = m.removeBundle(dir); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
 		m.logger.Info("manifest loaded",
 			"name", manifest.Name,
 			"hash", manifest.Hash(),
@@ -676,7 +703,7 @@ func (m *Manager) explodeBundles(paths []string) ([]*ExplodedManifest, error) {
 
 	manifests := make([]*ExplodedManifest, 0)
 	for _, path := range paths {
-		manifest, err := m.explodeBundle(path)
+		manifest, err := m.explodeBundle(path, WithTrustedPublishers(m.trustedPublishers))
 		if err != nil {
 			return nil, err
 		}
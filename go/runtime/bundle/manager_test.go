@@ -1,11 +1,19 @@
 package bundle
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 )
 
 type mockStore struct {
@@ -56,3 +64,96 @@ func TestRegisterManifest(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, len(manifests), len(store.manifestHashes))
 }
+
+func TestManagerTrustedPublishers(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir := t.TempDir()
+	manager, err := NewManager(tmpDir, nil, newMockStore())
+	require.NoError(err)
+
+	publisher := memorySigner.NewTestSigner("runtime/bundle: manager publisher")
+	untrusted := memorySigner.NewTestSigner("runtime/bundle: manager untrusted publisher")
+
+	var runtimeID common.Namespace
+	err = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(err)
+
+	writeBundle := func(name string, signer signature.Signer) string {
+		manifest := &Manifest{
+			Name: name,
+			ID:   runtimeID,
+			Components: []*Component{
+				{
+					Kind: component.RONL,
+					ELF: &ELFMetadata{
+						Executable: "runtime.bin",
+					},
+				},
+			},
+		}
+		bnd := &Bundle{
+			Manifest: manifest,
+		}
+		err = bnd.Add(manifest.Components[0].ELF.Executable, NewBytesData(randBuffer(1234)))
+		require.NoError(err, "bundle.Add(elf)")
+		if signer != nil {
+			err = bnd.Sign(signer)
+			require.NoError(err, "bundle.Sign")
+		}
+
+		fn := filepath.Join(tmpDir, fmt.Sprintf("%s.orc", name))
+		err = bnd.Write(fn)
+		require.NoError(err, "bundle.Write")
+		return fn
+	}
+
+	signedFn := writeBundle("signed", publisher)
+	unsignedFn := writeBundle("unsigned", nil)
+	untrustedFn := writeBundle("untrusted", untrusted)
+
+	// Without trusted publishers, all bundles should be accepted.
+	exploded, err := manager.explodeBundles([]string{signedFn, unsignedFn, untrustedFn})
+	require.NoError(err, "explodeBundles")
+	require.Len(exploded, 3)
+
+	manifests, err := manager.loadManifests()
+	require.NoError(err, "loadManifests")
+	require.Len(manifests, 3)
+
+	// Configured bundles should be verified.
+	manager.trustedPublishers = map[common.Namespace][]signature.PublicKey{
+		runtimeID: {publisher.Public()},
+	}
+
+	_, err = manager.explodeBundles([]string{signedFn, unsignedFn})
+	require.ErrorIs(err, ErrBundleNotSigned, "unsigned configured bundles should be rejected")
+	_, err = manager.explodeBundles([]string{signedFn, untrustedFn})
+	require.ErrorIs(err, ErrUntrustedPublisher, "untrusted configured bundles should be rejected")
+	exploded, err = manager.explodeBundles([]string{signedFn})
+	require.NoError(err, "explodeBundles")
+	require.Len(exploded, 1)
+
+	// Previously exploded bundles should be re-verified and removed if not trusted.
+	manifests, err = manager.loadManifests()
+	require.NoError(err, "loadManifests")
+	require.Len(manifests, 1)
+	require.Equal("signed", manifests[0].Name)
+	require.Equal(exploded[0].ExplodedDataDir, manifests[0].ExplodedDataDir)
+
+	entries, err := os.ReadDir(ExplodedPath(tmpDir))
+	require.NoError(err, "ReadDir")
+	require.Len(entries, 1, "untrusted bundles should have been removed")
+
+	// Tampering with the stored manifest should invalidate the signature.
+	manifestFn := filepath.Join(manifests[0].ExplodedDataDir, manifestName)
+	manifests[0].Name = "tampered"
+	b, err := json.Marshal(manifests[0].Manifest)
+	require.NoError(err, "json.Marshal")
+	err = os.WriteFile(manifestFn, b, 0o600)
+	require.NoError(err, "WriteFile")
+
+	manifests, err = manager.loadManifests()
+	require.NoError(err, "loadManifests")
+	require.Empty(manifests, "tampered bundles should be rejected")
+}
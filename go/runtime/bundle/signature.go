@@ -0,0 +1,194 @@
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+// signatureName is the name of the file containing detached publisher signatures.
+const signatureName = manifestPath + "/MANIFEST.SIG"
+
+// ManifestSignatureContext is the signature context used for signing bundle manifests.
+var ManifestSignatureContext = signature.NewContext("oasis-core/runtime/bundle: manifest")
+
+var (
+	// ErrBundleNotSigned is the error returned when a bundle is not signed.
+	ErrBundleNotSigned = errors.New("runtime/bundle: bundle is not signed")
+
+	// ErrUntrustedPublisher is the error returned when a bundle is not signed by any of
+	// the trusted publishers.
+	ErrUntrustedPublisher = errors.New("runtime/bundle: bundle is not signed by a trusted publisher")
+)
+
+// Sign signs the bundle manifest hash with the given publisher signer and adds the signature
+// to the bundle.
+//
+// Signatures must be added after the manifest has been finalized as any modification of the
+// manifest invalidates them.
+func (bnd *Bundle) Sign(signer signature.Signer) error {
+	sigs, err := bnd.Signatures()
+	if err != nil {
+		return err
+	}
+
+	manifestHash := bnd.Manifest.Hash()
+	sig, err := signature.Sign(signer, ManifestSignatureContext, manifestHash[:])
+	if err != nil {
+		return fmt.Errorf("runtime/bundle: failed to sign manifest: %w", err)
+	}
+
+	// Replace any existing signature by the same publisher.
+	sigs = slices.DeleteFunc(sigs, func(s signature.Signature) bool {
+		return s.PublicKey.Equal(sig.PublicKey)
+	})
+	sigs = append(sigs, *sig)
+
+	if bnd.Data == nil {
+		bnd.Data = make(map[string]Data)
+	}
+	bnd.Data[signatureName] = NewBytesData(cbor.Marshal(sigs))
+
+	return nil
+}
+
+// Signatures returns the detached publisher signatures over the manifest hash.
+//
+// The signatures are not verified.
+func (bnd *Bundle) Signatures() ([]signature.Signature, error) {
+	d, ok := bnd.Data[signatureName]
+	if !ok {
+		return nil, nil
+	}
+	return readSignatures(d)
+}
+
+// VerifyPublisher verifies that the bundle manifest has been signed by at least one
+// of the given trusted publishers.
+func (bnd *Bundle) VerifyPublisher(trusted []signature.PublicKey) error {
+	sigs, err := bnd.Signatures()
+	if err != nil {
+		return err
+	}
+	return verifyPublisher(bnd.Manifest, sigs, trusted)
+}
+
+// VerifyExplodedPublisher verifies that the manifest of the bundle exploded in the given
+// directory has been signed by at least one of the given trusted publishers.
+func VerifyExplodedPublisher(manifest *Manifest, dir string, trusted []signature.PublicKey) error {
+	var sigs []signature.Signature
+	switch _, err := os.Stat(filepath.Join(dir, signatureName)); {
+	case err == nil:
+		if sigs, err = readSignatures(NewFileData(filepath.Join(dir, signatureName))); err != nil {
+			return err
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("runtime/bundle: failed to stat signatures: %w", err)
+	}
+	return verifyPublisher(manifest, sigs, trusted)
+}
+
+// readSignatures reads and decodes detached publisher signatures.
+func readSignatures(d Data) ([]signature.Signature, error) {
+	b, err := ReadAllData(d)
+	if err != nil {
+		return nil, fmt.Errorf("runtime/bundle: failed to read signatures: %w", err)
+	}
+
+	var sigs []signature.Signature
+	if err = cbor.Unmarshal(b, &sigs); err != nil {
+		return nil, fmt.Errorf("runtime/bundle: malformed signatures: %w", err)
+	}
+	return sigs, nil
+}
+
+// verifyPublisher verifies that at least one of the given signatures over the manifest hash
+// has been made by one of the given trusted publishers.
+func verifyPublisher(manifest *Manifest, sigs []signature.Signature, trusted []signature.PublicKey) error {
+	if len(sigs) == 0 {
+		return ErrBundleNotSigned
+	}
+
+	manifestHash := manifest.Hash()
+	for _, sig := range sigs {
+		if !slices.ContainsFunc(trusted, sig.PublicKey.Equal) {
+			continue
+		}
+		if sig.Verify(ManifestSignatureContext, manifestHash[:]) {
+			return nil
+		}
+	}
+
+	return ErrUntrustedPublisher
+}
+
+// verifySignatures ensures that all signatures in the bundle, if any, are valid.
+func (bnd *Bundle) verifySignatures() error {
+	sigs, err := bnd.Signatures()
+	if err != nil {
+		return err
+	}
+	return verifySignatures(bnd.Manifest, sigs)
+}
+
+// verifySignatures ensures that all of the given signatures over the manifest hash are valid.
+func verifySignatures(manifest *Manifest, sigs []signature.Signature) error {
+	manifestHash := manifest.Hash()
+	for _, sig := range sigs {
+		if !sig.Verify(ManifestSignatureContext, manifestHash[:]) {
+			return fmt.Errorf("runtime/bundle: invalid manifest signature by '%s'", sig.PublicKey)
+		}
+	}
+
+	return nil
+}
+
+// writeExplodedSignatures writes the publisher signatures of the bundle to the given exploded
+// bundle directory.
+//
+// As the signatures are not covered by the manifest hash, bundles with the same manifest may
+// carry different signatures. Signatures that were previously written are therefore re-verified
+// and merged with the signatures of the bundle.
+func (bnd *Bundle) writeExplodedSignatures(dir string) error {
+	sigs, err := bnd.Signatures()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, signatureName)
+	var existing []signature.Signature
+	switch _, err = os.Stat(path); {
+	case err == nil:
+		if existing, err = readSignatures(NewFileData(path)); err != nil {
+			return err
+		}
+		if err = verifySignatures(bnd.Manifest, existing); err != nil {
+			return fmt.Errorf("runtime/bundle: corrupted signatures: '%s': %w", path, err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("runtime/bundle: failed to stat signatures: %w", err)
+	}
+
+	merged := slices.Clone(existing)
+	for _, sig := range sigs {
+		if slices.ContainsFunc(merged, func(s signature.Signature) bool {
+			return s.PublicKey.Equal(sig.PublicKey)
+		}) {
+			continue
+		}
+		merged = append(merged, sig)
+	}
+	if len(merged) == len(existing) {
+		return nil
+	}
+
+	if err = os.WriteFile(path, cbor.Marshal(merged), 0o600); err != nil {
+		return fmt.Errorf("runtime/bundle: failed to write signatures: %w", err)
+	}
+	return nil
+}
@@ -36,6 +36,10 @@ var (
 	ErrCheckTxFailed = errors.New(ModuleName, 5, "client: transaction check failed")
 	// ErrNoHostedRuntime is returned when the hosted runtime is not available locally.
 	ErrNoHostedRuntime = errors.New(ModuleName, 6, "client: no hosted runtime is available")
+	// ErrEventIndexDisabled is returned when the runtime event index is not enabled.
+	ErrEventIndexDisabled = errors.New(ModuleName, 7, "client: event index is disabled")
+	// ErrTxIndexDisabled is returned when the runtime transaction index is not enabled.
+	ErrTxIndexDisabled = errors.New(ModuleName, 8, "client: transaction index is disabled")
 )
 
 // RuntimeClient is the runtime client interface.
@@ -74,6 +78,12 @@ type RuntimeClient interface {
 	// its results (outputs and emitted events).
 	GetTransactionsWithResults(ctx context.Context, request *GetTransactionsRequest) ([]*TransactionWithResults, error)
 
+	// GetTransactionByHash looks up a runtime transaction by its hash and returns it together
+	// with its location and result.
+	//
+	// This requires the runtime transaction index to be enabled.
+	GetTransactionByHash(ctx context.Context, request *GetTransactionByHashRequest) (*GetTransactionByHashResponse, error)
+
 	// GetUnconfirmedTransactions fetches all unconfirmed runtime transactions
 	// that are currently pending to be included in a block.
 	GetUnconfirmedTransactions(ctx context.Context, runtimeID common.Namespace) ([][]byte, error)
@@ -81,6 +91,11 @@ type RuntimeClient interface {
 	// GetEvents returns all events emitted in a given block.
 	GetEvents(ctx context.Context, request *GetEventsRequest) ([]*Event, error)
 
+	// QueryEvents returns events with the given key prefix emitted in the given round range.
+	//
+	// This requires the runtime event index to be enabled.
+	QueryEvents(ctx context.Context, request *QueryEventsRequest) (*QueryEventsResponse, error)
+
 	// Query makes a runtime-specific query.
 	Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error)
 
@@ -142,6 +157,24 @@ type TransactionWithResults struct {
 	Events []*PlainEvent `json:"events,omitempty"`
 }
 
+// GetTransactionByHashRequest is a GetTransactionByHash request.
+type GetTransactionByHashRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	TxHash    hash.Hash        `json:"tx_hash"`
+}
+
+// GetTransactionByHashResponse is a GetTransactionByHash response.
+type GetTransactionByHashResponse struct {
+	// Round is the roothash round in which the transaction was executed.
+	Round uint64 `json:"round"`
+	// Index is the order of the transaction in the execution batch.
+	Index uint32 `json:"index"`
+	// Tx is the raw transaction.
+	Tx []byte `json:"tx"`
+	// Result is the raw transaction result.
+	Result []byte `json:"result"`
+}
+
 // GetEventsRequest is a GetEvents request.
 type GetEventsRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -157,6 +190,40 @@ type Event struct {
 	TxHash hash.Hash `json:"tx_hash"`
 }
 
+// QueryEventsRequest is a QueryEvents request.
+type QueryEventsRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// KeyPrefix is the prefix of the keys of returned events. An empty prefix matches all events.
+	KeyPrefix []byte `json:"key_prefix,omitempty"`
+	// FromRound is the first round (inclusive) of the queried round range.
+	FromRound uint64 `json:"from_round"`
+	// ToRound is the last round (inclusive) of the queried round range.
+	ToRound uint64 `json:"to_round"`
+	// Limit is the maximum number of returned events. Zero means the maximum allowed limit.
+	Limit uint64 `json:"limit,omitempty"`
+	// Cursor is the pagination cursor returned by a previous query with the same parameters.
+	Cursor []byte `json:"cursor,omitempty"`
+}
+
+// QueryEventsResponse is a QueryEvents response.
+type QueryEventsResponse struct {
+	// Events are the matching events, ordered by round, key and order of emission.
+	Events []*IndexedEvent `json:"events"`
+	// NextCursor is the cursor that can be used to fetch the next page of results. It is not
+	// set in case there are no more results.
+	NextCursor []byte `json:"next_cursor,omitempty"`
+}
+
+// IndexedEvent is an event emitted by a runtime together with the round it was emitted in.
+//
+// Key and value semantics are runtime-dependent.
+type IndexedEvent struct {
+	Round  uint64    `json:"round"`
+	Key    []byte    `json:"key"`
+	Value  []byte    `json:"value"`
+	TxHash hash.Hash `json:"tx_hash"`
+}
+
 // PlainEvent is an event emitted by a runtime in the form of a runtime transaction tag. It
 // does not include the transaction hash.
 //
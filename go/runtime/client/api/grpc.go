@@ -38,10 +38,14 @@ var (
 	methodGetTransactions = serviceName.NewMethod("GetTransactions", GetTransactionsRequest{})
 	// methodGetTransactionsWithResults is the GetTransactionsWithResults method.
 	methodGetTransactionsWithResults = serviceName.NewMethod("GetTransactionsWithResults", GetTransactionsRequest{})
+	// methodGetTransactionByHash is the GetTransactionByHash method.
+	methodGetTransactionByHash = serviceName.NewMethod("GetTransactionByHash", GetTransactionByHashRequest{})
 	// methodGetUnconfirmedTransactions is the GetUnconfirmedTransactions method.
 	methodGetUnconfirmedTransactions = serviceName.NewMethod("GetUnconfirmedTransactions", common.Namespace{})
 	// methodGetEvents is the GetEvents method.
 	methodGetEvents = serviceName.NewMethod("GetEvents", GetEventsRequest{})
+	// methodQueryEvents is the QueryEvents method.
+	methodQueryEvents = serviceName.NewMethod("QueryEvents", QueryEventsRequest{})
 	// methodQuery is the Query method.
 	methodQuery = serviceName.NewMethod("Query", QueryRequest{})
 	// methodStateSyncGet is the StateSyncGet method.
@@ -95,6 +99,10 @@ var (
 				MethodName: methodGetTransactionsWithResults.ShortName(),
 				Handler:    handlerGetTransactionsWithResults,
 			},
+			{
+				MethodName: methodGetTransactionByHash.ShortName(),
+				Handler:    handlerGetTransactionByHash,
+			},
 			{
 				MethodName: methodGetUnconfirmedTransactions.ShortName(),
 				Handler:    handlerGetUnconfirmedTransactions,
@@ -103,6 +111,10 @@ var (
 				MethodName: methodGetEvents.ShortName(),
 				Handler:    handlerGetEvents,
 			},
+			{
+				MethodName: methodQueryEvents.ShortName(),
+				Handler:    handlerQueryEvents,
+			},
 			{
 				MethodName: methodQuery.ShortName(),
 				Handler:    handlerQuery,
@@ -370,6 +382,29 @@ func handlerGetTransactionsWithResults(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetTransactionByHash(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetTransactionByHashRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).GetTransactionByHash(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTransactionByHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).GetTransactionByHash(ctx, req.(*GetTransactionByHashRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetUnconfirmedTransactions(
 	srv interface{},
 	ctx context.Context,
@@ -416,6 +451,29 @@ func handlerGetEvents(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerQueryEvents(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq QueryEventsRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).QueryEvents(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodQueryEvents.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).QueryEvents(ctx, req.(*QueryEventsRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerQuery( // nolint: revive
 	srv interface{},
 	ctx context.Context,
@@ -612,6 +670,14 @@ func (c *runtimeClient) GetTransactionsWithResults(ctx context.Context, request
 	return rsp, nil
 }
 
+func (c *runtimeClient) GetTransactionByHash(ctx context.Context, request *GetTransactionByHashRequest) (*GetTransactionByHashResponse, error) {
+	var rsp GetTransactionByHashResponse
+	if err := c.conn.Invoke(ctx, methodGetTransactionByHash.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) GetUnconfirmedTransactions(ctx context.Context, runtimeID common.Namespace) ([][]byte, error) {
 	var rsp [][]byte
 	if err := c.conn.Invoke(ctx, methodGetUnconfirmedTransactions.FullName(), runtimeID, &rsp); err != nil {
@@ -628,6 +694,14 @@ func (c *runtimeClient) GetEvents(ctx context.Context, request *GetEventsRequest
 	return rsp, nil
 }
 
+func (c *runtimeClient) QueryEvents(ctx context.Context, request *QueryEventsRequest) (*QueryEventsResponse, error) {
+	var rsp QueryEventsResponse
+	if err := c.conn.Invoke(ctx, methodQueryEvents.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error) {
 	var rsp QueryResponse
 	if err := c.conn.Invoke(ctx, methodQuery.FullName(), request, &rsp); err != nil {
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 	tpConfig "github.com/oasisprotocol/oasis-core/go/runtime/txpool/config"
 )
@@ -52,6 +53,32 @@ func (m *RuntimeProvisioner) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// LoadBalancerPolicy is the load balancer instance selection policy.
+type LoadBalancerPolicy string
+
+const (
+	// LoadBalancerPolicyRoundRobin is the name of the policy that selects healthy instances in
+	// a round-robin fashion.
+	LoadBalancerPolicyRoundRobin LoadBalancerPolicy = "round_robin"
+
+	// LoadBalancerPolicyLeastLoaded is the name of the policy that selects the healthy instance
+	// with the least outstanding requests.
+	LoadBalancerPolicyLeastLoaded LoadBalancerPolicy = "least_loaded"
+)
+
+// UnmarshalText decodes a text marshaled load balancer policy.
+func (m *LoadBalancerPolicy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case string(LoadBalancerPolicyRoundRobin):
+		*m = LoadBalancerPolicyRoundRobin
+	case string(LoadBalancerPolicyLeastLoaded):
+		*m = LoadBalancerPolicyLeastLoaded
+	default:
+		return fmt.Errorf("invalid load balancer policy: %s", string(text))
+	}
+	return nil
+}
+
 // RuntimeEnvironment is the runtime environment.
 type RuntimeEnvironment string
 
@@ -109,6 +136,12 @@ type Config struct {
 	// History pruner configuration.
 	Prune PruneConfig `yaml:"prune,omitempty"`
 
+	// EventIndex is the runtime event index configuration.
+	EventIndex EventIndexConfig `yaml:"event_index,omitempty"`
+
+	// TxIndex is the runtime transaction index configuration.
+	TxIndex TxIndexConfig `yaml:"tx_index,omitempty"`
+
 	// RuntimeConfig maps runtime IDs to their respective local configurations.
 	// NOTE: This may go away in the future, use `RuntimeConfig.Config` instead.
 	RuntimeConfig map[string]map[string]interface{} `yaml:"config,omitempty"`
@@ -138,6 +171,12 @@ type Config struct {
 	// endpoints themselves, only the constructed URLs need to be valid.
 	Registries []string `yaml:"registries,omitempty"`
 
+	// CgroupRoot is the path to a delegated cgroup v2 directory under which cgroups of sandboxed
+	// runtime processes are created in order to enforce component resource limits.
+	//
+	// If not specified, resource limits are not enforced.
+	CgroupRoot string `yaml:"cgroup_root,omitempty"`
+
 	// MaxBundleSize is the maximum allowed bundle size.
 	//
 	// If not specified, a default value is used.
@@ -197,10 +236,21 @@ type RuntimeConfig struct {
 	// to the base URL. Therefore, the provided URLs don't need to be valid
 	// endpoints themselves, only the constructed URLs need to be valid.
 	Registries []string `yaml:"registries,omitempty"`
+
+	// TrustedPublishers is the list of public keys of trusted bundle publishers.
+	//
+	// If specified, all bundles of the runtime (configured, downloaded, added at runtime
+	// or previously exploded) must be signed by at least one of the trusted publishers.
+	TrustedPublishers []signature.PublicKey `yaml:"trusted_publishers,omitempty"`
 }
 
 // Validate validates the runtime configuration.
 func (c *RuntimeConfig) Validate() error {
+	for _, pk := range c.TrustedPublishers {
+		if !pk.IsValid() {
+			return fmt.Errorf("runtime %s: invalid trusted publisher: %s", c.ID, pk)
+		}
+	}
 	for _, comp := range c.Components {
 		if err := comp.Validate(); err != nil {
 			return err
@@ -209,6 +259,15 @@ func (c *RuntimeConfig) Validate() error {
 	return nil
 }
 
+func (c *RuntimeConfig) hasResourceLimits() bool {
+	for _, comp := range c.Components {
+		if !comp.Resources.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
 // ComponentConfig is the component configuration.
 type ComponentConfig struct {
 	// ID is the component identifier.
@@ -223,6 +282,16 @@ type ComponentConfig struct {
 	// Disabled specifies whether the component is disabled. If a component is specified and not
 	// disabled, it is enabled.
 	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Resources are the resource limits of the component process. Any limits specified here
+	// override the limits declared in the bundle.
+	Resources *component.ResourceLimits `yaml:"resources,omitempty"`
+
+	// LocalStorageQuota is the maximum total size (in bytes) of all keys and values that the
+	// component can keep in the runtime's local storage. Zero means no quota.
+	//
+	// Quotas are only supported for ROFL components.
+	LocalStorageQuota uint64 `yaml:"local_storage_quota,omitempty"`
 }
 
 // Validate validates the component configuration.
@@ -236,6 +305,16 @@ func (c *ComponentConfig) Validate() error {
 		return fmt.Errorf("unknown TEE select mode: %s", c.TEE)
 	}
 
+	if c.Resources != nil {
+		if err := c.Resources.Validate(); err != nil {
+			return fmt.Errorf("component %s: malformed resources: %w", c.ID, err)
+		}
+	}
+
+	if c.LocalStorageQuota > 0 && c.ID.IsRONL() {
+		return fmt.Errorf("component %s: local storage quotas are only supported for ROFL components", c.ID)
+	}
+
 	return nil
 }
 
@@ -279,11 +358,32 @@ type PruneConfig struct {
 	NumKept uint64 `yaml:"num_kept"`
 }
 
+// EventIndexConfig is the runtime event index configuration.
+type EventIndexConfig struct {
+	// Enabled specifies whether runtime events should be indexed to support queries by event
+	// key prefix and round range.
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// TxIndexConfig is the runtime transaction index configuration.
+type TxIndexConfig struct {
+	// Enabled specifies whether runtime transactions should be indexed to support lookups by
+	// transaction hash.
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
 // LoadBalancerConfig is the load balancer configuration.
 type LoadBalancerConfig struct {
 	// NumInstances is the number of runtime instances to provision for load-balancing. Setting it
 	// to zero (default) or one disables load balancing.
 	NumInstances uint64 `yaml:"num_instances,omitempty"`
+
+	// Policy is the instance selection policy.
+	Policy LoadBalancerPolicy `yaml:"policy,omitempty"`
+
+	// FailureCooldown is the duration for which an instance is excluded from selection after
+	// a failed request.
+	FailureCooldown time.Duration `yaml:"failure_cooldown,omitempty"`
 }
 
 // Validate validates the configuration settings.
@@ -320,14 +420,33 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("unknown runtime history pruner strategy: %s", c.Prune.Strategy)
 	}
 
+	if c.TxPool.MaxTxsPerSender < 1 {
+		return fmt.Errorf("tx_pool.schedule_max_txs_per_sender must be >= 1")
+	}
+	if c.TxPool.PersistLocalTxs && c.TxPool.PersistedLocalTxMaxAge <= 0 {
+		return fmt.Errorf("tx_pool.persisted_local_tx_max_age must be positive when persisting local transactions")
+	}
+
 	if c.LoadBalancer.NumInstances > 128 {
 		return fmt.Errorf("cannot specify more than 128 instances for load balancing")
 	}
+	switch c.LoadBalancer.Policy {
+	case LoadBalancerPolicyRoundRobin:
+	case LoadBalancerPolicyLeastLoaded:
+	default:
+		return fmt.Errorf("unknown load balancer policy: %s", c.LoadBalancer.Policy)
+	}
+	if c.LoadBalancer.FailureCooldown < 0 {
+		return fmt.Errorf("load_balancer.failure_cooldown must be non-negative")
+	}
 
 	for _, rt := range c.Runtimes {
 		if err := rt.Validate(); err != nil {
 			return err
 		}
+		if c.CgroupRoot == "" && rt.hasResourceLimits() {
+			return fmt.Errorf("runtime %s: cgroup_root must be set when using resource limits", rt.ID)
+		}
 	}
 
 	return nil
@@ -347,17 +466,29 @@ func DefaultConfig() Config {
 			Interval: 2 * time.Minute,
 			NumKept:  600,
 		},
+		EventIndex: EventIndexConfig{
+			Enabled: false,
+		},
+		TxIndex: TxIndexConfig{
+			Enabled: false,
+		},
 		SentryAddresses: []string{},
 		TxPool: tpConfig.Config{
 			MaxPoolSize:          50_000,
+			MaxTxsPerSender:      1,
 			MaxLastSeenCacheSize: 100_000,
 			MaxCheckTxBatchSize:  128,
 			RecheckInterval:      5,
 			RepublishInterval:    60 * time.Second,
+
+			PersistLocalTxs:        false,
+			PersistedLocalTxMaxAge: time.Hour,
 		},
 		PreWarmEpochs: 3,
 		LoadBalancer: LoadBalancerConfig{
-			NumInstances: 0,
+			NumInstances:    0,
+			Policy:          LoadBalancerPolicyRoundRobin,
+			FailureCooldown: 10 * time.Second,
 		},
 		Registries: []string{oasisBundleRegistryURL},
 	}
@@ -1,7 +1,9 @@
 package history
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 
 	"github.com/dgraph-io/badger/v4"
 	"github.com/dgraph-io/badger/v4/options"
@@ -9,6 +11,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
@@ -32,6 +35,24 @@ var (
 	//
 	// Value is CBOR-serialized roothash.RoundResults.
 	roundResultsKeyFmt = keyFormat.New(0x03, uint64(0))
+	// eventKeyFmt is the event index key format.
+	//
+	// Key is composed of the round, the event key and the index of the event
+	// within the round. The round comes first so that round ranges can be
+	// seeked to directly. Value is CBOR-serialized eventIndexEntry.
+	eventKeyFmt = keyFormat.New(0x04, uint64(0), []byte{}, uint32(0))
+	// eventRoundKeyFmt is the per-round event index key format.
+	//
+	// Value is CBOR-serialized list of event index keys for the given round.
+	eventRoundKeyFmt = keyFormat.New(0x05, uint64(0))
+	// txKeyFmt is the transaction index key format.
+	//
+	// Value is CBOR-serialized txIndexEntry.
+	txKeyFmt = keyFormat.New(0x06, &hash.Hash{})
+	// txRoundKeyFmt is the per-round transaction index key format.
+	//
+	// Value is CBOR-serialized list of transaction hashes for the given round.
+	txRoundKeyFmt = keyFormat.New(0x07, uint64(0))
 )
 
 type dbMetadata struct {
@@ -279,6 +300,291 @@ func (d *DB) getRoundResults(round uint64) (*roothash.RoundResults, error) {
 	return roundResults, nil
 }
 
+func (d *DB) queryLastIndexedRound(tx *badger.Txn, roundKeyFmt *keyformat.KeyFormat) (uint64, error) {
+	it := tx.NewIterator(badger.IteratorOptions{
+		Prefix:  roundKeyFmt.Encode(),
+		Reverse: true,
+	})
+	defer it.Close()
+
+	for it.Seek(roundKeyFmt.Encode(uint64(math.MaxUint64))); it.Valid(); it.Next() {
+		var round uint64
+		if !roundKeyFmt.Decode(it.Item().Key(), &round) {
+			// This should not happen as the Badger iterator should take care of it.
+			panic("runtime/history: bad iterator")
+		}
+		return round, nil
+	}
+	return 0, roothash.ErrNotFound
+}
+
+func (d *DB) lastIndexedRound(roundKeyFmt *keyformat.KeyFormat) (uint64, error) {
+	var round uint64
+	txErr := d.db.View(func(tx *badger.Txn) error {
+		var err error
+		round, err = d.queryLastIndexedRound(tx, roundKeyFmt)
+		return err
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+	return round, nil
+}
+
+func (d *DB) ensureIndexRound(tx *badger.Txn, roundKeyFmt *keyformat.KeyFormat, round uint64) error {
+	lastRound, err := d.queryLastIndexedRound(tx, roundKeyFmt)
+	switch err {
+	case nil:
+		if round <= lastRound {
+			return fmt.Errorf("runtime/history: index at lower round (current: %d wanted: %d)",
+				lastRound,
+				round,
+			)
+		}
+		return nil
+	case roothash.ErrNotFound:
+		return nil
+	default:
+		return err
+	}
+}
+
+func (d *DB) indexEvents(round uint64, events []*Event) error {
+	return d.db.Update(func(tx *badger.Txn) error {
+		err := d.ensureIndexRound(tx, eventRoundKeyFmt, round)
+		if err != nil {
+			return err
+		}
+
+		keys := make([][]byte, 0, len(events))
+		for i, ev := range events {
+			key := eventKeyFmt.Encode(round, ev.Key, uint32(i))
+			entry := eventIndexEntry{
+				Value:  ev.Value,
+				TxHash: ev.TxHash,
+			}
+			if err = tx.Set(key, cbor.Marshal(entry)); err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+
+		return tx.Set(eventRoundKeyFmt.Encode(round), cbor.Marshal(keys))
+	})
+}
+
+func (d *DB) pruneEventIndex(tx *badger.Txn, round uint64) error {
+	roundKey := eventRoundKeyFmt.Encode(round)
+	item, err := tx.Get(roundKey)
+	switch err {
+	case nil:
+	case badger.ErrKeyNotFound:
+		// Round has not been indexed.
+		return nil
+	default:
+		return err
+	}
+
+	var keys [][]byte
+	if err = item.Value(func(val []byte) error {
+		return cbor.UnmarshalTrusted(val, &keys)
+	}); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err = tx.Delete(key); err != nil {
+			return err
+		}
+	}
+	return tx.Delete(roundKey)
+}
+
+func (d *DB) indexTransactions(round uint64, txs []*TransactionLocation) error {
+	return d.db.Update(func(tx *badger.Txn) error {
+		err := d.ensureIndexRound(tx, txRoundKeyFmt, round)
+		if err != nil {
+			return err
+		}
+
+		txHashes := make([]hash.Hash, 0, len(txs))
+		for _, loc := range txs {
+			entry := txIndexEntry{
+				Round: round,
+				Index: loc.Index,
+			}
+			if err = tx.Set(txKeyFmt.Encode(&loc.TxHash), cbor.Marshal(entry)); err != nil {
+				return err
+			}
+			txHashes = append(txHashes, loc.TxHash)
+		}
+
+		return tx.Set(txRoundKeyFmt.Encode(round), cbor.Marshal(txHashes))
+	})
+}
+
+func (d *DB) getTransactionLocation(txHash hash.Hash) (*TransactionLocation, error) {
+	var entry txIndexEntry
+	txErr := d.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(txKeyFmt.Encode(&txHash))
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			return roothash.ErrNotFound
+		default:
+			return err
+		}
+
+		return item.Value(func(val []byte) error {
+			return cbor.UnmarshalTrusted(val, &entry)
+		})
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return &TransactionLocation{
+		TxHash: txHash,
+		Round:  entry.Round,
+		Index:  entry.Index,
+	}, nil
+}
+
+func (d *DB) pruneTransactionIndex(tx *badger.Txn, round uint64) error {
+	roundKey := txRoundKeyFmt.Encode(round)
+	item, err := tx.Get(roundKey)
+	switch err {
+	case nil:
+	case badger.ErrKeyNotFound:
+		// Round has not been indexed.
+		return nil
+	default:
+		return err
+	}
+
+	var txHashes []hash.Hash
+	if err = item.Value(func(val []byte) error {
+		return cbor.UnmarshalTrusted(val, &txHashes)
+	}); err != nil {
+		return err
+	}
+	for _, txHash := range txHashes {
+		// The same transaction may have been included again in a later round in which case the
+		// index entry points to that round and must be kept.
+		key := txKeyFmt.Encode(&txHash)
+		if item, err = tx.Get(key); err != nil {
+			if err == badger.ErrKeyNotFound {
+				continue
+			}
+			return err
+		}
+		var entry txIndexEntry
+		if err = item.Value(func(val []byte) error {
+			return cbor.UnmarshalTrusted(val, &entry)
+		}); err != nil {
+			return err
+		}
+		if entry.Round != round {
+			continue
+		}
+		if err = tx.Delete(key); err != nil {
+			return err
+		}
+	}
+	return tx.Delete(roundKey)
+}
+
+func (d *DB) queryEvents(query *EventQuery) (*EventQueryResult, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = MaxEventQueryLimit
+	}
+
+	// Events matching the key prefix in a given round are all stored under the same raw prefix.
+	roundPrefix := func(round uint64) []byte {
+		return eventKeyFmt.Encode(round, query.KeyPrefix)
+	}
+
+	start := roundPrefix(query.FromRound)
+	if query.Cursor != nil {
+		var round uint64
+		if len(query.Cursor) < eventKeyFmt.Size() || !eventKeyFmt.Decode(query.Cursor, &round) ||
+			round < query.FromRound || round > query.ToRound || !bytes.HasPrefix(query.Cursor, roundPrefix(round)) {
+			return nil, fmt.Errorf("runtime/history: malformed event query cursor")
+		}
+		start = query.Cursor
+	}
+
+	var (
+		result  EventQueryResult
+		lastKey []byte
+	)
+	txErr := d.db.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.IteratorOptions{Prefix: eventKeyFmt.Encode()})
+		defer it.Close()
+
+		for it.Seek(start); it.Valid(); {
+			item := it.Item()
+
+			var (
+				round uint64
+				key   []byte
+			)
+			if !eventKeyFmt.Decode(item.Key(), &round, &key) {
+				// This should not happen as the Badger iterator should take care of it.
+				panic("runtime/history: bad iterator")
+			}
+			if round > query.ToRound {
+				break
+			}
+
+			// Skip to the events matching the key prefix.
+			if prefix := roundPrefix(round); !bytes.HasPrefix(item.Key(), prefix) {
+				switch {
+				case bytes.Compare(item.Key(), prefix) < 0:
+					it.Seek(prefix)
+				case round < query.ToRound:
+					it.Seek(roundPrefix(round + 1))
+				default:
+					return nil
+				}
+				continue
+			}
+			if !bytes.HasPrefix(key, query.KeyPrefix) || (query.Cursor != nil && bytes.Equal(item.Key(), query.Cursor)) {
+				// Skip events whose index happens to continue the key prefix and the last event
+				// returned by the previous query.
+				it.Next()
+				continue
+			}
+
+			if uint64(len(result.Events)) >= limit {
+				// There are more results, return a cursor pointing to the last returned event.
+				result.NextCursor = lastKey
+				break
+			}
+
+			var entry eventIndexEntry
+			if err := item.Value(func(val []byte) error {
+				return cbor.UnmarshalTrusted(val, &entry)
+			}); err != nil {
+				return err
+			}
+
+			result.Events = append(result.Events, &Event{
+				Round:  round,
+				Key:    key,
+				Value:  entry.Value,
+				TxHash: entry.TxHash,
+			})
+			lastKey = item.KeyCopy(nil)
+			it.Next()
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return &result, nil
+}
+
 func (d *DB) close() {
 	d.gc.Stop()
 	d.db.Close()
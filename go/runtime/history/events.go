@@ -0,0 +1,68 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+// MaxEventQueryLimit is the maximum number of events returned by a single event index query.
+const MaxEventQueryLimit = 1000
+
+// ErrEventIndexDisabled is the error returned when the event index is disabled.
+var ErrEventIndexDisabled = errors.New("runtime/history: event index is disabled")
+
+// Event is an indexed runtime event.
+type Event struct {
+	// Round is the round in which the event was emitted.
+	Round uint64
+	// Key is the event key.
+	Key []byte
+	// Value is the event value.
+	Value []byte
+	// TxHash is the hash of the transaction that emitted the event.
+	TxHash hash.Hash
+}
+
+// EventQuery is an event index query.
+type EventQuery struct {
+	// KeyPrefix is the prefix of the keys of returned events. An empty prefix matches all events.
+	KeyPrefix []byte
+	// FromRound is the first round (inclusive) of the queried round range.
+	FromRound uint64
+	// ToRound is the last round (inclusive) of the queried round range.
+	ToRound uint64
+	// Limit is the maximum number of returned events. Zero means MaxEventQueryLimit.
+	Limit uint64
+	// Cursor is the pagination cursor returned by a previous query with the same parameters.
+	Cursor []byte
+}
+
+// Validate validates the event query.
+func (q *EventQuery) Validate() error {
+	if q.FromRound > q.ToRound {
+		return fmt.Errorf("runtime/history: invalid round range (from: %d to: %d)", q.FromRound, q.ToRound)
+	}
+	if q.Limit > MaxEventQueryLimit {
+		return fmt.Errorf("runtime/history: query limit too large (max: %d got: %d)", MaxEventQueryLimit, q.Limit)
+	}
+	return nil
+}
+
+// EventQueryResult is the result of an event index query.
+//
+// Events are ordered by round, key and the order of emission within the round.
+type EventQueryResult struct {
+	// Events are the matching events.
+	Events []*Event
+	// NextCursor is the cursor that can be used to fetch the next page of results. It is nil in
+	// case there are no more results.
+	NextCursor []byte
+}
+
+// eventIndexEntry is the value stored for each indexed event.
+type eventIndexEntry struct {
+	Value  []byte    `json:"value"`
+	TxHash hash.Hash `json:"tx_hash"`
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/eapache/channels"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/config"
@@ -38,6 +39,33 @@ type History interface {
 	// Pruner returns the history pruner.
 	Pruner() Pruner
 
+	// IndexEvents adds the events emitted in the given round to the event index.
+	//
+	// Rounds must be indexed in increasing order. The Round field of the passed events is ignored.
+	IndexEvents(round uint64, events []*Event) error
+
+	// LastIndexedRound returns the last round for which events have been indexed.
+	LastIndexedRound() (uint64, error)
+
+	// QueryEvents queries the event index for events matching the given key prefix
+	// and round range.
+	QueryEvents(ctx context.Context, query *EventQuery) (*EventQueryResult, error)
+
+	// IndexTransactions adds the transactions included in the given round to the transaction
+	// index.
+	//
+	// Rounds must be indexed in increasing order. The Round field of the passed locations is
+	// ignored.
+	IndexTransactions(round uint64, txs []*TransactionLocation) error
+
+	// LastIndexedTransactionsRound returns the last round for which transactions have been
+	// indexed.
+	LastIndexedTransactionsRound() (uint64, error)
+
+	// GetTransactionLocation looks up the location of a transaction by its hash in the
+	// transaction index.
+	GetTransactionLocation(ctx context.Context, txHash hash.Hash) (*TransactionLocation, error)
+
 	// Close closes the history keeper.
 	Close()
 }
@@ -103,6 +131,30 @@ func (h *nopHistory) Pruner() Pruner {
 	return pruner
 }
 
+func (h *nopHistory) IndexEvents(uint64, []*Event) error {
+	return errNopHistory
+}
+
+func (h *nopHistory) LastIndexedRound() (uint64, error) {
+	return 0, errNopHistory
+}
+
+func (h *nopHistory) QueryEvents(context.Context, *EventQuery) (*EventQueryResult, error) {
+	return nil, errNopHistory
+}
+
+func (h *nopHistory) IndexTransactions(uint64, []*TransactionLocation) error {
+	return errNopHistory
+}
+
+func (h *nopHistory) LastIndexedTransactionsRound() (uint64, error) {
+	return 0, errNopHistory
+}
+
+func (h *nopHistory) GetTransactionLocation(context.Context, hash.Hash) (*TransactionLocation, error) {
+	return nil, errNopHistory
+}
+
 func (h *nopHistory) Close() {
 }
 
@@ -126,7 +178,9 @@ type runtimeHistory struct {
 	syncRoundLock          sync.RWMutex
 	lastStorageSyncedRound uint64
 
-	hasLocalStorage bool
+	hasLocalStorage   bool
+	indexEvents       bool
+	indexTransactions bool
 
 	pruner  Pruner
 	pruneCh *channels.RingChannel
@@ -324,6 +378,57 @@ func (h *runtimeHistory) Pruner() Pruner {
 	return h.pruner
 }
 
+func (h *runtimeHistory) IndexEvents(round uint64, events []*Event) error {
+	if !h.indexEvents {
+		return ErrEventIndexDisabled
+	}
+	return h.db.indexEvents(round, events)
+}
+
+func (h *runtimeHistory) LastIndexedRound() (uint64, error) {
+	if !h.indexEvents {
+		return 0, ErrEventIndexDisabled
+	}
+	return h.db.lastIndexedRound(eventRoundKeyFmt)
+}
+
+func (h *runtimeHistory) QueryEvents(ctx context.Context, query *EventQuery) (*EventQueryResult, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if !h.indexEvents {
+		return nil, ErrEventIndexDisabled
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	return h.db.queryEvents(query)
+}
+
+func (h *runtimeHistory) IndexTransactions(round uint64, txs []*TransactionLocation) error {
+	if !h.indexTransactions {
+		return ErrTransactionIndexDisabled
+	}
+	return h.db.indexTransactions(round, txs)
+}
+
+func (h *runtimeHistory) LastIndexedTransactionsRound() (uint64, error) {
+	if !h.indexTransactions {
+		return 0, ErrTransactionIndexDisabled
+	}
+	return h.db.lastIndexedRound(txRoundKeyFmt)
+}
+
+func (h *runtimeHistory) GetTransactionLocation(ctx context.Context, txHash hash.Hash) (*TransactionLocation, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if !h.indexTransactions {
+		return nil, ErrTransactionIndexDisabled
+	}
+	return h.db.getTransactionLocation(txHash)
+}
+
 func (h *runtimeHistory) Close() {
 	h.cancelCtx()
 	close(h.stopCh)
@@ -368,7 +473,11 @@ func (h *runtimeHistory) pruneWorker() {
 }
 
 // New creates a new runtime history keeper.
-func New(runtimeID common.Namespace, dataDir string, prunerFactory PrunerFactory, hasLocalStorage bool) (History, error) {
+//
+// In case indexEvents is true, the history also maintains an index of runtime events which
+// can be queried by key prefix and round range. In case indexTransactions is true, the history
+// also maintains an index of runtime transactions which can be queried by transaction hash.
+func New(runtimeID common.Namespace, dataDir string, prunerFactory PrunerFactory, hasLocalStorage bool, indexEvents bool, indexTransactions bool) (History, error) {
 	db, err := newDB(filepath.Join(dataDir, DbFilename), runtimeID)
 	if err != nil {
 		return nil, err
@@ -382,17 +491,19 @@ func New(runtimeID common.Namespace, dataDir string, prunerFactory PrunerFactory
 	ctx, cancelCtx := context.WithCancel(context.Background())
 
 	h := &runtimeHistory{
-		runtimeID:       runtimeID,
-		logger:          logging.GetLogger("runtime/history").With("runtime_id", runtimeID),
-		ctx:             ctx,
-		cancelCtx:       cancelCtx,
-		db:              db,
-		hasLocalStorage: hasLocalStorage,
-		blocksNotifier:  pubsub.NewBroker(true),
-		pruner:          pruner,
-		pruneCh:         channels.NewRingChannel(1),
-		stopCh:          make(chan struct{}),
-		quitCh:          make(chan struct{}),
+		runtimeID:         runtimeID,
+		logger:            logging.GetLogger("runtime/history").With("runtime_id", runtimeID),
+		ctx:               ctx,
+		cancelCtx:         cancelCtx,
+		db:                db,
+		hasLocalStorage:   hasLocalStorage,
+		indexEvents:       indexEvents,
+		indexTransactions: indexTransactions,
+		blocksNotifier:    pubsub.NewBroker(true),
+		pruner:            pruner,
+		pruneCh:           channels.NewRingChannel(1),
+		stopCh:            make(chan struct{}),
+		quitCh:            make(chan struct{}),
 	}
 
 	go h.pruneWorker()
@@ -401,8 +512,8 @@ func New(runtimeID common.Namespace, dataDir string, prunerFactory PrunerFactory
 }
 
 // NewFactory creates a new runtime history keeper factory.
-func NewFactory(prunerFactory PrunerFactory, haveLocalStorageWorker bool) Factory {
+func NewFactory(prunerFactory PrunerFactory, haveLocalStorageWorker bool, indexEvents bool, indexTransactions bool) Factory {
 	return func(runtimeID common.Namespace, dataDir string) (History, error) {
-		return New(runtimeID, dataDir, prunerFactory, haveLocalStorageWorker)
+		return New(runtimeID, dataDir, prunerFactory, haveLocalStorageWorker, indexEvents, indexTransactions)
 	}
 }
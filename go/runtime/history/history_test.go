@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 )
@@ -30,7 +31,7 @@ func TestHistory(t *testing.T) {
 	runtimeID2 := common.NewTestNamespaceFromSeed([]byte("history test ns 2"), 0)
 
 	prunerFactory := NewNonePrunerFactory()
-	history, err := New(runtimeID, dataDir, prunerFactory, true)
+	history, err := New(runtimeID, dataDir, prunerFactory, true, false, false)
 	require.NoError(err, "New")
 
 	require.Equal(runtimeID, history.RuntimeID())
@@ -142,10 +143,10 @@ func TestHistory(t *testing.T) {
 
 	// Try to manually load the block index database with incorrect runtime ID.
 	// Use path from the first runtime.
-	_, err = New(runtimeID2, dataDir, prunerFactory, true)
+	_, err = New(runtimeID2, dataDir, prunerFactory, true, false, false)
 	require.Error(err, "New should return an error on runtime mismatch")
 
-	history, err = New(runtimeID, dataDir, prunerFactory, true)
+	history, err = New(runtimeID, dataDir, prunerFactory, true, false, false)
 	require.NoError(err, "New")
 
 	require.Equal(runtimeID, history.RuntimeID())
@@ -212,7 +213,7 @@ func TestWatchBlocks(t *testing.T) {
 
 	// Test history with local storage.
 	prunerFactory := NewNonePrunerFactory()
-	history, err := New(runtimeID, dataDir, prunerFactory, true)
+	history, err := New(runtimeID, dataDir, prunerFactory, true, false, false)
 	require.NoError(err, "New")
 	// No blocks should be received.
 	testWatchBlocks(t, history, 0)
@@ -248,7 +249,7 @@ func TestWatchBlocks(t *testing.T) {
 	dataDir2, err := os.MkdirTemp("", "oasis-runtime-history-test_")
 	require.NoError(err, "TempDir")
 	defer os.RemoveAll(dataDir2)
-	history, err = New(runtimeID, dataDir2, prunerFactory, false)
+	history, err = New(runtimeID, dataDir2, prunerFactory, false, false, false)
 	require.NoError(err, "New")
 	// No blocks should be received.
 	testWatchBlocks(t, history, 0)
@@ -308,7 +309,7 @@ func TestHistoryPrune(t *testing.T) {
 	runtimeID := common.NewTestNamespaceFromSeed([]byte("history prune test ns"), 0)
 
 	pruneFactory := NewKeepLastPrunerFactory(10, 100*time.Millisecond)
-	history, err := New(runtimeID, dataDir, pruneFactory, true)
+	history, err := New(runtimeID, dataDir, pruneFactory, true, false, false)
 	require.NoError(err, "New")
 	defer history.Close()
 
@@ -415,7 +416,7 @@ func TestHistoryPruneError(t *testing.T) {
 	runtimeID := common.NewTestNamespaceFromSeed([]byte("history prune error test ns"), 0)
 
 	pruneFactory := NewKeepLastPrunerFactory(10, 100*time.Millisecond)
-	history, err := New(runtimeID, dataDir, pruneFactory, true)
+	history, err := New(runtimeID, dataDir, pruneFactory, true, false, false)
 	require.NoError(err, "New")
 	defer history.Close()
 
@@ -446,3 +447,233 @@ func TestHistoryPruneError(t *testing.T) {
 		require.NoError(err, "GetBlock(%d)", i)
 	}
 }
+
+func TestEventIndex(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := os.MkdirTemp("", "oasis-runtime-history-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("history event index test ns"), 0)
+
+	// Event index should be disabled by default.
+	dataDir2, err := os.MkdirTemp("", "oasis-runtime-history-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir2)
+
+	history, err := New(runtimeID, dataDir2, NewNonePrunerFactory(), false, false, false)
+	require.NoError(err, "New")
+	err = history.IndexEvents(0, nil)
+	require.ErrorIs(err, ErrEventIndexDisabled)
+	_, err = history.LastIndexedRound()
+	require.ErrorIs(err, ErrEventIndexDisabled)
+	_, err = history.QueryEvents(ctx, &EventQuery{ToRound: roothash.RoundLatest})
+	require.ErrorIs(err, ErrEventIndexDisabled)
+	history.Close()
+
+	pruneFactory := NewKeepLastPrunerFactory(5, time.Hour)
+	history, err = New(runtimeID, dataDir, pruneFactory, false, true, false)
+	require.NoError(err, "New")
+	defer history.Close()
+
+	_, err = history.LastIndexedRound()
+	require.ErrorIs(err, roothash.ErrNotFound)
+
+	// Create some blocks with events.
+	for i := 0; i < 10; i++ {
+		blk := roothash.AnnotatedBlock{
+			Height: int64(i),
+			Block:  block.NewGenesisBlock(runtimeID, 0),
+		}
+		blk.Block.Header.Round = uint64(i)
+
+		err = history.Commit(&blk, nil, true)
+		require.NoError(err, "Commit")
+
+		events := []*Event{
+			{Key: []byte("transfer.b"), Value: []byte{byte(i), 0}},
+			{Key: []byte("mint"), Value: []byte{byte(i), 1}},
+			{Key: []byte("transfer.a"), Value: []byte{byte(i), 2}},
+			{Key: []byte("x"), Value: []byte{byte(i), 3}},
+		}
+		err = history.IndexEvents(uint64(i), events)
+		require.NoError(err, "IndexEvents(%d)", i)
+	}
+
+	lastRound, err := history.LastIndexedRound()
+	require.NoError(err, "LastIndexedRound")
+	require.EqualValues(9, lastRound)
+
+	err = history.IndexEvents(5, nil)
+	require.Error(err, "IndexEvents should fail for already indexed round")
+
+	// Query by exact key.
+	res, err := history.QueryEvents(ctx, &EventQuery{
+		KeyPrefix: []byte("mint"),
+		ToRound:   roothash.RoundLatest,
+	})
+	require.NoError(err, "QueryEvents")
+	require.Len(res.Events, 10)
+	require.Nil(res.NextCursor)
+	for i, ev := range res.Events {
+		require.EqualValues(i, ev.Round)
+		require.Equal([]byte("mint"), ev.Key)
+		require.Equal([]byte{byte(i), 1}, ev.Value)
+	}
+
+	// Query by key prefix and round range with pagination.
+	query := EventQuery{
+		KeyPrefix: []byte("transfer."),
+		FromRound: 2,
+		ToRound:   5,
+		Limit:     3,
+	}
+	var events []*Event
+	for {
+		res, err = history.QueryEvents(ctx, &query)
+		require.NoError(err, "QueryEvents")
+		require.LessOrEqual(len(res.Events), 3)
+		events = append(events, res.Events...)
+		if res.NextCursor == nil {
+			break
+		}
+		query.Cursor = res.NextCursor
+	}
+	require.Len(events, 8)
+	for i, ev := range events {
+		round := 2 + i/2
+		switch i % 2 {
+		case 0:
+			require.Equal([]byte("transfer.a"), ev.Key)
+			require.Equal([]byte{byte(round), 2}, ev.Value)
+		case 1:
+			require.Equal([]byte("transfer.b"), ev.Key)
+			require.Equal([]byte{byte(round), 0}, ev.Value)
+		}
+		require.EqualValues(round, ev.Round)
+	}
+
+	// Event indices must not be matched as part of the key prefix.
+	res, err = history.QueryEvents(ctx, &EventQuery{
+		KeyPrefix: []byte("x\x00\x00\x00"),
+		ToRound:   roothash.RoundLatest,
+	})
+	require.NoError(err, "QueryEvents")
+	require.Empty(res.Events)
+	res, err = history.QueryEvents(ctx, &EventQuery{
+		KeyPrefix: []byte("x"),
+		FromRound: 3,
+		ToRound:   3,
+	})
+	require.NoError(err, "QueryEvents")
+	require.Len(res.Events, 1)
+	require.Equal([]byte{3, 3}, res.Events[0].Value)
+
+	// Invalid queries.
+	_, err = history.QueryEvents(ctx, &EventQuery{FromRound: 5, ToRound: 2})
+	require.Error(err, "QueryEvents should fail for invalid round range")
+	_, err = history.QueryEvents(ctx, &EventQuery{ToRound: 5, Limit: MaxEventQueryLimit + 1})
+	require.Error(err, "QueryEvents should fail for too large limit")
+	_, err = history.QueryEvents(ctx, &EventQuery{KeyPrefix: []byte("mint"), ToRound: 5, Cursor: []byte("foo")})
+	require.Error(err, "QueryEvents should fail for malformed cursor")
+
+	// Pruning should also prune the event index.
+	err = history.Pruner().Prune(9)
+	require.NoError(err, "Prune")
+
+	res, err = history.QueryEvents(ctx, &EventQuery{ToRound: roothash.RoundLatest})
+	require.NoError(err, "QueryEvents")
+	require.Len(res.Events, 20)
+	for _, ev := range res.Events {
+		require.GreaterOrEqual(ev.Round, uint64(5))
+	}
+
+	lastRound, err = history.LastIndexedRound()
+	require.NoError(err, "LastIndexedRound")
+	require.EqualValues(9, lastRound)
+}
+
+func TestTransactionIndex(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := os.MkdirTemp("", "oasis-runtime-history-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("history tx index test ns"), 0)
+
+	pruneFactory := NewKeepLastPrunerFactory(5, time.Hour)
+	history, err := New(runtimeID, dataDir, pruneFactory, false, false, true)
+	require.NoError(err, "New")
+	defer history.Close()
+
+	_, err = history.LastIndexedTransactionsRound()
+	require.ErrorIs(err, roothash.ErrNotFound)
+
+	// Create some blocks with transactions.
+	txHash := func(round, index int) hash.Hash {
+		return hash.NewFromBytes([]byte{byte(round), byte(index)})
+	}
+	for i := 0; i < 10; i++ {
+		blk := roothash.AnnotatedBlock{
+			Height: int64(i),
+			Block:  block.NewGenesisBlock(runtimeID, 0),
+		}
+		blk.Block.Header.Round = uint64(i)
+
+		err = history.Commit(&blk, nil, true)
+		require.NoError(err, "Commit")
+
+		txs := []*TransactionLocation{
+			{TxHash: txHash(i, 0), Index: 0},
+			{TxHash: txHash(i, 1), Index: 1},
+		}
+		if i == 8 {
+			// Include a transaction from a round that will be pruned again.
+			txs = append(txs, &TransactionLocation{TxHash: txHash(2, 0), Index: 2})
+		}
+		err = history.IndexTransactions(uint64(i), txs)
+		require.NoError(err, "IndexTransactions(%d)", i)
+	}
+
+	lastRound, err := history.LastIndexedTransactionsRound()
+	require.NoError(err, "LastIndexedTransactionsRound")
+	require.EqualValues(9, lastRound)
+
+	err = history.IndexTransactions(5, nil)
+	require.Error(err, "IndexTransactions should fail for already indexed round")
+
+	loc, err := history.GetTransactionLocation(ctx, txHash(3, 1))
+	require.NoError(err, "GetTransactionLocation")
+	require.Equal(txHash(3, 1), loc.TxHash)
+	require.EqualValues(3, loc.Round)
+	require.EqualValues(1, loc.Index)
+
+	_, err = history.GetTransactionLocation(ctx, txHash(3, 2))
+	require.ErrorIs(err, roothash.ErrNotFound)
+
+	// Pruning should also prune the transaction index.
+	err = history.Pruner().Prune(9)
+	require.NoError(err, "Prune")
+
+	_, err = history.GetTransactionLocation(ctx, txHash(3, 1))
+	require.ErrorIs(err, roothash.ErrNotFound)
+	loc, err = history.GetTransactionLocation(ctx, txHash(7, 0))
+	require.NoError(err, "GetTransactionLocation")
+	require.EqualValues(7, loc.Round)
+
+	// Pruning the original round should not remove the entry of a transaction included again.
+	loc, err = history.GetTransactionLocation(ctx, txHash(2, 0))
+	require.NoError(err, "GetTransactionLocation")
+	require.EqualValues(8, loc.Round)
+	require.EqualValues(2, loc.Index)
+
+	// Event index should be disabled.
+	_, err = history.QueryEvents(ctx, &EventQuery{ToRound: roothash.RoundLatest})
+	require.ErrorIs(err, ErrEventIndexDisabled)
+}
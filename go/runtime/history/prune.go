@@ -120,6 +120,22 @@ func (p *keepLastPruner) Prune(latestRound uint64) error {
 				break
 			}
 
+			if err := p.db.pruneTransactionIndex(tx, round); err != nil {
+				if err == badger.ErrTxnTooBig {
+					// We can't prune any more rounds in this transaction.
+					break
+				}
+				return err
+			}
+
+			if err := p.db.pruneEventIndex(tx, round); err != nil {
+				if err == badger.ErrTxnTooBig {
+					// We can't prune any more rounds in this transaction.
+					break
+				}
+				return err
+			}
+
 			if err := tx.Delete(roundResultsKeyFmt.Encode(round)); err != nil {
 				if err == badger.ErrTxnTooBig {
 					// We can't prune any more rounds in this transaction.
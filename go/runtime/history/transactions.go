@@ -0,0 +1,26 @@
+package history
+
+import (
+	"errors"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+// ErrTransactionIndexDisabled is the error returned when the transaction index is disabled.
+var ErrTransactionIndexDisabled = errors.New("runtime/history: transaction index is disabled")
+
+// TransactionLocation is the location of an indexed runtime transaction.
+type TransactionLocation struct {
+	// TxHash is the transaction hash.
+	TxHash hash.Hash
+	// Round is the round in which the transaction was included.
+	Round uint64
+	// Index is the order of the transaction in the round's batch.
+	Index uint32
+}
+
+// txIndexEntry is the value stored for each indexed transaction.
+type txIndexEntry struct {
+	Round uint64 `json:"round"`
+	Index uint32 `json:"index"`
+}
@@ -32,6 +32,9 @@ type Config struct {
 
 	// LocalConfig is the node-local runtime configuration.
 	LocalConfig map[string]interface{}
+
+	// ResourceLimits are the optional resource limits of the component process.
+	ResourceLimits *component.ResourceLimits
 }
 
 // Provisioner is the runtime provisioner interface.
@@ -134,7 +137,10 @@ type FailedToStartEvent struct {
 }
 
 // StoppedEvent is a runtime stopped event.
-type StoppedEvent struct{}
+type StoppedEvent struct {
+	// Error is the reason why the runtime has stopped unexpectedly (if any).
+	Error error
+}
 
 // UpdatedEvent is a runtime metadata updated event.
 type UpdatedEvent struct {
@@ -7,14 +7,17 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	cmnErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/runtime/config"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 )
@@ -22,10 +25,15 @@ import (
 type lbHost struct {
 	id        common.Namespace
 	instances []host.Runtime
+	cfg       config.LoadBalancerConfig
 
 	l                sync.Mutex
 	nextIdx          int
 	healthyInstances map[int]struct{}
+	// outstanding is the number of outstanding requests for each instance.
+	outstanding []int
+	// cooldownUntil is the time until which each instance is excluded due to a failed request.
+	cooldownUntil []time.Time
 
 	startOnce sync.Once
 	stopOnce  sync.Once
@@ -35,11 +43,14 @@ type lbHost struct {
 }
 
 // NewHost creates a new load balancer runtime host.
-func NewHost(id common.Namespace, instances []host.Runtime) host.Runtime {
+func NewHost(id common.Namespace, instances []host.Runtime, cfg config.LoadBalancerConfig) host.Runtime {
 	return &lbHost{
 		id:               id,
 		instances:        instances,
+		cfg:              cfg,
 		healthyInstances: make(map[int]struct{}),
+		outstanding:      make([]int, len(instances)),
+		cooldownUntil:    make([]time.Time, len(instances)),
 		stopCh:           make(chan struct{}),
 		logger:           logging.GetLogger("runtime/host/loadbalance").With("runtime_id", id),
 	}
@@ -125,32 +136,133 @@ func (h *lbHost) Call(ctx context.Context, body *protocol.Body) (*protocol.Body,
 			return nil, err
 		}
 
-		lbRequestCount.With(prometheus.Labels{
-			"runtime":     h.id.String(),
-			"lb_instance": fmt.Sprintf("%d", idx),
-		}).Inc()
+		lbRequestCount.With(h.instanceLabels(idx)).Inc()
 
-		return h.instances[idx].Call(ctx, body)
+		rsp, err := h.instances[idx].Call(ctx, body)
+		h.releaseInstance(idx, isInstanceFailure(ctx, err))
+
+		return rsp, err
 	default:
 		// Propagate only to the first instance.
 		return h.instances[0].Call(ctx, body)
 	}
 }
 
+// isInstanceFailure checks whether the given call error indicates a problem with the instance
+// itself rather than with the request.
+func isInstanceFailure(ctx context.Context, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case ctx.Err() != nil:
+		// The caller gave up, this is not the instance's fault.
+		return false
+	case errors.Is(err, protocol.ErrNotReady):
+		return true
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return true
+	default:
+		// Errors reported by the runtime are caused by the request while all other errors
+		// indicate a broken connection to the instance.
+		module, _ := cmnErrors.Code(err)
+		return module == cmnErrors.UnknownModule
+	}
+}
+
 func (h *lbHost) selectInstance() (int, error) {
 	h.l.Lock()
 	defer h.l.Unlock()
 
+	// Prefer healthy instances that have not recently failed, but fall back to any healthy
+	// instance in case all of them have recently failed.
+	now := time.Now()
+	for _, ignoreCooldown := range []bool{false, true} {
+		isAvailable := func(idx int) bool {
+			if _, healthy := h.healthyInstances[idx]; !healthy {
+				return false
+			}
+			return ignoreCooldown || !now.Before(h.cooldownUntil[idx])
+		}
+
+		var (
+			idx int
+			ok  bool
+		)
+		switch h.cfg.Policy {
+		case config.LoadBalancerPolicyLeastLoaded:
+			idx, ok = h.selectLeastLoadedLocked(isAvailable)
+		default:
+			idx, ok = h.selectRoundRobinLocked(isAvailable)
+		}
+		if !ok {
+			continue
+		}
+
+		h.outstanding[idx]++
+		lbOutstandingRequests.With(h.instanceLabels(idx)).Set(float64(h.outstanding[idx]))
+
+		return idx, nil
+	}
+
+	return 0, fmt.Errorf("host/loadbalance: no healthy instances available")
+}
+
+func (h *lbHost) selectRoundRobinLocked(isAvailable func(int) bool) (int, bool) {
 	for attempt := 0; attempt < len(h.instances); attempt++ {
 		idx := h.nextIdx
 		h.nextIdx = (h.nextIdx + 1) % len(h.instances)
 
-		if _, healthy := h.healthyInstances[idx]; healthy {
-			return idx, nil
+		if isAvailable(idx) {
+			return idx, true
 		}
 	}
+	return 0, false
+}
 
-	return 0, fmt.Errorf("host/loadbalance: no healthy instances available")
+func (h *lbHost) selectLeastLoadedLocked(isAvailable func(int) bool) (int, bool) {
+	// Start at a rotating offset so that ties are broken fairly.
+	best := -1
+	for attempt := 0; attempt < len(h.instances); attempt++ {
+		idx := (h.nextIdx + attempt) % len(h.instances)
+		if !isAvailable(idx) {
+			continue
+		}
+		if best == -1 || h.outstanding[idx] < h.outstanding[best] {
+			best = idx
+		}
+	}
+	h.nextIdx = (h.nextIdx + 1) % len(h.instances)
+
+	return best, best != -1
+}
+
+// releaseInstance marks a request to the given instance as completed. In case the request failed
+// due to an instance failure, the instance is excluded from selection for a while.
+func (h *lbHost) releaseInstance(idx int, failed bool) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	h.outstanding[idx]--
+	lbOutstandingRequests.With(h.instanceLabels(idx)).Set(float64(h.outstanding[idx]))
+
+	if !failed {
+		return
+	}
+
+	h.logger.Warn("instance request failed, excluding instance",
+		"instance", idx,
+		"cooldown", h.cfg.FailureCooldown,
+	)
+
+	h.cooldownUntil[idx] = time.Now().Add(h.cfg.FailureCooldown)
+	lbFailedRequestCount.With(h.instanceLabels(idx)).Inc()
+}
+
+func (h *lbHost) instanceLabels(idx int) prometheus.Labels {
+	return prometheus.Labels{
+		"runtime":     h.id.String(),
+		"lb_instance": fmt.Sprintf("%d", idx),
+	}
 }
 
 // Implements host.Runtime.
@@ -189,7 +301,10 @@ func (h *lbHost) Start() {
 
 							h.l.Lock()
 							h.healthyInstances[idx] = struct{}{}
+							h.cooldownUntil[idx] = time.Time{}
 							h.l.Unlock()
+
+							lbInstanceHealthy.With(h.instanceLabels(idx)).Set(1)
 						case ev.FailedToStart != nil, ev.Stopped != nil:
 							// Mark instance as failed.
 							h.logger.Warn("instance is no longer available",
@@ -199,6 +314,8 @@ func (h *lbHost) Start() {
 							h.l.Lock()
 							delete(h.healthyInstances, idx)
 							h.l.Unlock()
+
+							lbInstanceHealthy.With(h.instanceLabels(idx)).Set(0)
 						default:
 						}
 
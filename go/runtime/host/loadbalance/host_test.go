@@ -0,0 +1,99 @@
+package loadbalance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmnErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/runtime/config"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+func newTestHost(policy config.LoadBalancerPolicy, numInstances int) *lbHost {
+	h := NewHost(common.Namespace{}, make([]host.Runtime, numInstances), config.LoadBalancerConfig{
+		NumInstances:    uint64(numInstances),
+		Policy:          policy,
+		FailureCooldown: time.Hour,
+	}).(*lbHost)
+	for idx := range numInstances {
+		h.healthyInstances[idx] = struct{}{}
+	}
+	return h
+}
+
+func TestSelectInstanceRoundRobin(t *testing.T) {
+	require := require.New(t)
+
+	h := newTestHost(config.LoadBalancerPolicyRoundRobin, 3)
+	delete(h.healthyInstances, 1)
+
+	for _, expected := range []int{0, 2, 0, 2} {
+		idx, err := h.selectInstance()
+		require.NoError(err, "selectInstance")
+		require.Equal(expected, idx, "unhealthy instances should be skipped")
+	}
+
+	delete(h.healthyInstances, 0)
+	delete(h.healthyInstances, 2)
+	_, err := h.selectInstance()
+	require.Error(err, "selectInstance should fail without healthy instances")
+}
+
+func TestSelectInstanceLeastLoaded(t *testing.T) {
+	require := require.New(t)
+
+	h := newTestHost(config.LoadBalancerPolicyLeastLoaded, 3)
+
+	// Requests should be spread among all instances.
+	var selected []int
+	for range 3 {
+		idx, err := h.selectInstance()
+		require.NoError(err, "selectInstance")
+		selected = append(selected, idx)
+	}
+	require.ElementsMatch([]int{0, 1, 2}, selected)
+
+	// Once a request completes, the corresponding instance should be preferred.
+	h.releaseInstance(1, false)
+	idx, err := h.selectInstance()
+	require.NoError(err, "selectInstance")
+	require.Equal(1, idx, "least loaded instance should be selected")
+
+	// Recently failed instances should be excluded.
+	h.releaseInstance(1, true)
+	h.releaseInstance(2, false)
+	idx, err = h.selectInstance()
+	require.NoError(err, "selectInstance")
+	require.Equal(2, idx, "recently failed instance should be excluded")
+
+	// Unless all healthy instances have recently failed.
+	delete(h.healthyInstances, 0)
+	delete(h.healthyInstances, 2)
+	idx, err = h.selectInstance()
+	require.NoError(err, "selectInstance")
+	require.Equal(1, idx, "recently failed instance should be used as a last resort")
+}
+
+func TestIsInstanceFailure(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	require.False(isInstanceFailure(ctx, nil))
+	require.True(isInstanceFailure(ctx, protocol.ErrNotReady))
+	require.True(isInstanceFailure(ctx, fmt.Errorf("failed to send message: connection closed")))
+	require.True(isInstanceFailure(ctx, context.DeadlineExceeded))
+
+	// Errors reported by the runtime should not be considered instance failures.
+	require.False(isInstanceFailure(ctx, cmnErrors.New("test", 1, "query failed")))
+
+	// Errors caused by the caller giving up should not be considered instance failures.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.False(isInstanceFailure(cancelledCtx, context.Canceled))
+}
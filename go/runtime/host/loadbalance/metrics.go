@@ -23,9 +23,33 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	lbOutstandingRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_worker_client_lb_outstanding_requests",
+			Help: "Number of outstanding requests of the given load balancer instance.",
+		},
+		[]string{"runtime", "lb_instance"},
+	)
+	lbFailedRequestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_client_lb_failed_requests",
+			Help: "Number of requests failed due to a failure of the given load balancer instance.",
+		},
+		[]string{"runtime", "lb_instance"},
+	)
+	lbInstanceHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_worker_client_lb_instance_healthy",
+			Help: "Whether the given load balancer instance is healthy (1) or not (0).",
+		},
+		[]string{"runtime", "lb_instance"},
+	)
 	nodeCollectors = []prometheus.Collector{
 		lbRequestCount,
 		lbHealthyInstanceCount,
+		lbOutstandingRequests,
+		lbFailedRequestCount,
+		lbInstanceHealthy,
 	}
 
 	metricsOnce sync.Once
@@ -4,16 +4,19 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	"github.com/oasisprotocol/oasis-core/go/runtime/config"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 )
 
 type lbProvisioner struct {
 	inner        host.Provisioner
 	numInstances int
+	cfg          config.LoadBalancerConfig
 }
 
 // NewProvisioner creates a load-balancing runtime provisioner.
-func NewProvisioner(inner host.Provisioner, numInstances int) host.Provisioner {
+func NewProvisioner(inner host.Provisioner, cfg config.LoadBalancerConfig) host.Provisioner {
+	numInstances := int(cfg.NumInstances)
 	if numInstances < 2 {
 		// If there is only a single instance configured just return the inner provisioner.
 		return inner
@@ -24,6 +27,7 @@ func NewProvisioner(inner host.Provisioner, numInstances int) host.Provisioner {
 	return &lbProvisioner{
 		inner:        inner,
 		numInstances: numInstances,
+		cfg:          cfg,
 	}
 }
 
@@ -50,7 +54,7 @@ func (p *lbProvisioner) NewRuntime(cfg host.Config) (host.Runtime, error) {
 		instances = append(instances, rt)
 	}
 
-	host := NewHost(cfg.ID, instances)
+	host := NewHost(cfg.ID, instances, p.cfg)
 
 	return host, nil
 }
@@ -117,6 +117,10 @@ type Body struct {
 	HostLocalStorageGetResponse      *HostLocalStorageGetResponse      `json:",omitempty"`
 	HostLocalStorageSetRequest       *HostLocalStorageSetRequest       `json:",omitempty"`
 	HostLocalStorageSetResponse      *Empty                            `json:",omitempty"`
+	HostLocalStorageDeleteRequest    *HostLocalStorageDeleteRequest    `json:",omitempty"`
+	HostLocalStorageDeleteResponse   *Empty                            `json:",omitempty"`
+	HostLocalStorageIterateRequest   *HostLocalStorageIterateRequest   `json:",omitempty"`
+	HostLocalStorageIterateResponse  *HostLocalStorageIterateResponse  `json:",omitempty"`
 	HostFetchConsensusBlockRequest   *HostFetchConsensusBlockRequest   `json:",omitempty"`
 	HostFetchConsensusBlockResponse  *HostFetchConsensusBlockResponse  `json:",omitempty"`
 	HostFetchConsensusEventsRequest  *HostFetchConsensusEventsRequest  `json:",omitempty"`
@@ -558,6 +562,35 @@ type HostLocalStorageSetRequest struct {
 	Value []byte `json:"value"`
 }
 
+// HostLocalStorageDeleteRequest is a host local storage delete request message body.
+type HostLocalStorageDeleteRequest struct {
+	Key []byte `json:"key"`
+}
+
+// HostLocalStorageIterateRequest is a host local storage iterate request message body.
+type HostLocalStorageIterateRequest struct {
+	// Prefix is the key prefix to iterate over.
+	Prefix []byte `json:"prefix,omitempty"`
+	// After is the key after which to start iterating. In case it is empty, iteration starts at
+	// the first key with the given prefix.
+	After []byte `json:"after,omitempty"`
+	// Limit is the maximum number of key/value pairs to return. In case it is zero or exceeds the
+	// host limit, the host limit is used.
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// HostLocalStorageKeyValue is a host local storage key/value pair.
+type HostLocalStorageKeyValue struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// HostLocalStorageIterateResponse is a host local storage iterate response message body.
+type HostLocalStorageIterateResponse struct {
+	// Items are the key/value pairs in key order.
+	Items []HostLocalStorageKeyValue `json:"items,omitempty"`
+}
+
 // HostFetchConsensusBlockRequest is a request to host to fetch the given consensus light block.
 type HostFetchConsensusBlockRequest struct {
 	Height uint64 `json:"height"`
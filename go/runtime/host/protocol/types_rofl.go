@@ -1,8 +1,12 @@
 package protocol
 
 import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
+	consensusResults "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
 
@@ -40,6 +44,58 @@ type HostRegisterNotifyRequest struct {
 		// Tags specifies which event tags to subscribe to.
 		Tags [][]byte `json:"tags,omitempty"`
 	} `json:"runtime_event,omitempty"`
+	// ConsensusEvent subscribes to consensus event notifications.
+	ConsensusEvent *RegisterNotifyConsensusEvent `json:"consensus_event,omitempty"`
+	// KeyManagerStatus subscribes to status update notifications of the runtime's key manager.
+	KeyManagerStatus bool `json:"key_manager_status,omitempty"`
+	// Schedule registers scheduled triggers.
+	//
+	// The schedule is persisted by the host so triggers keep being delivered across restarts. In
+	// case it is not specified, any previously registered schedule is kept.
+	Schedule *RegisterNotifySchedule `json:"schedule,omitempty"`
+}
+
+// RegisterNotifySchedule is a set of scheduled triggers.
+type RegisterNotifySchedule struct {
+	// Triggers are the scheduled triggers, replacing any previously registered triggers.
+	//
+	// Triggers that are registered again with the same definition keep their delivery state so
+	// that, for example, one-shot triggers are not delivered twice.
+	Triggers []ScheduledTrigger `json:"triggers,omitempty"`
+}
+
+// ScheduledTrigger is a trigger delivered by the host according to chain time.
+//
+// Exactly one of the schedule fields must be set.
+type ScheduledTrigger struct {
+	// ID is the identifier of the trigger chosen by the component.
+	ID string `json:"id"`
+
+	// EveryRounds triggers periodically at runtime rounds that are multiples of the given value.
+	EveryRounds uint64 `json:"every_rounds,omitempty"`
+	// EveryEpochs triggers periodically at transitions to epochs that are multiples of the given
+	// value.
+	EveryEpochs uint64 `json:"every_epochs,omitempty"`
+	// AtEpoch triggers once at the transition to the given epoch.
+	AtEpoch beacon.EpochTime `json:"at_epoch,omitempty"`
+	// AtHeight triggers once at the given consensus height.
+	AtHeight int64 `json:"at_height,omitempty"`
+}
+
+// RegisterNotifyConsensusEvent specifies which consensus events to subscribe to.
+type RegisterNotifyConsensusEvent struct {
+	// StakingTransfersTo specifies the accounts for which to notify about incoming transfers.
+	StakingTransfersTo []staking.Address `json:"staking_transfers_to,omitempty"`
+	// RegistryNodes specifies whether to notify about registrations and deregistrations of nodes
+	// that host the runtime.
+	RegistryNodes bool `json:"registry_nodes,omitempty"`
+	// GovernanceProposals specifies whether to notify about governance proposal state changes.
+	GovernanceProposals bool `json:"governance_proposals,omitempty"`
+}
+
+// IsEmpty returns true iff no consensus events are subscribed to.
+func (e *RegisterNotifyConsensusEvent) IsEmpty() bool {
+	return e == nil || (len(e.StakingTransfersTo) == 0 && !e.RegistryNodes && !e.GovernanceProposals)
 }
 
 // RuntimeNotifyEvent is an event notification.
@@ -56,4 +112,22 @@ type RuntimeNotifyRequest struct {
 	RuntimeBlock *roothash.AnnotatedBlock `json:"runtime_block,omitempty"`
 	// RuntimeEvent notifies about a specific runtime event being emitted.
 	RuntimeEvent *RuntimeNotifyEvent `json:"runtime_event,omitempty"`
+	// ConsensusEvent notifies about a subscribed consensus event being emitted.
+	ConsensusEvent *consensusResults.Event `json:"consensus_event,omitempty"`
+	// KeyManagerStatus notifies about a status update of the runtime's key manager.
+	KeyManagerStatus *secrets.Status `json:"key_manager_status,omitempty"`
+	// ScheduledTrigger notifies about a scheduled trigger being due.
+	ScheduledTrigger *RuntimeNotifyScheduledTrigger `json:"scheduled_trigger,omitempty"`
+}
+
+// RuntimeNotifyScheduledTrigger is a scheduled trigger notification.
+type RuntimeNotifyScheduledTrigger struct {
+	// ID is the identifier of the trigger.
+	ID string `json:"id"`
+	// Round is the runtime round at which the trigger was scheduled (for round triggers).
+	Round uint64 `json:"round,omitempty"`
+	// Epoch is the epoch at which the trigger was scheduled (for epoch triggers).
+	Epoch beacon.EpochTime `json:"epoch,omitempty"`
+	// Height is the consensus height at which the trigger was scheduled (for height triggers).
+	Height int64 `json:"height,omitempty"`
 }
@@ -140,6 +140,7 @@ func createProvisioner(
 			HostInfo:          hostInfo,
 			InsecureNoSandbox: insecureNoSandbox,
 			SandboxBinaryPath: sandboxBinary,
+			CgroupRoot:        config.GlobalConfig.Runtime.CgroupRoot,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create runtime provisioner: %w", err)
@@ -166,6 +167,7 @@ func createProvisioner(
 			SandboxBinaryPath:     sandboxBinary,
 			InsecureNoSandbox:     insecureNoSandbox,
 			InsecureMock:          insecureMock,
+			CgroupRoot:            config.GlobalConfig.Runtime.CgroupRoot,
 			RuntimeAttestInterval: attestInterval,
 		})
 		if err != nil {
@@ -192,8 +194,7 @@ func createProvisioner(
 
 	// Configure optional load balancing.
 	for tee, rp := range provisioners {
-		numInstances := int(config.GlobalConfig.Runtime.LoadBalancer.NumInstances)
-		provisioners[tee] = hostLoadBalance.NewProvisioner(rp, numInstances)
+		provisioners[tee] = hostLoadBalance.NewProvisioner(rp, config.GlobalConfig.Runtime.LoadBalancer)
 	}
 
 	// Create a composite provisioner to provision the individual components.
@@ -233,6 +233,13 @@ func (h *sandboxHost) startProcess(ctx context.Context) (err error) {
 		// Make sure the process gets killed in case of errors.
 		if !ok && p != nil {
 			p.Kill()
+
+			// Surface processes killed due to exceeding their memory limit. The termination
+			// error is only available after the process has exited.
+			<-p.Wait()
+			if perr := p.Error(); errors.Is(perr, process.ErrOutOfMemory) {
+				err = fmt.Errorf("%w: %w", err, perr)
+			}
 		}
 	}()
 
@@ -240,6 +247,17 @@ func (h *sandboxHost) startProcess(ctx context.Context) (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to configure process: %w", err)
 	}
+	if limits := h.rtCfg.ResourceLimits; !limits.IsEmpty() {
+		switch h.cfg.CgroupRoot {
+		case "":
+			h.logger.Warn("not enforcing resource limits as cgroup root is not configured",
+				"limits", limits,
+			)
+		default:
+			cfg.ResourceLimits = limits
+			cfg.CgroupRoot = h.cfg.CgroupRoot
+		}
+	}
 	if err = connector.Configure(&h.rtCfg, &cfg); err != nil {
 		return err
 	}
@@ -520,8 +538,9 @@ func (h *sandboxHost) manager(ctx context.Context) {
 			return
 		case <-h.process.Wait():
 			// Process has terminated.
+			perr := h.process.Error()
 			h.logger.Error("runtime process has terminated unexpectedly",
-				"err", h.process.Error(),
+				"err", perr,
 			)
 
 			h.conn.Close()
@@ -533,7 +552,7 @@ func (h *sandboxHost) manager(ctx context.Context) {
 			h.Unlock()
 
 			// Notify subscribers that the runtime has stopped.
-			h.notifier.Broadcast(&host.Event{Stopped: &host.StoppedEvent{}})
+			h.notifier.Broadcast(&host.Event{Stopped: &host.StoppedEvent{Error: perr}})
 		case <-stopTickerCh:
 			// Stop the ticker if things work smoothly. Otherwise, keep on using the old ticker as
 			// it can happen that the runtime constantly terminates after a successful start.
@@ -193,6 +193,9 @@ func NewBubbleWrap(cfg Config) (Process, error) {
 		Args:   cliArgs,
 		Stdout: cfg.Stdout,
 		Stderr: cfg.Stderr,
+		// Enforce resource limits on the sandbox.
+		ResourceLimits: cfg.ResourceLimits,
+		CgroupRoot:     cfg.CgroupRoot,
 		// Pass all the pipe file descriptors.
 		// NOTE: Entry i becomes file descriptor 3+i.
 		extraFiles: fdPipes.pipes,
@@ -0,0 +1,151 @@
+//go:build linux
+// +build linux
+
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+const (
+	// cgroupPrefix is the name prefix of the cgroups created for sandboxed processes.
+	cgroupPrefix = "oasis-runtime-"
+
+	// cgroupCPUPeriod is the CPU bandwidth period (in microseconds) used for CPU limits.
+	cgroupCPUPeriod = 100_000
+
+	// cgroupRemoveRetries is the number of attempts to remove a cgroup after the process exits.
+	cgroupRemoveRetries = 10
+	// cgroupRemoveRetryInterval is the interval between attempts to remove a cgroup.
+	cgroupRemoveRetryInterval = 50 * time.Millisecond
+)
+
+// cgroup is a cgroup v2 subtree that enforces resource limits of a sandboxed process.
+type cgroup struct {
+	path string
+	dir  *os.File
+}
+
+func newCgroup(root string, limits *component.ResourceLimits) (*cgroup, error) {
+	switch {
+	case root == "":
+		return nil, fmt.Errorf("cgroup root not configured")
+	case !filepath.IsAbs(root):
+		return nil, fmt.Errorf("cgroup root '%s' is not an absolute path", root)
+	}
+
+	// Make sure the required controllers are available in child cgroups.
+	controllers, limitFiles := cgroupLimits(limits)
+	if err := writeCgroupFile(root, "cgroup.subtree_control", strings.Join(controllers, " ")); err != nil {
+		if errors.Is(err, syscall.EBUSY) {
+			// Controllers cannot be enabled for child cgroups of a non-root cgroup that holds
+			// processes itself (the "no internal processes" rule).
+			return nil, fmt.Errorf("failed to enable cgroup controllers: cgroup root '%s' must not contain any processes, move them to a child cgroup", root)
+		}
+		return nil, fmt.Errorf("failed to enable cgroup controllers: %w", err)
+	}
+
+	path, err := os.MkdirTemp(root, cgroupPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cgroup: %w", err)
+	}
+	cg := &cgroup{path: path}
+
+	for fn, value := range limitFiles {
+		if err = writeCgroupFile(path, fn, value); err != nil {
+			cg.destroy()
+			return nil, fmt.Errorf("failed to configure cgroup: %w", err)
+		}
+	}
+
+	if cg.dir, err = os.Open(path); err != nil {
+		cg.destroy()
+		return nil, fmt.Errorf("failed to open cgroup: %w", err)
+	}
+
+	return cg, nil
+}
+
+// cgroupLimits returns the controllers that need to be enabled in child cgroups and the contents
+// of the interface files that need to be written in order to enforce the given resource limits.
+func cgroupLimits(limits *component.ResourceLimits) ([]string, map[string]string) {
+	var controllers []string
+	limitFiles := make(map[string]string)
+	if limits.MemoryMax > 0 {
+		controllers = append(controllers, "+memory")
+		limitFiles["memory.max"] = strconv.FormatUint(limits.MemoryMax, 10)
+	}
+	if limits.MilliCPUs > 0 {
+		controllers = append(controllers, "+cpu")
+		limitFiles["cpu.max"] = fmt.Sprintf("%d %d", limits.MilliCPUs*cgroupCPUPeriod/1000, cgroupCPUPeriod)
+	}
+	if limits.PIDsMax > 0 {
+		controllers = append(controllers, "+pids")
+		limitFiles["pids.max"] = strconv.FormatUint(limits.PIDsMax, 10)
+	}
+	return controllers, limitFiles
+}
+
+// apply configures the given command to be started directly in the cgroup.
+func (cg *cgroup) apply(cmd *exec.Cmd) {
+	var attrs syscall.SysProcAttr
+	if cmd.SysProcAttr != nil {
+		attrs = *cmd.SysProcAttr
+	}
+	attrs.UseCgroupFD = true
+	attrs.CgroupFD = int(cg.dir.Fd())
+	cmd.SysProcAttr = &attrs
+}
+
+// oomKilled returns true iff any process in the cgroup has been killed by the OOM killer.
+func (cg *cgroup) oomKilled() bool {
+	data, err := os.ReadFile(filepath.Join(cg.path, "memory.events"))
+	if err != nil {
+		return false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[0] != "oom_kill" {
+			continue
+		}
+		count, err := strconv.ParseUint(fields[1], 10, 64)
+		return err == nil && count > 0
+	}
+	return false
+}
+
+// destroy kills any remaining processes in the cgroup and removes it.
+func (cg *cgroup) destroy() {
+	if cg.dir != nil {
+		_ = cg.dir.Close()
+		cg.dir = nil
+	}
+
+	// Kill any remaining processes, which is supported since Linux 5.14.
+	_ = writeCgroupFile(cg.path, "cgroup.kill", "1")
+
+	for i := 0; i < cgroupRemoveRetries; i++ {
+		if err := os.Remove(cg.path); err == nil || os.IsNotExist(err) {
+			return
+		}
+		time.Sleep(cgroupRemoveRetryInterval)
+	}
+}
+
+func writeCgroupFile(dir, fn, value string) error {
+	return os.WriteFile(filepath.Join(dir, fn), []byte(value), 0o600)
+}
@@ -0,0 +1,85 @@
+//go:build linux
+// +build linux
+
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+func TestCgroupLimits(t *testing.T) {
+	require := require.New(t)
+
+	controllers, files := cgroupLimits(&component.ResourceLimits{})
+	require.Empty(controllers)
+	require.Empty(files)
+
+	controllers, files = cgroupLimits(&component.ResourceLimits{
+		MemoryMax: 1 << 30,
+		MilliCPUs: 1500,
+		PIDsMax:   64,
+	})
+	require.Equal([]string{"+memory", "+cpu", "+pids"}, controllers)
+	require.Equal(map[string]string{
+		"memory.max": "1073741824",
+		"cpu.max":    "150000 100000",
+		"pids.max":   "64",
+	}, files)
+
+	controllers, files = cgroupLimits(&component.ResourceLimits{
+		MilliCPUs: 10,
+	})
+	require.Equal([]string{"+cpu"}, controllers)
+	require.Equal(map[string]string{
+		"cpu.max": "1000 100000",
+	}, files)
+}
+
+func TestCgroup(t *testing.T) {
+	require := require.New(t)
+
+	limits := &component.ResourceLimits{
+		MemoryMax: 1 << 20,
+		PIDsMax:   16,
+	}
+
+	_, err := newCgroup("", limits)
+	require.ErrorContains(err, "cgroup root not configured")
+	_, err = newCgroup("relative/cgroup", limits)
+	require.ErrorContains(err, "not an absolute path")
+	_, err = newCgroup(filepath.Join(t.TempDir(), "does-not-exist"), limits)
+	require.ErrorContains(err, "failed to enable cgroup controllers")
+
+	// Use a regular directory in place of a delegated cgroup to check the written files.
+	root := t.TempDir()
+	cg, err := newCgroup(root, limits)
+	require.NoError(err, "newCgroup")
+	require.Equal(root, filepath.Dir(cg.path), "cgroup should be created under the root")
+	require.True(strings.HasPrefix(filepath.Base(cg.path), cgroupPrefix), "cgroup should use the prefix")
+
+	readFile := func(dir, fn string) string {
+		data, rerr := os.ReadFile(filepath.Join(dir, fn))
+		require.NoError(rerr, "ReadFile(%s)", fn)
+		return string(data)
+	}
+	require.Equal("+memory +pids", readFile(root, "cgroup.subtree_control"))
+	require.Equal("1048576", readFile(cg.path, "memory.max"))
+	require.Equal("16", readFile(cg.path, "pids.max"))
+	require.NoFileExists(filepath.Join(cg.path, "cpu.max"))
+
+	// OOM kills should be detected from memory events.
+	require.False(cg.oomKilled(), "missing memory events should not be treated as OOM")
+	err = os.WriteFile(filepath.Join(cg.path, "memory.events"), []byte("low 0\nhigh 0\nmax 3\noom 1\noom_kill 0\n"), 0o600)
+	require.NoError(err, "WriteFile")
+	require.False(cg.oomKilled(), "no OOM kills should be detected")
+	err = os.WriteFile(filepath.Join(cg.path, "memory.events"), []byte("low 0\nhigh 0\nmax 3\noom 1\noom_kill 1\n"), 0o600)
+	require.NoError(err, "WriteFile")
+	require.True(cg.oomKilled(), "OOM kills should be detected")
+}
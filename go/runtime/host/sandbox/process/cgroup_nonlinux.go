@@ -0,0 +1,25 @@
+//go:build !linux
+// +build !linux
+
+package process
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+type cgroup struct{}
+
+func newCgroup(string, *component.ResourceLimits) (*cgroup, error) {
+	return nil, fmt.Errorf("resource limits are not supported on this platform")
+}
+
+func (cg *cgroup) apply(*exec.Cmd) {}
+
+func (cg *cgroup) oomKilled() bool {
+	return false
+}
+
+func (cg *cgroup) destroy() {}
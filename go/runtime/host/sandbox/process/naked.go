@@ -100,7 +100,20 @@ func NewNaked(cfg Config) (Process, error) {
 		}
 	}
 
+	// Start the process in a dedicated cgroup to enforce resource limits.
+	var cg *cgroup
+	if !cfg.ResourceLimits.IsEmpty() {
+		var err error
+		if cg, err = newCgroup(cfg.CgroupRoot, cfg.ResourceLimits); err != nil {
+			return nil, err
+		}
+		cg.apply(cmd)
+	}
+
 	if err := cmd.Start(); err != nil {
+		if cg != nil {
+			cg.destroy()
+		}
 		return nil, err
 	}
 
@@ -110,6 +123,12 @@ func NewNaked(cfg Config) (Process, error) {
 	}
 	go func() {
 		err := n.wait()
+		if cg != nil {
+			if cg.oomKilled() {
+				err = ErrOutOfMemory
+			}
+			cg.destroy()
+		}
 
 		n.Lock()
 		n.err = err
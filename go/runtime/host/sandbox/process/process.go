@@ -2,10 +2,17 @@
 package process
 
 import (
+	"errors"
 	"io"
 	"os"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 )
 
+// ErrOutOfMemory is the error returned when the process has been killed due to exceeding its
+// memory limit.
+var ErrOutOfMemory = errors.New("process killed due to out of memory")
+
 // Config contains the sandbox configuration.
 //
 // This is similar to the os/exec.Cmd structure.
@@ -45,6 +52,14 @@ type Config struct {
 	// AllowNetwork specifies whether network access should be allowed.
 	AllowNetwork bool
 
+	// ResourceLimits are the optional resource limits of the process which are enforced via
+	// a cgroup v2 subtree.
+	ResourceLimits *component.ResourceLimits
+
+	// CgroupRoot is the path to the delegated cgroup v2 directory under which the cgroup of the
+	// process is created. It must be set when resource limits are used.
+	CgroupRoot string
+
 	extraFiles []*os.File
 }
 
@@ -42,6 +42,10 @@ type Config struct {
 
 	// InsecureNoSandbox disables the sandbox and runs the runtime binary directly.
 	InsecureNoSandbox bool
+
+	// CgroupRoot is the path to the delegated cgroup v2 directory used to enforce resource limits
+	// of runtime processes. If not specified, resource limits are not enforced.
+	CgroupRoot string
 }
 
 type sandboxProvisioner struct {
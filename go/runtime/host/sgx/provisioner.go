@@ -76,6 +76,10 @@ type Config struct {
 
 	// InsecureNoSandbox disables the sandbox and runs the loader directly.
 	InsecureNoSandbox bool
+
+	// CgroupRoot is the path to the delegated cgroup v2 directory used to enforce resource limits
+	// of runtime processes. If not specified, resource limits are not enforced.
+	CgroupRoot string
 	// InsecureMock runs non-SGX binaries but treats it as if it would be running in an enclave,
 	// using mock quotes and reports.
 	//
@@ -124,6 +128,7 @@ func NewProvisioner(cfg Config) (host.Provisioner, error) {
 		HostInfo:          cfg.HostInfo,
 		HostInitializer:   p.hostInitializer,
 		InsecureNoSandbox: cfg.InsecureNoSandbox,
+		CgroupRoot:        cfg.CgroupRoot,
 		Logger:            p.logger,
 	})
 	if err != nil {
@@ -0,0 +1,94 @@
+package localstorage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+// exportVersion is the local storage export format version.
+const exportVersion = 1
+
+// exportHeader is the header of a local storage export.
+type exportHeader struct {
+	// Version is the export format version.
+	Version uint16 `json:"version"`
+	// RuntimeID is the identifier of the runtime that the local storage belongs to.
+	RuntimeID common.Namespace `json:"runtime_id"`
+}
+
+// exportEntry is a single key/value pair of a local storage export.
+type exportEntry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Export writes all key/value pairs of the given runtime's local storage into a portable stream
+// that can later be imported using Import. It returns the number of exported key/value pairs.
+func Export(s LocalStorage, runtimeID common.Namespace, w io.Writer) (uint64, error) {
+	enc := cbor.NewEncoder(w)
+	if err := enc.Encode(exportHeader{
+		Version:   exportVersion,
+		RuntimeID: runtimeID,
+	}); err != nil {
+		return 0, fmt.Errorf("localstorage: failed to write export header: %w", err)
+	}
+
+	var (
+		count  uint64
+		encErr error
+	)
+	if err := s.Iterate(nil, nil, func(key, value []byte) bool {
+		if encErr = enc.Encode(exportEntry{Key: key, Value: value}); encErr != nil {
+			return false
+		}
+		count++
+		return true
+	}); err != nil {
+		return 0, fmt.Errorf("localstorage: failed to iterate: %w", err)
+	}
+	if encErr != nil {
+		return 0, fmt.Errorf("localstorage: failed to write entry: %w", encErr)
+	}
+	return count, nil
+}
+
+// Import restores all key/value pairs from a stream previously created by Export into the given
+// runtime's local storage, overwriting any existing values under the same keys. It returns the
+// number of imported key/value pairs.
+func Import(s LocalStorage, runtimeID common.Namespace, r io.Reader) (uint64, error) {
+	dec := cbor.NewDecoder(r)
+
+	var header exportHeader
+	if err := dec.Decode(&header); err != nil {
+		return 0, fmt.Errorf("localstorage: failed to read export header: %w", err)
+	}
+	if header.Version != exportVersion {
+		return 0, fmt.Errorf("localstorage: unsupported export version: %d", header.Version)
+	}
+	if !header.RuntimeID.Equal(&runtimeID) {
+		return 0, fmt.Errorf("localstorage: export is for a different runtime (expected: %s got: %s)",
+			runtimeID,
+			header.RuntimeID,
+		)
+	}
+
+	var count uint64
+	for {
+		var entry exportEntry
+		if err := dec.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return count, fmt.Errorf("localstorage: failed to read entry: %w", err)
+		}
+		if err := s.Set(entry.Key, entry.Value); err != nil {
+			return count, fmt.Errorf("localstorage: failed to import entry: %w", err)
+		}
+		count++
+	}
+	return count, nil
+}
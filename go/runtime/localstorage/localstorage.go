@@ -3,10 +3,12 @@
 package localstorage
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"github.com/dgraph-io/badger/v4"
 	"github.com/dgraph-io/badger/v4/options"
@@ -19,6 +21,10 @@ import (
 var (
 	errInvalidKey = errors.New("invalid local storage key")
 
+	// ErrQuotaExceeded is the error returned when a write would exceed the quota configured for
+	// the key prefix.
+	ErrQuotaExceeded = errors.New("runtime/localstorage: quota exceeded")
+
 	_ LocalStorage = (*localStorage)(nil)
 )
 
@@ -30,15 +36,102 @@ type LocalStorage interface {
 	// Set sets a key to a specific value.
 	Set(key, value []byte) error
 
+	// Delete removes the given key.
+	Delete(key []byte) error
+
+	// Iterate calls fn for each key/value pair under the given key prefix in key order, starting
+	// after the given key (if non-empty). Iteration stops when fn returns false.
+	Iterate(prefix, after []byte, fn func(key, value []byte) bool) error
+
+	// SetQuota limits the total size (in bytes) of all keys and values under the given key
+	// prefix. A zero quota removes any previously configured quota.
+	SetQuota(prefix []byte, quota uint64) error
+
+	// Usage returns the total size (in bytes) of all keys and values under the given key prefix.
+	Usage(prefix []byte) (uint64, error)
+
 	// Stop stops local storage.
 	Stop()
 }
 
+// quota is the quota configured for a key prefix.
+type quota struct {
+	prefix []byte
+	limit  uint64
+	used   uint64
+}
+
 type localStorage struct {
 	logger *logging.Logger
 
 	db *badger.DB
 	gc *cmnBadger.GCWorker
+
+	// writeLock serializes writes so that quota usage is accounted correctly.
+	writeLock sync.Mutex
+	quotas    map[string]*quota
+}
+
+// matchingQuotas returns all quotas whose prefix matches the given key.
+func (s *localStorage) matchingQuotas(key []byte) []*quota {
+	var quotas []*quota
+	for _, q := range s.quotas {
+		if bytes.HasPrefix(key, q.prefix) {
+			quotas = append(quotas, q)
+		}
+	}
+	return quotas
+}
+
+// update replaces the value of the given key (removing it when value is nil) while enforcing
+// any quotas configured for the key.
+func (s *localStorage) update(key, value []byte) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	quotas := s.matchingQuotas(key)
+
+	var oldSize, newSize uint64
+	if value != nil {
+		newSize = entrySize(key, value)
+	}
+	if err := s.db.Update(func(tx *badger.Txn) error {
+		item, txErr := tx.Get(key)
+		switch txErr {
+		case nil:
+			if txErr = item.Value(func(val []byte) error {
+				oldSize = entrySize(key, val)
+				return nil
+			}); txErr != nil {
+				return txErr
+			}
+		case badger.ErrKeyNotFound:
+		default:
+			return txErr
+		}
+
+		// Writes that do not increase usage are always allowed so that components which
+		// exceed their quota (e.g. after it has been lowered) can free up space.
+		if newSize > oldSize {
+			for _, q := range quotas {
+				if q.used-min(q.used, oldSize)+newSize > q.limit {
+					return ErrQuotaExceeded
+				}
+			}
+		}
+
+		if value == nil {
+			return tx.Delete(key)
+		}
+		return tx.Set(key, value)
+	}); err != nil {
+		return err
+	}
+
+	for _, q := range quotas {
+		q.used = q.used - min(q.used, oldSize) + newSize
+	}
+	return nil
 }
 
 func (s *localStorage) Get(key []byte) ([]byte, error) {
@@ -77,9 +170,13 @@ func (s *localStorage) Set(key, value []byte) error {
 		return errInvalidKey
 	}
 
-	if err := s.db.Update(func(tx *badger.Txn) error {
-		return tx.Set(key, value)
-	}); err != nil {
+	if value == nil {
+		value = []byte{}
+	}
+	if err := s.update(key, value); err != nil {
+		if errors.Is(err, ErrQuotaExceeded) {
+			return err
+		}
 		s.logger.Error("failed put",
 			"err", err,
 			"key", hex.EncodeToString(key),
@@ -91,6 +188,104 @@ func (s *localStorage) Set(key, value []byte) error {
 	return nil
 }
 
+func (s *localStorage) Delete(key []byte) error {
+	if len(key) == 0 {
+		return errInvalidKey
+	}
+
+	if err := s.update(key, nil); err != nil {
+		s.logger.Error("failed delete",
+			"err", err,
+			"key", hex.EncodeToString(key),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (s *localStorage) Iterate(prefix, after []byte, fn func(key, value []byte) bool) error {
+	return s.db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		it.Seek(prefix)
+		if len(after) > 0 && bytes.Compare(after, prefix) >= 0 {
+			it.Seek(after)
+			if it.ValidForPrefix(prefix) && bytes.Equal(it.Item().Key(), after) {
+				it.Next()
+			}
+		}
+
+		for ; it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if !fn(item.KeyCopy(nil), value) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (s *localStorage) SetQuota(prefix []byte, limit uint64) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	if limit == 0 {
+		delete(s.quotas, string(prefix))
+		return nil
+	}
+
+	used, err := s.Usage(prefix)
+	if err != nil {
+		return fmt.Errorf("failed to compute local storage usage: %w", err)
+	}
+	if used > limit {
+		s.logger.Warn("local storage usage exceeds quota",
+			"prefix", hex.EncodeToString(prefix),
+			"used", used,
+			"quota", limit,
+		)
+	}
+
+	s.quotas[string(prefix)] = &quota{
+		prefix: append([]byte{}, prefix...),
+		limit:  limit,
+		used:   used,
+	}
+	return nil
+}
+
+func (s *localStorage) Usage(prefix []byte) (uint64, error) {
+	var used uint64
+	if err := s.db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			if err := item.Value(func(val []byte) error {
+				used += entrySize(item.Key(), val)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return used, nil
+}
+
 func (s *localStorage) Stop() {
 	s.gc.Stop()
 	if err := s.db.Close(); err != nil {
@@ -105,6 +300,7 @@ func (s *localStorage) Stop() {
 func New(dataDir, fn string, runtimeID common.Namespace) (LocalStorage, error) {
 	s := &localStorage{
 		logger: logging.GetLogger("runtime/localstorage").With("runtime_id", runtimeID),
+		quotas: make(map[string]*quota),
 	}
 
 	opts := badger.DefaultOptions(filepath.Join(dataDir, fn))
@@ -125,3 +321,7 @@ func New(dataDir, fn string, runtimeID common.Namespace) (LocalStorage, error) {
 
 	return s, nil
 }
+
+func entrySize(key, value []byte) uint64 {
+	return uint64(len(key)) + uint64(len(value))
+}
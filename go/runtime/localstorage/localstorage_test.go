@@ -0,0 +1,146 @@
+package localstorage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+var testNs = common.NewTestNamespaceFromSeed([]byte("localstorage test ns"), 0)
+
+func collect(t *testing.T, s LocalStorage, prefix, after []byte, limit int) []string {
+	var keys []string
+	err := s.Iterate(prefix, after, func(key, _ []byte) bool {
+		keys = append(keys, string(key))
+		return len(keys) < limit
+	})
+	require.NoError(t, err, "Iterate")
+	return keys
+}
+
+func TestLocalStorage(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(t.TempDir(), "local-storage.badger.db", testNs)
+	require.NoError(err, "New")
+	defer s.Stop()
+
+	for _, key := range []string{"a:1", "a:2", "a:3", "b:1", "c"} {
+		err = s.Set([]byte(key), []byte("value "+key))
+		require.NoError(err, "Set")
+	}
+
+	value, err := s.Get([]byte("a:2"))
+	require.NoError(err, "Get")
+	require.EqualValues("value a:2", value)
+
+	// Prefix iteration.
+	require.Equal([]string{"a:1", "a:2", "a:3"}, collect(t, s, []byte("a:"), nil, 10))
+	require.Equal([]string{"a:1", "a:2"}, collect(t, s, []byte("a:"), nil, 2))
+	require.Equal([]string{"a:3"}, collect(t, s, []byte("a:"), []byte("a:2"), 10))
+	require.Equal([]string{"a:2", "a:3"}, collect(t, s, []byte("a:"), []byte("a:10"), 10))
+	require.Empty(collect(t, s, []byte("a:"), []byte("b"), 10))
+	require.Equal([]string{"a:1", "a:2", "a:3", "b:1", "c"}, collect(t, s, nil, nil, 10))
+
+	// Delete.
+	err = s.Delete([]byte("a:2"))
+	require.NoError(err, "Delete")
+	value, err = s.Get([]byte("a:2"))
+	require.NoError(err, "Get")
+	require.Nil(value)
+	require.Equal([]string{"a:1", "a:3"}, collect(t, s, []byte("a:"), nil, 10))
+
+	err = s.Delete([]byte("a:2"))
+	require.NoError(err, "Delete of a missing key should succeed")
+	err = s.Delete(nil)
+	require.Error(err, "Delete of an empty key should fail")
+}
+
+func TestLocalStorageQuota(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(t.TempDir(), "local-storage.badger.db", testNs)
+	require.NoError(err, "New")
+	defer s.Stop()
+
+	err = s.Set([]byte("a:1"), bytes.Repeat([]byte{1}, 7))
+	require.NoError(err, "Set")
+
+	used, err := s.Usage([]byte("a:"))
+	require.NoError(err, "Usage")
+	require.EqualValues(10, used)
+
+	err = s.SetQuota([]byte("a:"), 20)
+	require.NoError(err, "SetQuota")
+
+	// Writes within the quota should succeed.
+	err = s.Set([]byte("a:2"), bytes.Repeat([]byte{2}, 7))
+	require.NoError(err, "Set")
+
+	// Writes exceeding the quota should fail.
+	err = s.Set([]byte("a:3"), []byte{3})
+	require.ErrorIs(err, ErrQuotaExceeded)
+	err = s.Set([]byte("a:2"), bytes.Repeat([]byte{2}, 8))
+	require.ErrorIs(err, ErrQuotaExceeded)
+
+	// Keys outside the prefix should not be affected.
+	err = s.Set([]byte("b:1"), bytes.Repeat([]byte{1}, 100))
+	require.NoError(err, "Set")
+
+	// Overwriting with smaller values and deleting should free up space.
+	err = s.Set([]byte("a:2"), bytes.Repeat([]byte{2}, 3))
+	require.NoError(err, "Set")
+	err = s.Delete([]byte("a:1"))
+	require.NoError(err, "Delete")
+	err = s.Set([]byte("a:3"), bytes.Repeat([]byte{3}, 7))
+	require.NoError(err, "Set")
+
+	used, err = s.Usage([]byte("a:"))
+	require.NoError(err, "Usage")
+	require.EqualValues(16, used)
+
+	// Removing the quota should allow any writes.
+	err = s.SetQuota([]byte("a:"), 0)
+	require.NoError(err, "SetQuota")
+	err = s.Set([]byte("a:4"), bytes.Repeat([]byte{4}, 100))
+	require.NoError(err, "Set")
+}
+
+func TestLocalStorageExportImport(t *testing.T) {
+	require := require.New(t)
+
+	src, err := New(t.TempDir(), "local-storage.badger.db", testNs)
+	require.NoError(err, "New")
+	defer src.Stop()
+
+	for _, key := range []string{"a:1", "a:2", "b:1"} {
+		err = src.Set([]byte(key), []byte("value "+key))
+		require.NoError(err, "Set")
+	}
+
+	var buf bytes.Buffer
+	count, err := Export(src, testNs, &buf)
+	require.NoError(err, "Export")
+	require.EqualValues(3, count)
+	data := buf.Bytes()
+
+	dst, err := New(t.TempDir(), "local-storage.badger.db", testNs)
+	require.NoError(err, "New")
+	defer dst.Stop()
+
+	otherNs := common.NewTestNamespaceFromSeed([]byte("localstorage other test ns"), 0)
+	_, err = Import(dst, otherNs, bytes.NewReader(data))
+	require.Error(err, "Import for a different runtime should fail")
+
+	count, err = Import(dst, testNs, bytes.NewReader(data))
+	require.NoError(err, "Import")
+	require.EqualValues(3, count)
+
+	require.Equal([]string{"a:1", "a:2", "b:1"}, collect(t, dst, nil, nil, 10))
+	value, err := dst.Get([]byte("b:1"))
+	require.NoError(err, "Get")
+	require.EqualValues("value b:1", value)
+}
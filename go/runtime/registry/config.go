@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/config"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 )
 
@@ -20,6 +21,22 @@ func getLocalConfig(runtimeID common.Namespace) map[string]interface{} {
 	return config.GlobalConfig.Runtime.GetLocalConfig(runtimeID)
 }
 
+func getResourceLimits(runtimeID common.Namespace, comp *bundle.ExplodedComponent) *component.ResourceLimits {
+	limits := comp.Resources
+	if compCfg, ok := config.GlobalConfig.Runtime.GetComponent(runtimeID, comp.ID()); ok {
+		limits = limits.Merge(compCfg.Resources)
+	}
+	return limits
+}
+
+func getLocalStorageQuota(runtimeID common.Namespace, compID component.ID) uint64 {
+	compCfg, ok := config.GlobalConfig.Runtime.GetComponent(runtimeID, compID)
+	if !ok {
+		return 0
+	}
+	return compCfg.LocalStorageQuota
+}
+
 func getConfiguredRuntimeIDs() ([]common.Namespace, error) {
 	// Check if any runtimes are configured to be hosted.
 	runtimes := make(map[common.Namespace]struct{})
@@ -97,7 +114,10 @@ func createHistoryFactory() (history.Factory, error) {
 	mode := config.GlobalConfig.Mode
 	hasLocalStorage := mode.HasLocalStorage() && !mode.IsArchive()
 
-	historyFactory := history.NewFactory(pruneFactory, hasLocalStorage)
+	indexEvents := config.GlobalConfig.Runtime.EventIndex.Enabled
+	indexTransactions := config.GlobalConfig.Runtime.TxIndex.Enabled
+
+	historyFactory := history.NewFactory(pruneFactory, hasLocalStorage, indexEvents, indexTransactions)
 
 	return historyFactory, nil
 }
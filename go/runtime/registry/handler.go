@@ -20,6 +20,10 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
+// maxLocalStorageIterateItems is the maximum number of key/value pairs returned by a single local
+// storage iterate request.
+const maxLocalStorageIterateItems = 1000
+
 // Ensure that the runtime host handler implements the Handler interface.
 var _ protocol.Handler = (*runtimeHostHandler)(nil)
 
@@ -88,6 +92,12 @@ func (h *runtimeHostHandler) Handle(ctx context.Context, rq *protocol.Body) (*pr
 	case rq.HostLocalStorageSetRequest != nil:
 		// Local storage set.
 		rsp.HostLocalStorageSetResponse, err = h.handleHostLocalStorageSet(rq.HostLocalStorageSetRequest)
+	case rq.HostLocalStorageDeleteRequest != nil:
+		// Local storage delete.
+		rsp.HostLocalStorageDeleteResponse, err = h.handleHostLocalStorageDelete(rq.HostLocalStorageDeleteRequest)
+	case rq.HostLocalStorageIterateRequest != nil:
+		// Local storage iterate.
+		rsp.HostLocalStorageIterateResponse, err = h.handleHostLocalStorageIterate(rq.HostLocalStorageIterateRequest)
 	case rq.HostFetchConsensusBlockRequest != nil:
 		// Consensus light client.
 		rsp.HostFetchConsensusBlockResponse, err = h.handleHostFetchConsensusBlock(ctx, rq.HostFetchConsensusBlockRequest)
@@ -253,6 +263,33 @@ func (h *runtimeHostHandler) handleHostLocalStorageSet(
 	return &protocol.Empty{}, nil
 }
 
+func (h *runtimeHostHandler) handleHostLocalStorageDelete(
+	rq *protocol.HostLocalStorageDeleteRequest,
+) (*protocol.Empty, error) {
+	if err := h.runtime.LocalStorage().Delete(rq.Key); err != nil {
+		return nil, err
+	}
+	return &protocol.Empty{}, nil
+}
+
+func (h *runtimeHostHandler) handleHostLocalStorageIterate(
+	rq *protocol.HostLocalStorageIterateRequest,
+) (*protocol.HostLocalStorageIterateResponse, error) {
+	limit := rq.Limit
+	if limit == 0 || limit > maxLocalStorageIterateItems {
+		limit = maxLocalStorageIterateItems
+	}
+
+	var items []protocol.HostLocalStorageKeyValue
+	if err := h.runtime.LocalStorage().Iterate(rq.Prefix, rq.After, func(key, value []byte) bool {
+		items = append(items, protocol.HostLocalStorageKeyValue{Key: key, Value: value})
+		return uint64(len(items)) < limit
+	}); err != nil {
+		return nil, err
+	}
+	return &protocol.HostLocalStorageIterateResponse{Items: items}, nil
+}
+
 func (h *runtimeHostHandler) handleHostFetchConsensusBlock(
 	ctx context.Context,
 	rq *protocol.HostFetchConsensusBlockRequest,
@@ -65,6 +65,7 @@ func (n *RuntimeHostNode) ProvisionHostedRuntimeComponent(comp *bundle.ExplodedC
 		Component:      comp,
 		MessageHandler: handler,
 		LocalConfig:    getLocalConfig(n.runtime.ID()),
+		ResourceLimits: getResourceLimits(n.runtime.ID(), comp),
 	}
 
 	rt, err := n.provisioner.NewRuntime(cfg)
@@ -9,6 +9,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	cmnSync "github.com/oasisprotocol/oasis-core/go/common/sync"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
@@ -57,14 +58,22 @@ func newSubHandlerROFL(id component.ID, parent *runtimeHostHandler) (*roflHostHa
 		With("runtime_id", parent.runtime.ID()).
 		With("component_id", id)
 
-	return &roflHostHandler{
+	rh := &roflHostHandler{
 		parent:        parent,
 		id:            id,
 		comps:         make(map[component.ID]host.Runtime),
 		client:        client,
-		eventNotifier: newROFLEventNotifier(parent.runtime, client, logger),
+		eventNotifier: newROFLEventNotifier(id, parent.runtime, client, parent.consensus, logger),
 		logger:        logger,
-	}, nil
+	}
+
+	// Enforce the local storage quota of the component, if any.
+	quota := getLocalStorageQuota(parent.runtime.ID(), id)
+	if err = parent.runtime.LocalStorage().SetQuota(rh.getLocalStorageKey(nil), quota); err != nil {
+		return nil, fmt.Errorf("failed to configure local storage quota: %w", err)
+	}
+
+	return rh, nil
 }
 
 // Implements host.RuntimeHandler.
@@ -89,6 +98,11 @@ func (rh *roflHostHandler) getLocalStorageKey(key []byte) []byte {
 	return result
 }
 
+// stripLocalStorageKey returns the component-local version of a namespaced local storage key.
+func (rh *roflHostHandler) stripLocalStorageKey(key []byte) []byte {
+	return key[len(rh.getLocalStorageKey(nil)):]
+}
+
 // Implements protocol.Handler.
 func (rh *roflHostHandler) Handle(ctx context.Context, rq *protocol.Body) (*protocol.Body, error) {
 	var (
@@ -107,6 +121,13 @@ func (rh *roflHostHandler) Handle(ctx context.Context, rq *protocol.Body) (*prot
 		// Local storage set.
 		rq.HostLocalStorageSetRequest.Key = rh.getLocalStorageKey(rq.HostLocalStorageSetRequest.Key)
 		return rh.parent.Handle(ctx, rq)
+	case rq.HostLocalStorageDeleteRequest != nil:
+		// Local storage delete.
+		rq.HostLocalStorageDeleteRequest.Key = rh.getLocalStorageKey(rq.HostLocalStorageDeleteRequest.Key)
+		return rh.parent.Handle(ctx, rq)
+	case rq.HostLocalStorageIterateRequest != nil:
+		// Local storage iterate.
+		return rh.handleHostLocalStorageIterate(ctx, rq)
 	case rq.HostSubmitTxRequest != nil:
 		// Transaction submission.
 		rsp.HostSubmitTxResponse, err = rh.handleHostSubmitTx(ctx, rq.HostSubmitTxRequest)
@@ -125,6 +146,29 @@ func (rh *roflHostHandler) Handle(ctx context.Context, rq *protocol.Body) (*prot
 	return &rsp, nil
 }
 
+func (rh *roflHostHandler) handleHostLocalStorageIterate(
+	ctx context.Context,
+	rq *protocol.Body,
+) (*protocol.Body, error) {
+	iterRq := rq.HostLocalStorageIterateRequest
+	iterRq.Prefix = rh.getLocalStorageKey(iterRq.Prefix)
+	if len(iterRq.After) > 0 {
+		iterRq.After = rh.getLocalStorageKey(iterRq.After)
+	}
+
+	rsp, err := rh.parent.Handle(ctx, rq)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.HostLocalStorageIterateResponse != nil {
+		for i := range rsp.HostLocalStorageIterateResponse.Items {
+			item := &rsp.HostLocalStorageIterateResponse.Items[i]
+			item.Key = rh.stripLocalStorageKey(item.Key)
+		}
+	}
+	return rsp, nil
+}
+
 func (rh *roflHostHandler) handleHostRPCCall(
 	ctx context.Context,
 	rq *protocol.Body,
@@ -236,9 +280,14 @@ type roflAttachRuntimeCmd struct {
 	ch chan<- error
 }
 
+type roflRegisterNotifyCmd struct {
+	rq *protocol.HostRegisterNotifyRequest
+	ch chan<- error
+}
+
 type roflEventNotifierCmd struct {
 	// registerNotify is the command to register for notifications.
-	registerNotify *protocol.HostRegisterNotifyRequest
+	registerNotify *roflRegisterNotifyCmd
 	// attachRuntime is the command to attach a runtime host.
 	attachRuntime *roflAttachRuntimeCmd
 }
@@ -246,20 +295,30 @@ type roflEventNotifierCmd struct {
 type roflEventNotifier struct {
 	startOne cmnSync.One
 
-	runtime Runtime
-	client  runtimeClient.RuntimeClient
-	cmdCh   chan *roflEventNotifierCmd
+	id        component.ID
+	runtime   Runtime
+	client    runtimeClient.RuntimeClient
+	consensus consensus.Backend
+	cmdCh     chan *roflEventNotifierCmd
 
 	logger *logging.Logger
 }
 
-func newROFLEventNotifier(runtime Runtime, client runtimeClient.RuntimeClient, logger *logging.Logger) *roflEventNotifier {
+func newROFLEventNotifier(
+	id component.ID,
+	runtime Runtime,
+	client runtimeClient.RuntimeClient,
+	consensus consensus.Backend,
+	logger *logging.Logger,
+) *roflEventNotifier {
 	return &roflEventNotifier{
-		startOne: cmnSync.NewOne(),
-		runtime:  runtime,
-		client:   client,
-		cmdCh:    make(chan *roflEventNotifierCmd),
-		logger:   logger,
+		startOne:  cmnSync.NewOne(),
+		id:        id,
+		runtime:   runtime,
+		client:    client,
+		consensus: consensus,
+		cmdCh:     make(chan *roflEventNotifierCmd),
+		logger:    logger,
 	}
 }
 
@@ -270,12 +329,19 @@ func (en *roflEventNotifier) start() {
 func (en *roflEventNotifier) RegisterNotify(ctx context.Context, rq *protocol.HostRegisterNotifyRequest) error {
 	en.start() // Ensure event notifier is running.
 
+	ch := make(chan error, 1)
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case en.cmdCh <- &roflEventNotifierCmd{registerNotify: rq}:
+	case en.cmdCh <- &roflEventNotifierCmd{registerNotify: &roflRegisterNotifyCmd{rq, ch}}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-ch:
+		return err
 	}
-	return nil
 }
 
 func (en *roflEventNotifier) AttachRuntime(rt host.Runtime) error {
@@ -299,7 +365,38 @@ func (en *roflEventNotifier) run(ctx context.Context) {
 
 		notifyBlocks bool
 		notifyTags   [][]byte
+
+		cs = &roflConsensusSubscriptions{}
+
+		pos roflChainPosition
+		ss  = &roflScheduleSubscriptions{}
 	)
+	defer func() {
+		cs.Close()
+		ss.Close()
+	}()
+
+	// Load any persisted schedule so that scheduled triggers survive restarts.
+	sched := en.loadSchedule()
+	resubscribeSchedule := func() {
+		if newSs := en.resubscribeSchedule(ctx, sched); newSs != nil {
+			ss.Close()
+			ss = newSs
+		}
+	}
+	resubscribeSchedule()
+	processSchedule := func() {
+		if sched == nil {
+			return
+		}
+
+		// Triggers that have fired may no longer need the same chain time sources.
+		needsEpochs, needsHeights := sched.needsEpochs(), sched.needsHeights()
+		en.processSchedule(ctx, rt, sched, &pos)
+		if sched.needsEpochs() != needsEpochs || sched.needsHeights() != needsHeights {
+			resubscribeSchedule()
+		}
+	}
 
 	for {
 		select {
@@ -309,14 +406,44 @@ func (en *roflEventNotifier) run(ctx context.Context) {
 			// Process a command.
 			switch {
 			case cmd.registerNotify != nil:
-				// Update configuration.
-				notifyBlocks = cmd.registerNotify.RuntimeBlock
+				// Validate the request before changing any state.
+				rq := cmd.registerNotify.rq
+				var err error
+				if rq.Schedule != nil {
+					err = en.validateSchedule(sched, rq.Schedule)
+				}
 
-				if re := cmd.registerNotify.RuntimeEvent; re != nil {
-					notifyTags = re.Tags
-				} else {
-					notifyTags = nil
+				// Subscribe to consensus layer events.
+				var newCs *roflConsensusSubscriptions
+				if err == nil {
+					newCs, err = en.subscribeConsensus(ctx, rq)
 				}
+
+				// Replace any existing scheduled triggers.
+				if err == nil && rq.Schedule != nil {
+					if err = en.updateSchedule(sched, rq.Schedule); err != nil {
+						newCs.Close()
+					} else {
+						resubscribeSchedule()
+					}
+				}
+
+				// Update configuration and replace any existing consensus layer subscriptions.
+				if err == nil {
+					notifyBlocks = rq.RuntimeBlock
+
+					if re := rq.RuntimeEvent; re != nil {
+						notifyTags = re.Tags
+					} else {
+						notifyTags = nil
+					}
+
+					cs.Close()
+					cs = newCs
+				}
+
+				cmd.registerNotify.ch <- err
+				close(cmd.registerNotify.ch)
 			case cmd.attachRuntime != nil:
 				// Attach runtime.
 				var err error
@@ -350,6 +477,36 @@ func (en *roflEventNotifier) run(ctx context.Context) {
 			}
 
 			en.notifyTags(ctx, rt, blk, notifyTags)
+
+			if blk != nil {
+				pos.round, pos.haveRound = blk.Block.Header.Round, true
+				processSchedule()
+			}
+		case ev := <-cs.stakingCh:
+			en.notifyConsensusEvent(ctx, rt, cs.filterStakingEvent(ev))
+		case ev := <-cs.registryCh:
+			en.notifyConsensusEvent(ctx, rt, cs.filterRegistryEvent(ev))
+		case ev := <-cs.governanceCh:
+			en.notifyConsensusEvent(ctx, rt, cs.filterGovernanceEvent(ev))
+		case rtDsc := <-cs.rtDscCh:
+			if rtDsc != nil {
+				cs.kmID = rtDsc.KeyManager
+			}
+		case st := <-cs.kmStatusCh:
+			en.notifyKeyManagerStatus(ctx, rt, cs.filterKeyManagerStatus(st))
+		case epoch, ok := <-ss.epochCh:
+			if !ok {
+				ss.epochCh = nil
+				continue
+			}
+			pos.epoch, pos.haveEpoch = epoch, true
+			processSchedule()
+		case cblk := <-ss.blkCh:
+			if cblk == nil {
+				continue
+			}
+			pos.height, pos.haveHeight = cblk.Height, true
+			processSchedule()
 		}
 	}
 }
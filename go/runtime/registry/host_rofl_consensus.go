@@ -0,0 +1,213 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensusResults "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// roflConsensusSubscriptions are the consensus layer subscriptions of the ROFL event notifier.
+//
+// Channels of kinds that have not been subscribed to are nil so they never fire.
+type roflConsensusSubscriptions struct {
+	filter *protocol.RegisterNotifyConsensusEvent
+
+	// runtimeID is the identifier of the runtime the subscriptions belong to.
+	runtimeID common.Namespace
+
+	stakingCh    <-chan *staking.Event
+	registryCh   <-chan *registry.Event
+	governanceCh <-chan *governance.Event
+	kmStatusCh   <-chan *secrets.Status
+	rtDscCh      <-chan *registry.Runtime
+
+	// kmID is the identifier of the runtime's current key manager, if any.
+	kmID *common.Namespace
+
+	subs []pubsub.ClosableSubscription
+}
+
+// subscribeConsensus subscribes to consensus layer events as requested by the given notification
+// registration request.
+func (en *roflEventNotifier) subscribeConsensus(
+	ctx context.Context,
+	rq *protocol.HostRegisterNotifyRequest,
+) (*roflConsensusSubscriptions, error) {
+	cs := &roflConsensusSubscriptions{
+		filter:    rq.ConsensusEvent,
+		runtimeID: en.runtime.ID(),
+	}
+
+	if filter := rq.ConsensusEvent; !filter.IsEmpty() {
+		if len(filter.StakingTransfersTo) > 0 {
+			ch, sub, err := en.consensus.Staking().WatchEvents(ctx)
+			if err != nil {
+				cs.Close()
+				return nil, fmt.Errorf("failed to subscribe to staking events: %w", err)
+			}
+			cs.stakingCh = ch
+			cs.subs = append(cs.subs, sub)
+		}
+		if filter.RegistryNodes {
+			ch, sub, err := en.consensus.Registry().WatchEvents(ctx)
+			if err != nil {
+				cs.Close()
+				return nil, fmt.Errorf("failed to subscribe to registry events: %w", err)
+			}
+			cs.registryCh = ch
+			cs.subs = append(cs.subs, sub)
+		}
+		if filter.GovernanceProposals {
+			ch, sub, err := en.consensus.Governance().WatchEvents(ctx)
+			if err != nil {
+				cs.Close()
+				return nil, fmt.Errorf("failed to subscribe to governance events: %w", err)
+			}
+			cs.governanceCh = ch
+			cs.subs = append(cs.subs, sub)
+		}
+	}
+
+	if rq.KeyManagerStatus {
+		// Track the key manager of the runtime as it may change.
+		dscCh, dscSub, err := en.runtime.WatchRegistryDescriptor()
+		if err != nil {
+			cs.Close()
+			return nil, fmt.Errorf("failed to subscribe to registry descriptor updates: %w", err)
+		}
+		cs.rtDscCh = dscCh
+		cs.subs = append(cs.subs, dscSub)
+
+		// Start with the current key manager so that statuses are not missed until the next
+		// descriptor update.
+		rtDsc, err := en.runtime.RegistryDescriptor(ctx)
+		if err != nil {
+			cs.Close()
+			return nil, fmt.Errorf("failed to get registry descriptor: %w", err)
+		}
+		cs.kmID = rtDsc.KeyManager
+
+		stCh, stSub := en.consensus.KeyManager().Secrets().WatchStatuses()
+		cs.kmStatusCh = stCh
+		cs.subs = append(cs.subs, stSub)
+	}
+
+	return cs, nil
+}
+
+// Close closes all consensus layer subscriptions.
+func (cs *roflConsensusSubscriptions) Close() {
+	if cs == nil {
+		return
+	}
+	for _, sub := range cs.subs {
+		sub.Close()
+	}
+	cs.subs = nil
+}
+
+// filterStakingEvent returns the subset of the staking event that has been subscribed to, if any.
+func (cs *roflConsensusSubscriptions) filterStakingEvent(ev *staking.Event) *consensusResults.Event {
+	if ev == nil || ev.Transfer == nil || !slices.Contains(cs.filter.StakingTransfersTo, ev.Transfer.To) {
+		return nil
+	}
+	return &consensusResults.Event{
+		Staking: &staking.Event{
+			Height:   ev.Height,
+			TxHash:   ev.TxHash,
+			Transfer: ev.Transfer,
+		},
+	}
+}
+
+// filterRegistryEvent returns the subset of the registry event that has been subscribed to, if any.
+//
+// Only events of nodes that host the runtime are passed through.
+func (cs *roflConsensusSubscriptions) filterRegistryEvent(ev *registry.Event) *consensusResults.Event {
+	if ev == nil || ev.NodeEvent == nil || ev.NodeEvent.Node == nil || !ev.NodeEvent.Node.HasRuntime(cs.runtimeID) {
+		return nil
+	}
+	return &consensusResults.Event{
+		Registry: &registry.Event{
+			Height:    ev.Height,
+			TxHash:    ev.TxHash,
+			NodeEvent: ev.NodeEvent,
+		},
+	}
+}
+
+// filterGovernanceEvent returns the subset of the governance event that has been subscribed to,
+// if any.
+func (cs *roflConsensusSubscriptions) filterGovernanceEvent(ev *governance.Event) *consensusResults.Event {
+	if ev == nil || (ev.ProposalSubmitted == nil && ev.ProposalExecuted == nil && ev.ProposalFinalized == nil) {
+		return nil
+	}
+	return &consensusResults.Event{
+		Governance: &governance.Event{
+			Height:            ev.Height,
+			TxHash:            ev.TxHash,
+			ProposalSubmitted: ev.ProposalSubmitted,
+			ProposalExecuted:  ev.ProposalExecuted,
+			ProposalFinalized: ev.ProposalFinalized,
+		},
+	}
+}
+
+// filterKeyManagerStatus returns the status if it belongs to the runtime's key manager.
+func (cs *roflConsensusSubscriptions) filterKeyManagerStatus(st *secrets.Status) *secrets.Status {
+	if st == nil || cs.kmID == nil || !st.ID.Equal(cs.kmID) {
+		return nil
+	}
+	return st
+}
+
+func (en *roflEventNotifier) notifyConsensusEvent(ctx context.Context, rt host.Runtime, ev *consensusResults.Event) {
+	if rt == nil || ev == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, roflNotifyTimeout)
+	defer cancel()
+
+	_, err := rt.Call(ctx, &protocol.Body{
+		RuntimeNotifyRequest: &protocol.RuntimeNotifyRequest{
+			ConsensusEvent: ev,
+		},
+	})
+	if err != nil {
+		en.logger.Warn("failed to deliver consensus event notification to runtime",
+			"err", err,
+		)
+	}
+}
+
+func (en *roflEventNotifier) notifyKeyManagerStatus(ctx context.Context, rt host.Runtime, st *secrets.Status) {
+	if rt == nil || st == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, roflNotifyTimeout)
+	defer cancel()
+
+	_, err := rt.Call(ctx, &protocol.Body{
+		RuntimeNotifyRequest: &protocol.RuntimeNotifyRequest{
+			KeyManagerStatus: st,
+		},
+	})
+	if err != nil {
+		en.logger.Warn("failed to deliver key manager status notification to runtime",
+			"err", err,
+			"keymanager", st.ID,
+		)
+	}
+}
@@ -0,0 +1,203 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var (
+	testROFLRuntimeID    = common.NewTestNamespaceFromSeed([]byte("runtime/registry: rofl test runtime"), 0)
+	testROFLKeyManagerID = common.NewTestNamespaceFromSeed([]byte("runtime/registry: rofl test key manager"), common.NamespaceKeyManager)
+)
+
+// testROFLRuntime is a runtime with a fixed registry descriptor.
+type testROFLRuntime struct {
+	Runtime
+
+	dataDir string
+	rtDsc   *registry.Runtime
+	rtDscs  *pubsub.Broker
+}
+
+func newTestROFLRuntime(dataDir string, rtDsc *registry.Runtime) *testROFLRuntime {
+	return &testROFLRuntime{
+		dataDir: dataDir,
+		rtDsc:   rtDsc,
+		rtDscs:  pubsub.NewBroker(false),
+	}
+}
+
+func (r *testROFLRuntime) ID() common.Namespace {
+	return r.rtDsc.ID
+}
+
+func (r *testROFLRuntime) DataDir() string {
+	return r.dataDir
+}
+
+func (r *testROFLRuntime) RegistryDescriptor(context.Context) (*registry.Runtime, error) {
+	return r.rtDsc, nil
+}
+
+func (r *testROFLRuntime) WatchRegistryDescriptor() (<-chan *registry.Runtime, pubsub.ClosableSubscription, error) {
+	sub := r.rtDscs.Subscribe()
+	ch := make(chan *registry.Runtime)
+	sub.Unwrap(ch)
+
+	return ch, sub, nil
+}
+
+// testROFLConsensus is a consensus backend that only supports key manager status updates.
+type testROFLConsensus struct {
+	consensus.Backend
+
+	km *testROFLKeyManager
+}
+
+func (c *testROFLConsensus) KeyManager() keymanager.Backend {
+	return c.km
+}
+
+type testROFLKeyManager struct {
+	keymanager.Backend
+
+	secrets *testROFLSecrets
+}
+
+func (km *testROFLKeyManager) Secrets() secrets.Backend {
+	return km.secrets
+}
+
+type testROFLSecrets struct {
+	secrets.Backend
+
+	statuses *pubsub.Broker
+}
+
+func (s *testROFLSecrets) WatchStatuses() (<-chan *secrets.Status, *pubsub.Subscription) {
+	sub := s.statuses.Subscribe()
+	ch := make(chan *secrets.Status)
+	sub.Unwrap(ch)
+
+	return ch, sub
+}
+
+func newTestROFLEventNotifier(dataDir string, rtDsc *registry.Runtime) *roflEventNotifier {
+	return newROFLEventNotifier(
+		component.ID_RONL,
+		newTestROFLRuntime(dataDir, rtDsc),
+		nil,
+		&testROFLConsensus{
+			km: &testROFLKeyManager{
+				secrets: &testROFLSecrets{statuses: pubsub.NewBroker(false)},
+			},
+		},
+		logging.GetLogger("runtime/registry/test"),
+	)
+}
+
+func TestROFLConsensusFilters(t *testing.T) {
+	require := require.New(t)
+
+	otherRuntimeID := common.NewTestNamespaceFromSeed([]byte("runtime/registry: rofl other runtime"), 0)
+	addr1 := staking.NewModuleAddress("rofl-test", "address 1")
+	addr2 := staking.NewModuleAddress("rofl-test", "address 2")
+
+	cs := &roflConsensusSubscriptions{
+		filter: &protocol.RegisterNotifyConsensusEvent{
+			StakingTransfersTo: []staking.Address{addr1},
+			RegistryNodes:      true,
+		},
+		runtimeID: testROFLRuntimeID,
+	}
+
+	// Staking events should be filtered by the destination address.
+	ev := cs.filterStakingEvent(&staking.Event{Height: 1, Transfer: &staking.TransferEvent{To: addr1}})
+	require.NotNil(ev, "transfers to subscribed addresses should be passed through")
+	require.Equal(addr1, ev.Staking.Transfer.To)
+	require.Nil(cs.filterStakingEvent(&staking.Event{Transfer: &staking.TransferEvent{To: addr2}}))
+	require.Nil(cs.filterStakingEvent(&staking.Event{Burn: &staking.BurnEvent{Owner: addr1}}))
+	require.Nil(cs.filterStakingEvent(nil))
+
+	// Registry events should be filtered by the runtimes the nodes host.
+	nodeEvent := func(runtimeIDs ...common.Namespace) *registry.Event {
+		var n node.Node
+		for _, id := range runtimeIDs {
+			n.Runtimes = append(n.Runtimes, &node.Runtime{ID: id})
+		}
+		return &registry.Event{Height: 1, NodeEvent: &registry.NodeEvent{Node: &n, IsRegistration: true}}
+	}
+	ev = cs.filterRegistryEvent(nodeEvent(otherRuntimeID, testROFLRuntimeID))
+	require.NotNil(ev, "events of nodes hosting the runtime should be passed through")
+	require.True(ev.Registry.NodeEvent.IsRegistration)
+	require.Nil(cs.filterRegistryEvent(nodeEvent(otherRuntimeID)), "events of other nodes should be filtered")
+	require.Nil(cs.filterRegistryEvent(nodeEvent()), "events of other nodes should be filtered")
+	require.Nil(cs.filterRegistryEvent(&registry.Event{NodeEvent: &registry.NodeEvent{}}))
+	require.Nil(cs.filterRegistryEvent(&registry.Event{EntityEvent: &registry.EntityEvent{}}))
+	require.Nil(cs.filterRegistryEvent(nil))
+
+	// Governance events should be filtered by kind.
+	ev = cs.filterGovernanceEvent(&governance.Event{ProposalExecuted: &governance.ProposalExecutedEvent{ID: 1}})
+	require.NotNil(ev, "proposal events should be passed through")
+	require.Nil(cs.filterGovernanceEvent(&governance.Event{Vote: &governance.VoteEvent{ID: 1}}))
+	require.Nil(cs.filterGovernanceEvent(nil))
+
+	// Key manager statuses should be filtered by the runtime's key manager.
+	st := &secrets.Status{ID: testROFLKeyManagerID}
+	require.Nil(cs.filterKeyManagerStatus(st), "statuses should be filtered without a key manager")
+	cs.kmID = &testROFLKeyManagerID
+	require.Equal(st, cs.filterKeyManagerStatus(st))
+	require.Nil(cs.filterKeyManagerStatus(&secrets.Status{ID: otherRuntimeID}))
+	require.Nil(cs.filterKeyManagerStatus(nil))
+}
+
+func TestROFLSubscribeConsensus(t *testing.T) {
+	require := require.New(t)
+
+	en := newTestROFLEventNotifier(t.TempDir(), &registry.Runtime{
+		ID:         testROFLRuntimeID,
+		KeyManager: &testROFLKeyManagerID,
+	})
+	ctx := context.Background()
+
+	// Key manager statuses should be filtered by the current key manager right away.
+	cs, err := en.subscribeConsensus(ctx, &protocol.HostRegisterNotifyRequest{KeyManagerStatus: true})
+	require.NoError(err, "subscribeConsensus")
+	defer cs.Close()
+
+	require.Equal(testROFLRuntimeID, cs.runtimeID)
+	require.NotNil(cs.kmID, "key manager should be initialized from the current descriptor")
+	require.Equal(testROFLKeyManagerID, *cs.kmID)
+	require.NotNil(cs.rtDscCh)
+	require.NotNil(cs.kmStatusCh)
+	require.Nil(cs.stakingCh)
+	require.Nil(cs.registryCh)
+	require.Nil(cs.governanceCh)
+
+	st := &secrets.Status{ID: testROFLKeyManagerID}
+	require.Equal(st, cs.filterKeyManagerStatus(st))
+
+	// Runtimes without a key manager should not receive any statuses.
+	en = newTestROFLEventNotifier(t.TempDir(), &registry.Runtime{ID: testROFLRuntimeID})
+	cs, err = en.subscribeConsensus(ctx, &protocol.HostRegisterNotifyRequest{KeyManagerStatus: true})
+	require.NoError(err, "subscribeConsensus")
+	defer cs.Close()
+
+	require.Nil(cs.kmID)
+	require.Nil(cs.filterKeyManagerStatus(st))
+}
@@ -0,0 +1,403 @@
+package registry
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+const (
+	// roflScheduleDir is the name of the directory (within the runtime state directory) holding
+	// persisted schedules of ROFL components.
+	roflScheduleDir = "rofl-schedules"
+
+	// roflMaxScheduledTriggers is the maximum number of scheduled triggers per component.
+	roflMaxScheduledTriggers = 64
+)
+
+// roflChainPosition is the latest observed chain position used to evaluate scheduled triggers.
+type roflChainPosition struct {
+	round     uint64
+	haveRound bool
+
+	epoch     beacon.EpochTime
+	haveEpoch bool
+
+	height     int64
+	haveHeight bool
+}
+
+// roflScheduledTrigger is a scheduled trigger together with its delivery state.
+type roflScheduledTrigger struct {
+	// Trigger is the trigger definition.
+	Trigger protocol.ScheduledTrigger `json:"trigger"`
+	// Armed is true iff a periodic trigger has observed the chain position at least once.
+	Armed bool `json:"armed,omitempty"`
+	// Last is the round or epoch of the last delivered (or skipped) periodic trigger.
+	Last uint64 `json:"last,omitempty"`
+	// Fired is true iff a one-shot trigger has been delivered.
+	Fired bool `json:"fired,omitempty"`
+}
+
+// check returns the trigger notification in case the trigger is due at the given chain position.
+// It also returns true when the delivery state of the trigger has changed.
+func (t *roflScheduledTrigger) check(pos *roflChainPosition) (*protocol.RuntimeNotifyScheduledTrigger, bool) {
+	tr := &t.Trigger
+	switch {
+	case tr.EveryRounds > 0:
+		if !pos.haveRound {
+			return nil, false
+		}
+		scheduled, due, changed := t.checkPeriodic(pos.round, tr.EveryRounds)
+		if !due {
+			return nil, changed
+		}
+		return &protocol.RuntimeNotifyScheduledTrigger{ID: tr.ID, Round: scheduled}, changed
+	case tr.EveryEpochs > 0:
+		if !pos.haveEpoch {
+			return nil, false
+		}
+		scheduled, due, changed := t.checkPeriodic(uint64(pos.epoch), tr.EveryEpochs)
+		if !due {
+			return nil, changed
+		}
+		return &protocol.RuntimeNotifyScheduledTrigger{ID: tr.ID, Epoch: beacon.EpochTime(scheduled)}, changed
+	case tr.AtEpoch > 0:
+		if t.Fired || !pos.haveEpoch || pos.epoch < tr.AtEpoch {
+			return nil, false
+		}
+		return &protocol.RuntimeNotifyScheduledTrigger{ID: tr.ID, Epoch: tr.AtEpoch}, false
+	case tr.AtHeight > 0:
+		if t.Fired || !pos.haveHeight || pos.height < tr.AtHeight {
+			return nil, false
+		}
+		return &protocol.RuntimeNotifyScheduledTrigger{ID: tr.ID, Height: tr.AtHeight}, false
+	default:
+		return nil, false
+	}
+}
+
+// checkPeriodic checks whether a periodic trigger with the given interval is due at the given
+// position and returns the position at which it was scheduled.
+//
+// Triggers that were missed (e.g. while the node was offline) are coalesced into a single one.
+func (t *roflScheduledTrigger) checkPeriodic(current, interval uint64) (uint64, bool, bool) {
+	scheduled := current - current%interval
+	if !t.Armed {
+		// Do not deliver triggers scheduled before the trigger has been registered.
+		t.Armed = true
+		t.Last = scheduled
+		return 0, false, true
+	}
+	return scheduled, scheduled > t.Last, false
+}
+
+// markDelivered updates the delivery state after the given notification has been delivered.
+func (t *roflScheduledTrigger) markDelivered(n *protocol.RuntimeNotifyScheduledTrigger) {
+	switch {
+	case t.Trigger.EveryRounds > 0:
+		t.Last = n.Round
+	case t.Trigger.EveryEpochs > 0:
+		t.Last = uint64(n.Epoch)
+	default:
+		t.Fired = true
+	}
+}
+
+// roflSchedule is the persisted schedule of a ROFL component.
+type roflSchedule struct {
+	path string
+
+	// Triggers are the scheduled triggers.
+	Triggers []*roflScheduledTrigger `json:"triggers,omitempty"`
+}
+
+// needsEpochs returns true iff any of the triggers depends on epoch transitions.
+func (s *roflSchedule) needsEpochs() bool {
+	for _, t := range s.Triggers {
+		if t.Trigger.EveryEpochs > 0 || (t.Trigger.AtEpoch > 0 && !t.Fired) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsHeights returns true iff any of the triggers depends on consensus heights.
+func (s *roflSchedule) needsHeights() bool {
+	for _, t := range s.Triggers {
+		if t.Trigger.AtHeight > 0 && !t.Fired {
+			return true
+		}
+	}
+	return false
+}
+
+// replace replaces the triggers while keeping the delivery state of unchanged triggers.
+func (s *roflSchedule) replace(triggers []protocol.ScheduledTrigger) {
+	existing := make(map[protocol.ScheduledTrigger]*roflScheduledTrigger, len(s.Triggers))
+	for _, t := range s.Triggers {
+		existing[t.Trigger] = t
+	}
+
+	s.Triggers = make([]*roflScheduledTrigger, 0, len(triggers))
+	for _, tr := range triggers {
+		if t, ok := existing[tr]; ok {
+			s.Triggers = append(s.Triggers, t)
+			continue
+		}
+		s.Triggers = append(s.Triggers, &roflScheduledTrigger{Trigger: tr})
+	}
+}
+
+// save persists the schedule.
+func (s *roflSchedule) save() error {
+	// Write to a temporary file first so that partially written schedules are never loaded.
+	f, err := os.CreateTemp(filepath.Dir(s.path), "tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create schedule: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(cbor.Marshal(s)); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write schedule: %w", err)
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to sync schedule: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to close schedule: %w", err)
+	}
+	if err = os.Rename(f.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to commit schedule: %w", err)
+	}
+	return nil
+}
+
+// loadROFLSchedule loads the persisted schedule of the given component. In case no schedule has
+// been persisted, an empty schedule is returned.
+func loadROFLSchedule(rtDataDir string, id component.ID) (*roflSchedule, error) {
+	dir := filepath.Join(rtDataDir, roflScheduleDir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create schedule directory: %w", err)
+	}
+
+	// Component names are not guaranteed to be valid file names, so encode them.
+	name, err := id.MarshalText()
+	if err != nil {
+		return nil, fmt.Errorf("malformed component identifier: %w", err)
+	}
+	s := &roflSchedule{
+		path: filepath.Join(dir, hex.EncodeToString(name)),
+	}
+	data, err := os.ReadFile(s.path)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	default:
+		return nil, fmt.Errorf("failed to read schedule: %w", err)
+	}
+	if err = cbor.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("malformed schedule: %w", err)
+	}
+	return s, nil
+}
+
+// validateROFLSchedule validates the scheduled triggers requested by a component.
+func validateROFLSchedule(rs *protocol.RegisterNotifySchedule) error {
+	if len(rs.Triggers) > roflMaxScheduledTriggers {
+		return fmt.Errorf("too many scheduled triggers (max: %d)", roflMaxScheduledTriggers)
+	}
+
+	ids := make(map[string]struct{}, len(rs.Triggers))
+	for i, tr := range rs.Triggers {
+		if tr.ID == "" {
+			return fmt.Errorf("scheduled trigger %d: missing identifier", i)
+		}
+		if _, ok := ids[tr.ID]; ok {
+			return fmt.Errorf("scheduled trigger %d: duplicate identifier '%s'", i, tr.ID)
+		}
+		ids[tr.ID] = struct{}{}
+
+		var kinds int
+		for _, set := range []bool{tr.EveryRounds > 0, tr.EveryEpochs > 0, tr.AtEpoch > 0, tr.AtHeight > 0} {
+			if set {
+				kinds++
+			}
+		}
+		if kinds != 1 {
+			return fmt.Errorf("scheduled trigger '%s': exactly one schedule must be set", tr.ID)
+		}
+	}
+	return nil
+}
+
+// roflScheduleSubscriptions are the chain time subscriptions needed by the scheduled triggers.
+//
+// Channels that are not needed are nil so they never fire.
+type roflScheduleSubscriptions struct {
+	epochCh <-chan beacon.EpochTime
+	blkCh   <-chan *consensus.Block
+
+	subs []pubsub.ClosableSubscription
+}
+
+// subscribeSchedule subscribes to the chain time sources needed by the given schedule.
+func (en *roflEventNotifier) subscribeSchedule(ctx context.Context, s *roflSchedule) (*roflScheduleSubscriptions, error) {
+	ss := &roflScheduleSubscriptions{}
+	if s.needsEpochs() {
+		ch, sub, err := en.consensus.Beacon().WatchEpochs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to epochs: %w", err)
+		}
+		ss.epochCh = ch
+		ss.subs = append(ss.subs, sub)
+	}
+	if s.needsHeights() {
+		ch, sub, err := en.consensus.WatchBlocks(ctx)
+		if err != nil {
+			ss.Close()
+			return nil, fmt.Errorf("failed to subscribe to consensus blocks: %w", err)
+		}
+		ss.blkCh = ch
+		ss.subs = append(ss.subs, sub)
+	}
+	return ss, nil
+}
+
+// Close closes all chain time subscriptions.
+func (ss *roflScheduleSubscriptions) Close() {
+	if ss == nil {
+		return
+	}
+	for _, sub := range ss.subs {
+		sub.Close()
+	}
+	ss.subs = nil
+}
+
+// loadSchedule loads the persisted schedule of the component. In case the schedule cannot be
+// loaded, scheduled triggers are disabled and nil is returned.
+func (en *roflEventNotifier) loadSchedule() *roflSchedule {
+	s, err := loadROFLSchedule(en.runtime.DataDir(), en.id)
+	if err != nil {
+		en.logger.Error("failed to load schedule, scheduled triggers are disabled",
+			"err", err,
+		)
+		return nil
+	}
+	return s
+}
+
+// validateSchedule validates the scheduled triggers requested by the component.
+func (en *roflEventNotifier) validateSchedule(s *roflSchedule, rs *protocol.RegisterNotifySchedule) error {
+	if s == nil {
+		return fmt.Errorf("scheduled triggers are not available")
+	}
+	if err := validateROFLSchedule(rs); err != nil {
+		return fmt.Errorf("malformed schedule: %w", err)
+	}
+	return nil
+}
+
+// updateSchedule replaces the scheduled triggers and persists the schedule. In case the schedule
+// cannot be persisted, the previous triggers are kept.
+//
+// The scheduled triggers must have been validated using validateSchedule.
+func (en *roflEventNotifier) updateSchedule(s *roflSchedule, rs *protocol.RegisterNotifySchedule) error {
+	prev := s.Triggers
+	s.replace(rs.Triggers)
+	if err := s.save(); err != nil {
+		s.Triggers = prev
+		return fmt.Errorf("failed to persist schedule: %w", err)
+	}
+	return nil
+}
+
+// resubscribeSchedule returns the chain time subscriptions matching the given schedule. In case
+// the subscriptions cannot be established, nil is returned and the existing ones should be kept.
+func (en *roflEventNotifier) resubscribeSchedule(ctx context.Context, s *roflSchedule) *roflScheduleSubscriptions {
+	if s == nil {
+		return nil
+	}
+	ss, err := en.subscribeSchedule(ctx, s)
+	if err != nil {
+		en.logger.Error("failed to subscribe to chain time for schedule",
+			"err", err,
+		)
+		return nil
+	}
+	return ss
+}
+
+// processSchedule delivers all scheduled triggers that are due at the given chain position and
+// persists the updated schedule.
+func (en *roflEventNotifier) processSchedule(
+	ctx context.Context,
+	rt host.Runtime,
+	s *roflSchedule,
+	pos *roflChainPosition,
+) {
+	if rt == nil || s == nil {
+		return
+	}
+
+	var changed bool
+	for _, t := range s.Triggers {
+		n, stateChanged := t.check(pos)
+		changed = changed || stateChanged
+		if n == nil {
+			continue
+		}
+		if err := en.notifyScheduledTrigger(ctx, rt, n); err != nil {
+			// Delivery will be retried at the next chain position update.
+			continue
+		}
+		t.markDelivered(n)
+		changed = true
+	}
+	if !changed {
+		return
+	}
+
+	if err := s.save(); err != nil {
+		en.logger.Error("failed to persist schedule",
+			"err", err,
+		)
+	}
+}
+
+func (en *roflEventNotifier) notifyScheduledTrigger(
+	ctx context.Context,
+	rt host.Runtime,
+	n *protocol.RuntimeNotifyScheduledTrigger,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, roflNotifyTimeout)
+	defer cancel()
+
+	_, err := rt.Call(ctx, &protocol.Body{
+		RuntimeNotifyRequest: &protocol.RuntimeNotifyRequest{
+			ScheduledTrigger: n,
+		},
+	})
+	if err != nil {
+		en.logger.Warn("failed to deliver scheduled trigger notification to runtime",
+			"err", err,
+			"trigger_id", n.ID,
+		)
+	}
+	return err
+}
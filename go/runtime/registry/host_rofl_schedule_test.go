@@ -0,0 +1,292 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+func TestROFLScheduledTriggerCheckPeriodic(t *testing.T) {
+	require := require.New(t)
+
+	var tr roflScheduledTrigger
+
+	// The first observed position should only arm the trigger.
+	scheduled, due, changed := tr.checkPeriodic(25, 10)
+	require.False(due, "trigger should not be due when arming")
+	require.True(changed, "arming should change the delivery state")
+	require.True(tr.Armed)
+	require.EqualValues(20, tr.Last)
+
+	scheduled, due, changed = tr.checkPeriodic(29, 10)
+	require.False(due, "trigger should not be due before the next period")
+	require.False(changed)
+
+	scheduled, due, changed = tr.checkPeriodic(30, 10)
+	require.True(due, "trigger should be due at the next period")
+	require.False(changed)
+	require.EqualValues(30, scheduled)
+
+	// Until delivered, the trigger should remain due.
+	scheduled, due, _ = tr.checkPeriodic(31, 10)
+	require.True(due, "undelivered trigger should remain due")
+	require.EqualValues(30, scheduled)
+	tr.Last = scheduled
+
+	// Missed periods should be coalesced into a single trigger.
+	scheduled, due, _ = tr.checkPeriodic(75, 10)
+	require.True(due, "trigger should be due after missed periods")
+	require.EqualValues(70, scheduled, "missed periods should be coalesced")
+	tr.Last = scheduled
+
+	_, due, _ = tr.checkPeriodic(79, 10)
+	require.False(due, "coalesced trigger should only be delivered once")
+}
+
+func TestROFLScheduledTriggerCheck(t *testing.T) {
+	t.Run("EveryRounds", func(t *testing.T) {
+		require := require.New(t)
+
+		tr := &roflScheduledTrigger{Trigger: protocol.ScheduledTrigger{ID: "rounds", EveryRounds: 5}}
+
+		n, changed := tr.check(&roflChainPosition{epoch: 10, haveEpoch: true})
+		require.Nil(n, "round trigger should not fire without a round")
+		require.False(changed)
+
+		n, changed = tr.check(&roflChainPosition{round: 7, haveRound: true})
+		require.Nil(n, "round trigger should not fire when arming")
+		require.True(changed)
+
+		n, _ = tr.check(&roflChainPosition{round: 10, haveRound: true})
+		require.Equal(&protocol.RuntimeNotifyScheduledTrigger{ID: "rounds", Round: 10}, n)
+		tr.markDelivered(n)
+		require.EqualValues(10, tr.Last)
+
+		n, _ = tr.check(&roflChainPosition{round: 12, haveRound: true})
+		require.Nil(n, "delivered round trigger should not fire again in the same period")
+	})
+
+	t.Run("EveryEpochs", func(t *testing.T) {
+		require := require.New(t)
+
+		tr := &roflScheduledTrigger{Trigger: protocol.ScheduledTrigger{ID: "epochs", EveryEpochs: 2}}
+
+		n, _ := tr.check(&roflChainPosition{epoch: 3, haveEpoch: true})
+		require.Nil(n, "epoch trigger should not fire when arming")
+
+		n, _ = tr.check(&roflChainPosition{epoch: 4, haveEpoch: true})
+		require.Equal(&protocol.RuntimeNotifyScheduledTrigger{ID: "epochs", Epoch: 4}, n)
+		tr.markDelivered(n)
+		require.EqualValues(4, tr.Last)
+
+		n, _ = tr.check(&roflChainPosition{epoch: 5, haveEpoch: true})
+		require.Nil(n)
+	})
+
+	t.Run("AtEpoch", func(t *testing.T) {
+		require := require.New(t)
+
+		s := &roflSchedule{Triggers: []*roflScheduledTrigger{
+			{Trigger: protocol.ScheduledTrigger{ID: "at-epoch", AtEpoch: 5}},
+		}}
+		tr := s.Triggers[0]
+		require.True(s.needsEpochs())
+
+		n, _ := tr.check(&roflChainPosition{epoch: 4, haveEpoch: true})
+		require.Nil(n, "one-shot trigger should not fire early")
+
+		// Delivery failures should be retried.
+		n, changed := tr.check(&roflChainPosition{epoch: 6, haveEpoch: true})
+		require.Equal(&protocol.RuntimeNotifyScheduledTrigger{ID: "at-epoch", Epoch: 5}, n)
+		require.False(changed)
+		n, _ = tr.check(&roflChainPosition{epoch: 6, haveEpoch: true})
+		require.NotNil(n, "undelivered one-shot trigger should be retried")
+
+		tr.markDelivered(n)
+		require.True(tr.Fired)
+		require.False(s.needsEpochs(), "fired one-shot trigger should no longer need epochs")
+
+		n, _ = tr.check(&roflChainPosition{epoch: 7, haveEpoch: true})
+		require.Nil(n, "one-shot trigger should only fire once")
+	})
+
+	t.Run("AtHeight", func(t *testing.T) {
+		require := require.New(t)
+
+		s := &roflSchedule{Triggers: []*roflScheduledTrigger{
+			{Trigger: protocol.ScheduledTrigger{ID: "at-height", AtHeight: 100}},
+		}}
+		tr := s.Triggers[0]
+		require.True(s.needsHeights())
+		require.False(s.needsEpochs())
+
+		n, _ := tr.check(&roflChainPosition{height: 99, haveHeight: true})
+		require.Nil(n, "one-shot trigger should not fire early")
+
+		n, _ = tr.check(&roflChainPosition{height: 100, haveHeight: true})
+		require.Equal(&protocol.RuntimeNotifyScheduledTrigger{ID: "at-height", Height: 100}, n)
+		tr.markDelivered(n)
+		require.False(s.needsHeights(), "fired one-shot trigger should no longer need heights")
+
+		n, _ = tr.check(&roflChainPosition{height: 101, haveHeight: true})
+		require.Nil(n, "one-shot trigger should only fire once")
+	})
+}
+
+func TestROFLScheduleReplace(t *testing.T) {
+	require := require.New(t)
+
+	periodic := protocol.ScheduledTrigger{ID: "periodic", EveryRounds: 10}
+	oneShot := protocol.ScheduledTrigger{ID: "one-shot", AtHeight: 100}
+
+	s := &roflSchedule{}
+	s.replace([]protocol.ScheduledTrigger{periodic, oneShot})
+	require.Len(s.Triggers, 2)
+
+	s.Triggers[0].Armed = true
+	s.Triggers[0].Last = 20
+	s.Triggers[1].Fired = true
+
+	// Unchanged triggers should keep their delivery state.
+	changed := protocol.ScheduledTrigger{ID: "periodic", EveryRounds: 5}
+	added := protocol.ScheduledTrigger{ID: "added", EveryEpochs: 1}
+	s.replace([]protocol.ScheduledTrigger{oneShot, changed, added})
+	require.Len(s.Triggers, 3)
+	require.Equal(oneShot, s.Triggers[0].Trigger)
+	require.True(s.Triggers[0].Fired, "unchanged one-shot trigger should not fire again")
+	require.Equal(&roflScheduledTrigger{Trigger: changed}, s.Triggers[1], "changed trigger should be reset")
+	require.Equal(&roflScheduledTrigger{Trigger: added}, s.Triggers[2])
+
+	// Removed triggers should be dropped.
+	s.replace(nil)
+	require.Empty(s.Triggers)
+}
+
+func TestValidateROFLSchedule(t *testing.T) {
+	tooMany := make([]protocol.ScheduledTrigger, 0, roflMaxScheduledTriggers+1)
+	for i := 0; i <= roflMaxScheduledTriggers; i++ {
+		tooMany = append(tooMany, protocol.ScheduledTrigger{ID: fmt.Sprintf("t%d", i), EveryRounds: 1})
+	}
+
+	for _, tc := range []struct {
+		name     string
+		triggers []protocol.ScheduledTrigger
+		err      string
+	}{
+		{"Empty", nil, ""},
+		{"Valid", []protocol.ScheduledTrigger{
+			{ID: "a", EveryRounds: 1},
+			{ID: "b", EveryEpochs: 1},
+			{ID: "c", AtEpoch: 1},
+			{ID: "d", AtHeight: 1},
+		}, ""},
+		{"TooMany", tooMany, "too many scheduled triggers"},
+		{"MissingID", []protocol.ScheduledTrigger{{EveryRounds: 1}}, "missing identifier"},
+		{"DuplicateID", []protocol.ScheduledTrigger{
+			{ID: "a", EveryRounds: 1},
+			{ID: "a", EveryEpochs: 1},
+		}, "duplicate identifier"},
+		{"NoSchedule", []protocol.ScheduledTrigger{{ID: "a"}}, "exactly one schedule"},
+		{"MultipleSchedules", []protocol.ScheduledTrigger{{ID: "a", EveryRounds: 1, AtHeight: 1}}, "exactly one schedule"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateROFLSchedule(&protocol.RegisterNotifySchedule{Triggers: tc.triggers})
+			switch tc.err {
+			case "":
+				require.NoError(t, err)
+			default:
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestROFLScheduleSaveLoad(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+
+	// Missing schedules should load as empty.
+	s, err := loadROFLSchedule(dataDir, component.ID_RONL)
+	require.NoError(err, "loadROFLSchedule")
+	require.Empty(s.Triggers)
+
+	s.replace([]protocol.ScheduledTrigger{
+		{ID: "periodic", EveryEpochs: 2},
+		{ID: "one-shot", AtEpoch: 10},
+	})
+	s.Triggers[0].Armed = true
+	s.Triggers[0].Last = 4
+	s.Triggers[1].Fired = true
+	err = s.save()
+	require.NoError(err, "save")
+
+	// Schedules should be persisted per component.
+	other, err := loadROFLSchedule(dataDir, component.ID{Kind: component.ROFL, Name: "other"})
+	require.NoError(err, "loadROFLSchedule")
+	require.Empty(other.Triggers)
+
+	loaded, err := loadROFLSchedule(dataDir, component.ID_RONL)
+	require.NoError(err, "loadROFLSchedule")
+	require.Equal(s, loaded, "delivery state should be persisted")
+
+	// Malformed schedules should be rejected.
+	err = os.WriteFile(s.path, []byte("malformed"), 0o600)
+	require.NoError(err, "WriteFile")
+	_, err = loadROFLSchedule(dataDir, component.ID_RONL)
+	require.ErrorContains(err, "malformed schedule")
+}
+
+func TestROFLRegisterNotifySchedule(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	en := newTestROFLEventNotifier(dataDir, &registry.Runtime{ID: testROFLRuntimeID})
+	defer en.startOne.TryStop()
+
+	ctx := context.Background()
+	triggers := []protocol.ScheduledTrigger{{ID: "periodic", EveryRounds: 10}}
+	err := en.RegisterNotify(ctx, &protocol.HostRegisterNotifyRequest{
+		Schedule: &protocol.RegisterNotifySchedule{Triggers: triggers},
+	})
+	require.NoError(err, "RegisterNotify")
+
+	// Invalid schedules should be rejected without changing any state.
+	err = en.RegisterNotify(ctx, &protocol.HostRegisterNotifyRequest{
+		RuntimeBlock: true,
+		Schedule: &protocol.RegisterNotifySchedule{Triggers: []protocol.ScheduledTrigger{
+			{ID: "periodic", EveryRounds: 10},
+			{ID: "invalid"},
+		}},
+	})
+	require.ErrorContains(err, "malformed schedule")
+
+	s, err := loadROFLSchedule(dataDir, component.ID_RONL)
+	require.NoError(err, "loadROFLSchedule")
+	require.Len(s.Triggers, 1)
+	require.Equal(triggers[0], s.Triggers[0].Trigger)
+}
+
+func TestROFLUpdateSchedule(t *testing.T) {
+	require := require.New(t)
+
+	en := newTestROFLEventNotifier(t.TempDir(), &registry.Runtime{ID: testROFLRuntimeID})
+	s := &roflSchedule{path: filepath.Join(t.TempDir(), "missing", "schedule")}
+	s.replace([]protocol.ScheduledTrigger{{ID: "periodic", EveryRounds: 10}})
+	prev := s.Triggers
+
+	// Triggers should be kept in case the schedule cannot be persisted.
+	err := en.updateSchedule(s, &protocol.RegisterNotifySchedule{
+		Triggers: []protocol.ScheduledTrigger{{ID: "one-shot", AtHeight: 10}},
+	})
+	require.ErrorContains(err, "failed to persist schedule")
+	require.Equal(prev, s.Triggers)
+}
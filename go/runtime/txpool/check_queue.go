@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/gammazero/deque"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 )
 
 type checkTxQueue struct {
@@ -62,6 +64,15 @@ func (cq *checkTxQueue) pop() []*PendingCheckTransaction {
 	return batch
 }
 
+func (cq *checkTxQueue) contains(h hash.Hash) bool {
+	cq.l.Lock()
+	defer cq.l.Unlock()
+
+	return cq.txs.Index(func(pct *PendingCheckTransaction) bool {
+		return pct.Hash() == h
+	}) >= 0
+}
+
 func (cq *checkTxQueue) size() int {
 	cq.l.Lock()
 	defer cq.l.Unlock()
@@ -7,6 +7,8 @@ import "time"
 type Config struct {
 	// Maximum size of the scheduling transaction pool.
 	MaxPoolSize uint64 `yaml:"schedule_max_tx_pool_size"`
+	// Maximum number of transactions from the same sender in the scheduling transaction pool.
+	MaxTxsPerSender uint64 `yaml:"schedule_max_txs_per_sender,omitempty"`
 	// Maximum cache size of recently scheduled transactions to prevent re-scheduling.
 	MaxLastSeenCacheSize uint64 `yaml:"schedule_tx_cache_size"`
 	// Maximum check tx batch size.
@@ -15,4 +17,9 @@ type Config struct {
 	RecheckInterval uint64 `yaml:"recheck_interval"`
 	// Republish interval.
 	RepublishInterval time.Duration
+	// Whether transactions submitted by local clients should be persisted on disk and replayed
+	// after a node restart.
+	PersistLocalTxs bool `yaml:"persist_local_txs,omitempty"`
+	// Maximum age of persisted local transactions. Older transactions are not replayed.
+	PersistedLocalTxMaxAge time.Duration `yaml:"persisted_local_tx_max_age,omitempty"`
 }
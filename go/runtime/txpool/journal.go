@@ -0,0 +1,186 @@
+package txpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+const (
+	// localTxJournalDir is the name of the local transaction journal directory.
+	localTxJournalDir = "txpool-journal"
+	// journalEntrySuffix is the filename suffix of journal entries.
+	journalEntrySuffix = ".tx"
+)
+
+// journalEntry is a persisted local transaction.
+type journalEntry struct {
+	// Tx is the raw transaction.
+	Tx []byte `json:"tx"`
+	// Timestamp is the UNIX timestamp (in seconds) when the transaction was first submitted.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// localTxJournal is an on-disk journal of transactions submitted by local clients which allows
+// them to survive node restarts. Each transaction is stored in its own file.
+type localTxJournal struct {
+	l      sync.Mutex
+	logger *logging.Logger
+
+	dir string
+	txs map[hash.Hash]struct{}
+}
+
+func (j *localTxJournal) entryPath(h hash.Hash) string {
+	return filepath.Join(j.dir, h.String()+journalEntrySuffix)
+}
+
+// add persists the given transaction. Transactions that are already in the journal are not
+// updated so that they keep their original timestamp.
+func (j *localTxJournal) add(tx *TxQueueMeta) error {
+	j.l.Lock()
+	defer j.l.Unlock()
+
+	if _, ok := j.txs[tx.Hash()]; ok {
+		return nil
+	}
+
+	entry := journalEntry{
+		Tx:        tx.Raw(),
+		Timestamp: tx.FirstSeen().Unix(),
+	}
+
+	// Write to a temporary file first so that partially written entries are never loaded.
+	f, err := os.CreateTemp(j.dir, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("txpool: failed to create journal entry: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(cbor.Marshal(entry)); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("txpool: failed to write journal entry: %w", err)
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("txpool: failed to sync journal entry: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("txpool: failed to close journal entry: %w", err)
+	}
+	if err = os.Rename(f.Name(), j.entryPath(tx.Hash())); err != nil {
+		return fmt.Errorf("txpool: failed to commit journal entry: %w", err)
+	}
+
+	j.txs[tx.Hash()] = struct{}{}
+	return nil
+}
+
+// remove removes the given transactions from the journal. Transactions not in the journal are
+// ignored.
+func (j *localTxJournal) remove(hashes []hash.Hash) {
+	j.l.Lock()
+	defer j.l.Unlock()
+
+	for _, h := range hashes {
+		if _, ok := j.txs[h]; !ok {
+			continue
+		}
+		delete(j.txs, h)
+
+		if err := os.Remove(j.entryPath(h)); err != nil && !os.IsNotExist(err) {
+			j.logger.Warn("failed to remove journal entry",
+				"err", err,
+				"tx_hash", h,
+			)
+		}
+	}
+}
+
+// load loads all persisted transactions that are not older than maxAge. Expired and malformed
+// entries are removed from the journal.
+func (j *localTxJournal) load(maxAge time.Duration) ([]*TxQueueMeta, error) {
+	j.l.Lock()
+	defer j.l.Unlock()
+
+	files, err := os.ReadDir(j.dir)
+	if err != nil {
+		return nil, fmt.Errorf("txpool: failed to read journal: %w", err)
+	}
+
+	var txs []*TxQueueMeta
+	for _, file := range files {
+		path := filepath.Join(j.dir, file.Name())
+		if file.IsDir() || !strings.HasSuffix(file.Name(), journalEntrySuffix) {
+			// Remove any leftover temporary files.
+			_ = os.Remove(path)
+			continue
+		}
+
+		var entry journalEntry
+		if err = loadJournalEntry(path, &entry); err != nil {
+			j.logger.Warn("removing malformed journal entry",
+				"err", err,
+				"path", path,
+			)
+			_ = os.Remove(path)
+			continue
+		}
+
+		tx := &TxQueueMeta{
+			raw:       entry.Tx,
+			hash:      hash.NewFromBytes(entry.Tx),
+			firstSeen: time.Unix(entry.Timestamp, 0),
+		}
+		if file.Name() != tx.Hash().String()+journalEntrySuffix || time.Since(tx.FirstSeen()) > maxAge {
+			_ = os.Remove(path)
+			continue
+		}
+
+		j.txs[tx.Hash()] = struct{}{}
+		txs = append(txs, tx)
+	}
+
+	// Preserve the original submission order.
+	slices.SortStableFunc(txs, func(a, b *TxQueueMeta) int {
+		return a.FirstSeen().Compare(b.FirstSeen())
+	})
+
+	return txs, nil
+}
+
+// size returns the number of transactions in the journal.
+func (j *localTxJournal) size() int {
+	j.l.Lock()
+	defer j.l.Unlock()
+	return len(j.txs)
+}
+
+func loadJournalEntry(path string, entry *journalEntry) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return cbor.Unmarshal(data, entry)
+}
+
+func openLocalTxJournal(dataDir string) (*localTxJournal, error) {
+	dir := filepath.Join(dataDir, localTxJournalDir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("txpool: failed to create journal directory: %w", err)
+	}
+
+	return &localTxJournal{
+		logger: logging.GetLogger("runtime/txpool/journal"),
+		dir:    dir,
+		txs:    make(map[hash.Hash]struct{}),
+	}, nil
+}
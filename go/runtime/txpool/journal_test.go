@@ -0,0 +1,126 @@
+package txpool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool/config"
+)
+
+func TestLocalTxJournal(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	j, err := openLocalTxJournal(dataDir)
+	require.NoError(err, "openLocalTxJournal")
+
+	txs, err := j.load(time.Hour)
+	require.NoError(err, "load")
+	require.Empty(txs, "empty journal should not contain any transactions")
+
+	now := time.Now()
+	newTx := func(raw string, firstSeen time.Time) *TxQueueMeta {
+		return &TxQueueMeta{raw: []byte(raw), hash: hash.NewFromBytes([]byte(raw)), firstSeen: firstSeen}
+	}
+	txA := newTx("a", now.Add(-2*time.Minute))
+	txB := newTx("b", now.Add(-time.Minute))
+	txC := newTx("c", now.Add(-2*time.Hour))
+	for _, tx := range []*TxQueueMeta{txB, txA, txC} {
+		require.NoError(j.add(tx), "add")
+	}
+	require.Equal(3, j.size())
+
+	// Adding an existing transaction should be a no-op.
+	require.NoError(j.add(newTx("a", now)), "add existing")
+	require.Equal(3, j.size())
+
+	// Add some garbage to the journal directory.
+	err = os.WriteFile(filepath.Join(dataDir, localTxJournalDir, "tmp-garbage"), []byte("garbage"), 0o600)
+	require.NoError(err, "WriteFile")
+	err = os.WriteFile(filepath.Join(dataDir, localTxJournalDir, "malformed"+journalEntrySuffix), []byte("garbage"), 0o600)
+	require.NoError(err, "WriteFile")
+
+	// Reopen the journal and make sure non-expired transactions are loaded in order.
+	j, err = openLocalTxJournal(dataDir)
+	require.NoError(err, "openLocalTxJournal")
+	txs, err = j.load(time.Hour)
+	require.NoError(err, "load")
+	require.Len(txs, 2)
+	require.Equal(txA.Hash(), txs[0].Hash())
+	require.Equal(txA.Raw(), txs[0].Raw())
+	require.Equal(txA.FirstSeen().Unix(), txs[0].FirstSeen().Unix())
+	require.Equal(txB.Hash(), txs[1].Hash())
+	require.Equal(2, j.size())
+
+	// Expired and malformed entries should be removed.
+	files, err := os.ReadDir(filepath.Join(dataDir, localTxJournalDir))
+	require.NoError(err, "ReadDir")
+	require.Len(files, 2)
+
+	// Remove a transaction.
+	j.remove([]hash.Hash{txA.Hash(), txC.Hash()})
+	require.Equal(1, j.size())
+
+	j, err = openLocalTxJournal(dataDir)
+	require.NoError(err, "openLocalTxJournal")
+	txs, err = j.load(time.Hour)
+	require.NoError(err, "load")
+	require.Len(txs, 1)
+	require.Equal(txB.Hash(), txs[0].Hash())
+}
+
+func TestLocalTxJournalReplay(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	newPool := func() *txPool {
+		pool, err := New(common.Namespace{}, config.Config{
+			MaxPoolSize:            1,
+			MaxLastSeenCacheSize:   10,
+			MaxCheckTxBatchSize:    10,
+			PersistLocalTxs:        true,
+			PersistedLocalTxMaxAge: time.Hour,
+		}, dataDir, nil, nil, nil)
+		require.NoError(err, "New")
+		return pool.(*txPool)
+	}
+
+	// Submit a local transaction and simulate it passing checks.
+	pool := newPool()
+	rawTx := []byte("local tx")
+	err := pool.SubmitTxNoWait(rawTx, &TransactionMeta{Local: true})
+	require.NoError(err, "SubmitTxNoWait")
+	batch := pool.checkTxQueue.pop()
+	require.Len(batch, 1)
+	err = pool.localQueue.OfferChecked(batch[0].TxQueueMeta, nil)
+	require.NoError(err, "OfferChecked")
+
+	// A failed resubmission should not remove the pending transaction from the journal.
+	err = pool.SubmitTxNoWait([]byte("remote tx"), &TransactionMeta{})
+	require.NoError(err, "SubmitTxNoWait")
+	err = pool.SubmitTxNoWait(rawTx, &TransactionMeta{Local: true})
+	require.Error(err, "SubmitTxNoWait should fail when the check queue is full")
+	require.Equal(1, pool.journal.size(), "pending transaction should remain in the journal")
+
+	// The pending transaction should be replayed after a restart.
+	pool.Stop()
+	pool = newPool()
+	require.NoError(pool.replayJournal(), "replayJournal")
+	batch = pool.checkTxQueue.pop()
+	require.Len(batch, 1, "pending transaction should be replayed")
+	require.Equal(rawTx, batch[0].Raw())
+	require.True(batch[0].dstQueue == pool.localQueue, "replayed transaction should be local")
+
+	// Transactions that left the pool should not be replayed.
+	pool.HandleTxsUsed([]hash.Hash{batch[0].Hash()})
+	pool.Stop()
+	pool = newPool()
+	require.NoError(pool.replayJournal(), "replayJournal")
+	require.Zero(pool.checkTxQueue.size(), "used transaction should not be replayed")
+}
@@ -78,9 +78,9 @@ type mainQueue struct {
 	inner *scheduleQueue
 }
 
-func newMainQueue(capacity int) *mainQueue {
+func newMainQueue(capacity int, maxTxsPerSender int, onEvict evictHandler) *mainQueue {
 	return &mainQueue{
-		inner: newScheduleQueue(capacity),
+		inner: newScheduleQueue(capacity, maxTxsPerSender, onEvict),
 	}
 }
 
@@ -49,6 +49,13 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	evictedTransactions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_txpool_evicted_transactions",
+			Help: "Number of transactions evicted from the schedule queue.",
+		},
+		[]string{"runtime", "reason"},
+	)
 	txpoolCollectors = []prometheus.Collector{
 		pendingCheckSize,
 		mainQueueSize,
@@ -56,6 +63,7 @@ var (
 		rimQueueSize,
 		rejectedTransactions,
 		acceptedTransactions,
+		evictedTransactions,
 	}
 
 	metricsOnce sync.Once
@@ -1,7 +1,9 @@
 package txpool
 
 import (
+	"cmp"
 	"errors"
+	"slices"
 	"sync"
 
 	"github.com/google/btree"
@@ -12,8 +14,24 @@ import (
 var (
 	ErrReplacementTxPriorityTooLow = errors.New("txpool: replacement tx priority too low")
 	ErrQueueFull                   = errors.New("txpool: schedule queue is full")
+	ErrSenderLimitReached          = errors.New("txpool: sender transaction limit reached")
 )
 
+// evictionReason is the reason why a transaction has been evicted from the queue.
+type evictionReason string
+
+const (
+	// evictionReasonQueueFull means that the transaction has been evicted to make room for a
+	// higher priority transaction as the queue is full.
+	evictionReasonQueueFull = evictionReason("queue_full")
+	// evictionReasonSenderLimit means that the transaction has been evicted to make room for a
+	// higher priority transaction from the same sender as the sender's limit has been reached.
+	evictionReasonSenderLimit = evictionReason("sender_limit")
+)
+
+// evictHandler is a function that gets called when a transaction is evicted from the queue.
+type evictHandler func(tx *MainQueueTransaction, reason evictionReason)
+
 // priorityLessFunc is a comparison function for ordering transactions by priority.
 func priorityLessFunc(tx, tx2 *MainQueueTransaction) bool {
 	switch {
@@ -39,47 +57,133 @@ type scheduleQueue struct {
 	l sync.Mutex
 
 	all        map[hash.Hash]*MainQueueTransaction
-	bySender   map[string]*MainQueueTransaction
+	bySender   map[string]map[uint64]*MainQueueTransaction
 	byPriority *btree.BTreeG[*MainQueueTransaction]
 
-	capacity int
+	capacity        int
+	maxTxsPerSender int
+	onEvict         evictHandler
 }
 
 func (sq *scheduleQueue) add(tx *MainQueueTransaction) error {
 	sq.l.Lock()
 	defer sq.l.Unlock()
 
-	// If a transaction from the same sender already exists, we accept a new transaction only if it
-	// has a higher priority or if the old transaction is no longer valid based on sequence numbers.
-	if etx, exists := sq.bySender[tx.sender]; exists {
-		if etx.senderSeq >= tx.senderStateSeq && tx.priority <= etx.priority {
+	// Remove any transactions from the same sender that are no longer valid based on sequence
+	// numbers.
+	for _, etx := range sq.bySender[tx.sender] {
+		if etx.senderSeq < tx.senderStateSeq {
+			sq.removeLocked(etx)
+		}
+	}
+
+	// If a transaction with the same sequence number from the same sender already exists, we
+	// accept a new transaction only if it has a higher priority.
+	if etx, exists := sq.bySender[tx.sender][tx.senderSeq]; exists {
+		if tx.priority <= etx.priority {
 			return ErrReplacementTxPriorityTooLow
 		}
 
-		// Remove any existing transaction.
+		// Remove the existing transaction.
 		sq.removeLocked(etx)
 	}
 
-	// If the queue is full, we accept a new transaction only if it has a higher priority.
+	// If the sender has reached its limit, we only evict the transaction at the tail of the
+	// sender's sequence as evicting any other transaction would leave a gap in the sequence.
+	if senderTxs := sq.bySender[tx.sender]; len(senderTxs) >= sq.maxTxsPerSender {
+		etx := sq.senderTailLocked(tx.sender)
+		switch {
+		case sq.maxTxsPerSender == 1:
+			// Only a single transaction is kept, accept a new one only if it has a higher priority.
+			if tx.priority <= etx.priority {
+				return ErrSenderLimitReached
+			}
+		case tx.senderSeq > etx.senderSeq:
+			return ErrSenderLimitReached
+		}
+		sq.evictLocked(etx, evictionReasonSenderLimit)
+	}
+
+	// If the queue is full, we accept a new transaction only if it has a higher priority than the
+	// lowest priority transaction at the tail of its sender's sequence.
 	if len(sq.all) >= sq.capacity {
 		// Attempt eviction.
-		etx, _ := sq.byPriority.Min()
+		etx := sq.lowestPriorityTailLocked()
 		if tx.priority <= etx.priority {
 			return ErrQueueFull
 		}
-		sq.removeLocked(etx)
+		if etx.sender == tx.sender && tx.senderSeq > etx.senderSeq {
+			// The new transaction would be queued after a gap in the sender's sequence.
+			return ErrQueueFull
+		}
+		sq.evictLocked(etx, evictionReasonQueueFull)
 	}
 
 	sq.all[tx.Hash()] = tx
-	sq.bySender[tx.sender] = tx
+	if sq.bySender[tx.sender] == nil {
+		sq.bySender[tx.sender] = make(map[uint64]*MainQueueTransaction)
+	}
+	sq.bySender[tx.sender][tx.senderSeq] = tx
 	sq.byPriority.ReplaceOrInsert(tx)
 
 	return nil
 }
 
+// senderTxsLocked returns the queued transactions of the given sender, ordered by their sequence
+// numbers.
+func (sq *scheduleQueue) senderTxsLocked(sender string) []*MainQueueTransaction {
+	txs := make([]*MainQueueTransaction, 0, len(sq.bySender[sender]))
+	for _, tx := range sq.bySender[sender] {
+		txs = append(txs, tx)
+	}
+	slices.SortFunc(txs, func(a, b *MainQueueTransaction) int {
+		return cmp.Compare(a.senderSeq, b.senderSeq)
+	})
+	return txs
+}
+
+// senderTailLocked returns the queued transaction of the given sender with the highest sequence
+// number.
+func (sq *scheduleQueue) senderTailLocked(sender string) *MainQueueTransaction {
+	var tail *MainQueueTransaction
+	for _, tx := range sq.bySender[sender] {
+		if tail == nil || tx.senderSeq > tail.senderSeq {
+			tail = tx
+		}
+	}
+	return tail
+}
+
+// lowestPriorityTailLocked returns the lowest priority transaction that is at the tail of its
+// sender's sequence.
+func (sq *scheduleQueue) lowestPriorityTailLocked() *MainQueueTransaction {
+	var etx *MainQueueTransaction
+	sq.byPriority.Ascend(func(tx *MainQueueTransaction) bool {
+		if sq.senderTailLocked(tx.sender) != tx {
+			return true
+		}
+		etx = tx
+		return false
+	})
+	return etx
+}
+
+func (sq *scheduleQueue) evictLocked(tx *MainQueueTransaction, reason evictionReason) {
+	sq.removeLocked(tx)
+
+	if sq.onEvict != nil {
+		sq.onEvict(tx, reason)
+	}
+}
+
 func (sq *scheduleQueue) removeLocked(tx *MainQueueTransaction) {
 	delete(sq.all, tx.Hash())
-	delete(sq.bySender, tx.sender)
+	if senderTxs := sq.bySender[tx.sender]; senderTxs != nil {
+		delete(senderTxs, tx.senderSeq)
+		if len(senderTxs) == 0 {
+			delete(sq.bySender, tx.sender)
+		}
+	}
 	sq.byPriority.Delete(tx)
 }
 
@@ -114,6 +218,10 @@ func (sq *scheduleQueue) getPrioritizedBatch(offset *hash.Hash, limit uint32) []
 		offsetItem = offsetTx
 	}
 
+	if sq.maxTxsPerSender > 1 {
+		return sq.getSequencedBatchLocked(offsetItem, limit)
+	}
+
 	sq.byPriority.DescendLessOrEqual(offsetItem, func(tx *MainQueueTransaction) bool {
 		// Skip the offset item itself (if specified).
 		h := tx.Hash()
@@ -132,6 +240,48 @@ func (sq *scheduleQueue) getPrioritizedBatch(offset *hash.Hash, limit uint32) []
 	return batch
 }
 
+// getSequencedBatchLocked returns a batch of transactions ordered by priority where transactions
+// from the same sender are always ordered by their sequence numbers. A transaction is preceded by
+// all queued transactions of its sender with lower sequence numbers, regardless of their priority.
+//
+// If an offset item is given, only transactions following it in this order are returned.
+func (sq *scheduleQueue) getSequencedBatchLocked(offsetItem *MainQueueTransaction, limit uint32) []*MainQueueTransaction {
+	var batch []*MainQueueTransaction
+	seen := make(map[hash.Hash]struct{})
+	skipping := offsetItem != nil
+
+	sq.byPriority.Descend(func(tx *MainQueueTransaction) bool {
+		if _, ok := seen[tx.Hash()]; ok {
+			return true
+		}
+
+		for _, stx := range sq.senderTxsLocked(tx.sender) {
+			if stx.senderSeq > tx.senderSeq {
+				break
+			}
+			if _, ok := seen[stx.Hash()]; ok {
+				continue
+			}
+			seen[stx.Hash()] = struct{}{}
+
+			// Skip everything up to and including the offset item (if specified).
+			if skipping {
+				skipping = stx != offsetItem
+				continue
+			}
+
+			// Add the transaction to the batch.
+			batch = append(batch, stx)
+			if uint32(len(batch)) >= limit {
+				return false
+			}
+		}
+		return true
+	})
+
+	return batch
+}
+
 func (sq *scheduleQueue) getKnownBatch(batch []hash.Hash) ([]*MainQueueTransaction, map[hash.Hash]int) {
 	sq.l.Lock()
 	defer sq.l.Unlock()
@@ -172,15 +322,17 @@ func (sq *scheduleQueue) clear() {
 	defer sq.l.Unlock()
 
 	sq.all = make(map[hash.Hash]*MainQueueTransaction)
-	sq.bySender = make(map[string]*MainQueueTransaction)
+	sq.bySender = make(map[string]map[uint64]*MainQueueTransaction)
 	sq.byPriority.Clear(true)
 }
 
-func newScheduleQueue(capacity int) *scheduleQueue {
+func newScheduleQueue(capacity int, maxTxsPerSender int, onEvict evictHandler) *scheduleQueue {
 	return &scheduleQueue{
-		all:        make(map[hash.Hash]*MainQueueTransaction),
-		bySender:   make(map[string]*MainQueueTransaction),
-		byPriority: btree.NewG[*MainQueueTransaction](2, priorityLessFunc),
-		capacity:   capacity,
+		all:             make(map[hash.Hash]*MainQueueTransaction),
+		bySender:        make(map[string]map[uint64]*MainQueueTransaction),
+		byPriority:      btree.NewG[*MainQueueTransaction](2, priorityLessFunc),
+		capacity:        capacity,
+		maxTxsPerSender: max(maxTxsPerSender, 1),
+		onEvict:         onEvict,
 	}
 }
@@ -26,7 +26,7 @@ func newTestTransaction(data []byte, priority uint64) *MainQueueTransaction {
 func TestScheduleQueueBasic(t *testing.T) {
 	require := require.New(t)
 
-	queue := newScheduleQueue(51)
+	queue := newScheduleQueue(51, 1, nil)
 
 	tx := newTestTransaction([]byte("hello world"), 0)
 
@@ -68,7 +68,7 @@ func TestScheduleQueueBasic(t *testing.T) {
 func TestScheduleQueueRemoveTxBatch(t *testing.T) {
 	require := require.New(t)
 
-	queue := newScheduleQueue(51)
+	queue := newScheduleQueue(51, 1, nil)
 	queue.remove([]hash.Hash{})
 
 	for _, tx := range []*MainQueueTransaction{
@@ -99,7 +99,7 @@ func TestScheduleQueueRemoveTxBatch(t *testing.T) {
 func TestScheduleQueuePriority(t *testing.T) {
 	require := require.New(t)
 
-	queue := newScheduleQueue(3)
+	queue := newScheduleQueue(3, 1, nil)
 
 	txs := []*MainQueueTransaction{
 		newTestTransaction(
@@ -184,7 +184,7 @@ func TestScheduleQueueSender(t *testing.T) {
 		sender2 = "sender2"
 	)
 
-	queue := newScheduleQueue(10)
+	queue := newScheduleQueue(10, 1, nil)
 
 	tx := newTestTransaction([]byte("hello world s1 p0"), 0)
 	tx.sender = sender1
@@ -213,3 +213,118 @@ func TestScheduleQueueSender(t *testing.T) {
 	queue.remove([]hash.Hash{tx.Hash()})
 	require.Equal(0, queue.size())
 }
+
+func TestScheduleQueueSenderLimit(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		sender1 = "sender1"
+		sender2 = "sender2"
+	)
+
+	evicted := make(map[evictionReason][]*MainQueueTransaction)
+	queue := newScheduleQueue(4, 2, func(tx *MainQueueTransaction, reason evictionReason) {
+		evicted[reason] = append(evicted[reason], tx)
+	})
+
+	newSenderTx := func(sender string, seq uint64, priority uint64) *MainQueueTransaction {
+		tx := newTestTransaction([]byte(fmt.Sprintf("hello world %s %d p%d", sender, seq, priority)), priority)
+		tx.sender = sender
+		tx.senderSeq = seq
+		return tx
+	}
+
+	s1tx0 := newSenderTx(sender1, 0, 5)
+	s1tx2 := newSenderTx(sender1, 2, 10)
+	require.NoError(queue.add(s1tx0), "Add")
+	require.NoError(queue.add(s1tx2), "Add")
+	require.Equal(2, queue.size())
+
+	// Sender limit reached, a transaction following the sender's sequence should not get queued
+	// regardless of its priority.
+	err := queue.add(newSenderTx(sender1, 3, 20))
+	require.Equal(ErrSenderLimitReached, err)
+	require.Equal(2, queue.size())
+
+	// Sender limit reached, a transaction filling a gap in the sender's sequence should evict the
+	// transaction at the tail of the sender's sequence.
+	s1tx1 := newSenderTx(sender1, 1, 1)
+	require.NoError(queue.add(s1tx1), "Add")
+	require.Equal(2, queue.size())
+	require.Equal([]*MainQueueTransaction{s1tx2}, evicted[evictionReasonSenderLimit])
+
+	// Other senders should not be affected by the limit.
+	s2tx0 := newSenderTx(sender2, 0, 2)
+	s2tx1 := newSenderTx(sender2, 1, 3)
+	require.NoError(queue.add(s2tx0), "Add")
+	require.NoError(queue.add(s2tx1), "Add")
+	require.Equal(4, queue.size())
+
+	// Queue is full, a higher priority transaction should evict the lowest priority transaction
+	// at the tail of its sender's sequence.
+	s3tx0 := newSenderTx("sender3", 0, 4)
+	require.NoError(queue.add(s3tx0), "Add")
+	require.Equal(4, queue.size())
+	require.Equal([]*MainQueueTransaction{s1tx1}, evicted[evictionReasonQueueFull])
+
+	// Transactions that are no longer valid based on sequence numbers should be removed without
+	// counting as evictions.
+	s1tx3 := newSenderTx(sender1, 3, 1)
+	s1tx3.senderStateSeq = 3
+	require.NoError(queue.add(s1tx3), "Add")
+	require.Equal(4, queue.size())
+	require.Len(evicted[evictionReasonSenderLimit], 1)
+	require.Len(evicted[evictionReasonQueueFull], 1)
+
+	// Queue is full, but evicting the transaction at the tail of the sender's sequence would leave
+	// a gap before the new transaction.
+	err = queue.add(newSenderTx(sender1, 5, 10))
+	require.Equal(ErrQueueFull, err)
+
+	batch := queue.getPrioritizedBatch(nil, 10)
+	require.EqualValues([]*MainQueueTransaction{s3tx0, s2tx0, s2tx1, s1tx3}, batch)
+}
+
+func TestScheduleQueueSenderOrder(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		sender1 = "sender1"
+		sender2 = "sender2"
+	)
+
+	queue := newScheduleQueue(10, 3, nil)
+
+	newSenderTx := func(sender string, seq uint64, priority uint64) *MainQueueTransaction {
+		tx := newTestTransaction([]byte(fmt.Sprintf("hello world %s %d p%d", sender, seq, priority)), priority)
+		tx.sender = sender
+		tx.senderSeq = seq
+		return tx
+	}
+
+	s1tx0 := newSenderTx(sender1, 0, 1)
+	s1tx1 := newSenderTx(sender1, 1, 30)
+	s1tx2 := newSenderTx(sender1, 2, 5)
+	s2tx0 := newSenderTx(sender2, 0, 20)
+	s2tx1 := newSenderTx(sender2, 1, 10)
+	for _, tx := range []*MainQueueTransaction{s1tx2, s2tx1, s1tx1, s2tx0, s1tx0} {
+		require.NoError(queue.add(tx), "Add")
+	}
+
+	// Transactions from the same sender should be ordered by their sequence numbers.
+	batch := queue.getPrioritizedBatch(nil, 10)
+	require.EqualValues([]*MainQueueTransaction{s1tx0, s1tx1, s2tx0, s2tx1, s1tx2}, batch)
+
+	// Batches should be limited.
+	batch = queue.getPrioritizedBatch(nil, 3)
+	require.EqualValues([]*MainQueueTransaction{s1tx0, s1tx1, s2tx0}, batch)
+
+	// Offsets should follow the same order.
+	offsetTx := s1tx1.Hash()
+	batch = queue.getPrioritizedBatch(&offsetTx, 2)
+	require.EqualValues([]*MainQueueTransaction{s2tx0, s2tx1}, batch)
+
+	offsetTx = s2tx1.Hash()
+	batch = queue.getPrioritizedBatch(&offsetTx, 10)
+	require.EqualValues([]*MainQueueTransaction{s1tx2}, batch)
+}
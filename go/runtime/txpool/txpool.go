@@ -158,6 +158,9 @@ type txPool struct {
 	txPublisher TransactionPublisher
 	history     history.History
 
+	// journal is the local transaction journal (if enabled).
+	journal *localTxJournal
+
 	// seenCache maps from transaction hashes to time.Time that specifies when the transaction was
 	// last published.
 	seenCache *lru.Cache
@@ -188,6 +191,10 @@ type txPool struct {
 }
 
 func (t *txPool) Start() error {
+	if err := t.replayJournal(); err != nil {
+		return err
+	}
+
 	go t.checkWorker()
 	go t.republishWorker()
 	go t.recheckWorker()
@@ -250,7 +257,69 @@ func (t *txPool) submitTx(rawTx []byte, meta *TransactionMeta, notifyCh chan *pr
 		pct.dstQueue = t.mainQueue
 	}
 
-	return t.addToCheckQueue(pct)
+	// Persist local transactions so they survive restarts.
+	persist := t.journal != nil && pct.dstQueue == t.localQueue
+	if persist {
+		if err := t.journal.add(tx); err != nil {
+			t.logger.Warn("failed to persist local transaction",
+				"tx_hash", tx.Hash(),
+				"err", err,
+			)
+		}
+	}
+
+	if err := t.addToCheckQueue(pct); err != nil {
+		if persist {
+			t.removeFromJournal([]hash.Hash{tx.Hash()})
+		}
+		return err
+	}
+	return nil
+}
+
+// removeFromJournal removes the given local transactions from the journal unless they are still
+// pending in the pool, e.g., when only a resubmitted duplicate of a pending transaction failed.
+func (t *txPool) removeFromJournal(hashes []hash.Hash) {
+	if t.journal == nil {
+		return
+	}
+
+	removed := make([]hash.Hash, 0, len(hashes))
+	for _, h := range hashes {
+		if t.localQueue.GetTxByHash(h) != nil || t.checkTxQueue.contains(h) {
+			continue
+		}
+		removed = append(removed, h)
+	}
+	t.journal.remove(removed)
+}
+
+// replayJournal resubmits any persisted local transactions for checks.
+func (t *txPool) replayJournal() error {
+	if t.journal == nil {
+		return nil
+	}
+
+	txs, err := t.journal.load(t.cfg.PersistedLocalTxMaxAge)
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		if err = t.SubmitTxNoWait(tx.Raw(), &TransactionMeta{Local: true}); err != nil {
+			t.logger.Warn("failed to replay persisted local transaction",
+				"tx_hash", tx.Hash(),
+				"err", err,
+			)
+			t.removeFromJournal([]hash.Hash{tx.Hash()})
+		}
+	}
+
+	t.logger.Info("replayed persisted local transactions",
+		"num_txs", len(txs),
+	)
+
+	return nil
 }
 
 func (t *txPool) addToCheckQueue(pct *PendingCheckTransaction) error {
@@ -352,6 +421,9 @@ func (t *txPool) HandleTxsUsed(hashes []hash.Hash) {
 	for _, q := range t.usableSources {
 		q.HandleTxsUsed(hashes)
 	}
+	if t.journal != nil {
+		t.journal.remove(hashes)
+	}
 
 	mainQueueSize.With(t.getMetricLabels()).Set(float64(t.mainQueue.inner.size()))
 	localQueueSize.With(t.getMetricLabels()).Set(float64(t.localQueue.size()))
@@ -531,6 +603,7 @@ func (t *txPool) checkTxBatch(ctx context.Context) error {
 	newTxs := make([]*PendingCheckTransaction, 0, len(results))
 	goodPcts := make([]*PendingCheckTransaction, 0, len(results))
 	batchIndices := make([]int, 0, len(results))
+	var failedLocalTxs []hash.Hash
 	for i, res := range results {
 		if !res.IsSuccess() {
 			rejectedTransactions.With(t.getMetricLabels()).Inc()
@@ -545,6 +618,11 @@ func (t *txPool) checkTxBatch(ctx context.Context) error {
 			// become valid in the future.
 			t.seenCache.Remove(batch[i].Hash())
 
+			// Failed local transactions should not be replayed after a restart.
+			if batch[i].dstQueue == t.localQueue {
+				failedLocalTxs = append(failedLocalTxs, batch[i].Hash())
+			}
+
 			// We won't be sending this tx on to its destination queue.
 			notifySubmitter(i)
 			continue
@@ -570,6 +648,10 @@ func (t *txPool) checkTxBatch(ctx context.Context) error {
 		t.checkTxCh.In() <- struct{}{}
 	}
 
+	// Remove failed local transactions from the journal once the checked transactions have been
+	// queued, so that entries of pending transactions are kept when only a duplicate failed.
+	defer t.removeFromJournal(failedLocalTxs)
+
 	if len(goodPcts) == 0 {
 		return nil
 	}
@@ -634,6 +716,23 @@ func (t *txPool) checkTxBatch(ctx context.Context) error {
 	return nil
 }
 
+func (t *txPool) handleEvictedTx(tx *MainQueueTransaction, reason evictionReason) {
+	labels := t.getMetricLabels()
+	labels["reason"] = string(reason)
+	evictedTransactions.With(labels).Inc()
+
+	t.logger.Debug("evicted transaction from the schedule queue",
+		"tx_hash", tx.Hash(),
+		"sender", tx.sender,
+		"priority", tx.priority,
+		"reason", reason,
+	)
+
+	// Make sure an evicted transaction is removed from the seen cache so that it can be
+	// resubmitted later.
+	t.seenCache.Remove(tx.Hash())
+}
+
 func (t *txPool) ensureInitialized() error {
 	select {
 	case <-t.stopCh:
@@ -875,15 +974,27 @@ func (t *txPool) recheck() {
 }
 
 // New creates a new transaction pool instance.
+//
+// The data directory is used to persist transactions submitted by local clients in case this
+// is enabled in the configuration.
 func New(
 	runtimeID common.Namespace,
 	cfg config.Config,
+	dataDir string,
 	runtime host.RichRuntime,
 	history history.History,
 	txPublisher TransactionPublisher,
-) TransactionPool {
+) (TransactionPool, error) {
 	initMetrics()
 
+	var journal *localTxJournal
+	if cfg.PersistLocalTxs {
+		var err error
+		if journal, err = openLocalTxJournal(dataDir); err != nil {
+			return nil, err
+		}
+	}
+
 	seenCache := lru.New(lru.Capacity(cfg.MaxLastSeenCacheSize, false))
 
 	// The transaction check queue should be 10% larger than the transaction pool to allow for some
@@ -892,9 +1003,13 @@ func New(
 
 	rq := newRimQueue()
 	lq := newLocalQueue()
-	mq := newMainQueue(int(cfg.MaxPoolSize))
 
-	return &txPool{
+	var t *txPool
+	mq := newMainQueue(int(cfg.MaxPoolSize), int(cfg.MaxTxsPerSender), func(tx *MainQueueTransaction, reason evictionReason) {
+		t.handleEvictedTx(tx, reason)
+	})
+
+	t = &txPool{
 		logger:               logging.GetLogger("runtime/txpool"),
 		stopCh:               make(chan struct{}),
 		quitCh:               make(chan struct{}),
@@ -903,6 +1018,7 @@ func New(
 		cfg:                  cfg,
 		runtime:              runtime,
 		history:              history,
+		journal:              journal,
 		txPublisher:          txPublisher,
 		seenCache:            seenCache,
 		checkTxQueue:         newCheckTxQueue(maxCheckTxQueueSize, int(cfg.MaxCheckTxBatchSize)),
@@ -918,4 +1034,5 @@ func New(
 		proposedTxs:          make(map[hash.Hash]*TxQueueMeta),
 		republishCh:          channels.NewRingChannel(1),
 	}
+	return t, nil
 }
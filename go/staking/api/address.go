@@ -6,9 +6,11 @@ import (
 	"sync"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/address"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/encoding/bech32"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 )
 
 var (
@@ -18,6 +20,8 @@ var (
 	AddressRuntimeV0Context = address.NewContext("oasis-core/address: runtime", 0)
 	// AddressModuleV0Context is the unique context for v0 module account addresses.
 	AddressModuleV0Context = address.NewContext("oasis-core/address: module", 0)
+	// AddressMultisigV0Context is the unique context for v0 multisig account addresses.
+	AddressMultisigV0Context = address.NewContext("oasis-core/address: multisig", 0)
 	// AddressBech32HRP is the unique human readable part of Bech32 encoded
 	// staking account addresses.
 	AddressBech32HRP = address.NewBech32HRP("oasis")
@@ -105,6 +109,11 @@ func NewModuleAddress(module string, kind string) (a Address) {
 	return (Address)(address.NewAddress(AddressModuleV0Context, data))
 }
 
+// NewMultisigAddress creates a new multisig account address for the given multisig configuration.
+func NewMultisigAddress(cfg *transaction.MultisigConfig) (a Address) {
+	return (Address)(address.NewAddress(AddressMultisigV0Context, cbor.Marshal(cfg)))
+}
+
 // NewReservedAddress creates a new reserved address from the given public key
 // or panics.
 // NOTE: The given public key is also blacklisted.
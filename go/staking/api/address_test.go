@@ -8,6 +8,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 )
 
 func TestAddressDeserialization(t *testing.T) {
@@ -87,6 +88,27 @@ func TestModuleAddress(t *testing.T) {
 	require.NotEqualValues(addr2, addr3, "module addresses for different modules should be different")
 }
 
+func TestMultisigAddress(t *testing.T) {
+	require := require.New(t)
+
+	pk1 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	pk2 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+
+	cfg1 := transaction.NewMultisigConfig(1, pk2, pk1)
+	addr1 := NewMultisigAddress(&cfg1)
+	require.True(addr1.IsValid(), "multisig address should be valid")
+
+	cfg2 := transaction.NewMultisigConfig(1, pk1, pk2)
+	require.EqualValues(addr1, NewMultisigAddress(&cfg2), "multisig address should not depend on signer order")
+
+	cfg3 := transaction.NewMultisigConfig(2, pk1, pk2)
+	require.NotEqualValues(addr1, NewMultisigAddress(&cfg3), "multisig addresses for different thresholds should be different")
+
+	cfg4 := transaction.NewMultisigConfig(1, pk1)
+	require.NotEqualValues(addr1, NewMultisigAddress(&cfg4), "multisig addresses for different signers should be different")
+	require.NotEqualValues(NewAddress(pk1), NewMultisigAddress(&cfg4), "multisig addresses should be separated from staking addresses")
+}
+
 func TestInternal(t *testing.T) {
 	for _, v := range []struct {
 		n       string
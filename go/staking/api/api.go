@@ -70,8 +70,14 @@ var (
 	// total supply value.
 	ErrAllowanceGreaterThanSupply = errors.New(ModuleName, 11, "staking: allowance greater than total supply")
 
+	// ErrVestingLocked is the error returned when an operation would spend
+	// tokens that are still locked by the account's vesting schedule.
+	ErrVestingLocked = errors.New(ModuleName, 12, "staking: amount is locked by vesting schedule")
+
 	// MethodTransfer is the method name for transfers.
 	MethodTransfer = transaction.NewMethodName(ModuleName, "Transfer", Transfer{})
+	// MethodTransferBatch is the method name for batched transfers.
+	MethodTransferBatch = transaction.NewMethodName(ModuleName, "TransferBatch", TransferBatch{})
 	// MethodBurn is the method name for burns.
 	MethodBurn = transaction.NewMethodName(ModuleName, "Burn", Burn{})
 	// MethodAddEscrow is the method name for escrows.
@@ -88,6 +94,7 @@ var (
 	// Methods is the list of all methods supported by the staking backend.
 	Methods = []transaction.MethodName{
 		MethodTransfer,
+		MethodTransferBatch,
 		MethodBurn,
 		MethodAddEscrow,
 		MethodReclaimEscrow,
@@ -97,6 +104,7 @@ var (
 	}
 
 	_ prettyprint.PrettyPrinter = (*Transfer)(nil)
+	_ prettyprint.PrettyPrinter = (*TransferBatch)(nil)
 	_ prettyprint.PrettyPrinter = (*Burn)(nil)
 	_ prettyprint.PrettyPrinter = (*Escrow)(nil)
 	_ prettyprint.PrettyPrinter = (*ReclaimEscrow)(nil)
@@ -447,6 +455,33 @@ func NewTransferTx(nonce uint64, fee *transaction.Fee, xfer *Transfer) *transact
 	return transaction.NewTransaction(nonce, fee, MethodTransfer, xfer)
 }
 
+// TransferBatch is a batch of stake transfers from the same source account
+// which are executed atomically.
+type TransferBatch struct {
+	Transfers []Transfer `json:"transfers"`
+}
+
+// PrettyPrint writes a pretty-printed representation of TransferBatch to the
+// given writer.
+func (tb TransferBatch) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sTransfers:\n", prefix)
+	for i, xfer := range tb.Transfers {
+		fmt.Fprintf(w, "%s  [%d]\n", prefix, i)
+		xfer.PrettyPrint(ctx, prefix+"    ", w)
+	}
+}
+
+// PrettyType returns a representation of TransferBatch that can be used for
+// pretty printing.
+func (tb TransferBatch) PrettyType() (interface{}, error) {
+	return tb, nil
+}
+
+// NewTransferBatchTx creates a new batched transfer transaction.
+func NewTransferBatchTx(nonce uint64, fee *transaction.Fee, batch *TransferBatch) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodTransferBatch, batch)
+}
+
 // Burn is a stake burn (destruction).
 type Burn struct {
 	Amount quantity.Quantity `json:"amount"`
@@ -1011,6 +1046,22 @@ type GeneralAccount struct {
 	// Hooks is the set of hooks that should be invoked when specific actions happen to override
 	// common behavior.
 	Hooks map[HookKind]HookDestination `json:"hooks,omitempty"`
+
+	// Vesting is an optional vesting schedule that locks part of the balance. It can only be
+	// configured in the genesis document.
+	Vesting *VestingSchedule `json:"vesting,omitempty"`
+}
+
+// LockedBalance returns the part of the general balance that is locked by the
+// vesting schedule at the given epoch.
+//
+// NOTE: Since locked tokens may be escrowed, the locked amount may exceed the
+// general balance.
+func (ga *GeneralAccount) LockedBalance(epoch beacon.EpochTime) *quantity.Quantity {
+	if ga.Vesting == nil {
+		return quantity.NewQuantity()
+	}
+	return ga.Vesting.LockedAmount(epoch)
 }
 
 // PrettyPrint writes a pretty-printed representation of GeneralAccount to the
@@ -1041,6 +1092,11 @@ func (ga GeneralAccount) PrettyPrint(ctx context.Context, prefix string, w io.Wr
 			fmt.Fprintf(w, "%s%s%s: %s\n", prefix, prefix, kind, dst.Module)
 		}
 	}
+
+	if ga.Vesting != nil {
+		fmt.Fprintf(w, "%sVesting:\n", prefix)
+		ga.Vesting.PrettyPrint(ctx, prefix+prefix, w)
+	}
 }
 
 // PrettyType returns a representation of GeneralAccount that can be used for
@@ -1260,6 +1316,10 @@ type ConsensusParameters struct { // nolint: maligned
 	// MaxAllowances is the maximum number of allowances an account can have. Zero means disabled.
 	MaxAllowances uint32 `json:"max_allowances,omitempty"`
 
+	// MaxBatchTransfers is the maximum number of transfers in a transfer batch. Zero means
+	// disabled.
+	MaxBatchTransfers uint32 `json:"max_batch_transfers,omitempty"`
+
 	// FeeSplitWeightPropose is the proportion of block fee portions that go to the proposer.
 	FeeSplitWeightPropose quantity.Quantity `json:"fee_split_weight_propose"`
 	// FeeSplitWeightVote is the proportion of block fee portions that go to the validator that votes.
@@ -1310,6 +1370,9 @@ type ConsensusParameterChanges struct {
 	// MaxAllowances is the new maximum number of allowances.
 	MaxAllowances *uint32 `json:"max_allowances,omitempty"`
 
+	// MaxBatchTransfers is the new maximum number of transfers in a transfer batch.
+	MaxBatchTransfers *uint32 `json:"max_batch_transfers,omitempty"`
+
 	// FeeSplitWeightPropose is the new propose fee split weight.
 	FeeSplitWeightPropose *quantity.Quantity `json:"fee_split_weight_propose"`
 	// FeeSplitWeightVote is the new vote fee split weight.
@@ -1358,6 +1421,9 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxAllowances != nil {
 		params.MaxAllowances = *c.MaxAllowances
 	}
+	if c.MaxBatchTransfers != nil {
+		params.MaxBatchTransfers = *c.MaxBatchTransfers
+	}
 	if c.FeeSplitWeightPropose != nil {
 		params.FeeSplitWeightPropose = *c.FeeSplitWeightPropose
 	}
@@ -1379,6 +1445,9 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 const (
 	// GasOpTransfer is the gas operation identifier for transfer.
 	GasOpTransfer transaction.Op = "transfer"
+	// GasOpTransferBatch is the gas operation identifier for each transfer in
+	// a batched transfer. If not configured, the cost of GasOpTransfer is used.
+	GasOpTransferBatch transaction.Op = "transfer_batch"
 	// GasOpBurn is the gas operation identifier for burn.
 	GasOpBurn transaction.Op = "burn"
 	// GasOpAddEscrow is the gas operation identifier for add escrow.
@@ -80,6 +80,7 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.DisableDelegation == nil &&
 		c.AllowEscrowMessages == nil &&
 		c.MaxAllowances == nil &&
+		c.MaxBatchTransfers == nil &&
 		c.FeeSplitWeightPropose == nil &&
 		c.FeeSplitWeightVote == nil &&
 		c.FeeSplitWeightNextPropose == nil &&
@@ -132,6 +133,15 @@ func SanityCheckAccount(
 		)
 	}
 
+	if vs := acct.General.Vesting; vs != nil {
+		if err := vs.ValidateBasic(); err != nil {
+			return fmt.Errorf("staking: sanity check failed: vesting schedule for account %s is invalid: %w", addr, err)
+		}
+		if vs.Amount.Cmp(totalSupply) > 0 {
+			return fmt.Errorf("staking: sanity check failed: account %s vesting amount is greater than total supply", addr)
+		}
+	}
+
 	for beneficiary, allowance := range acct.General.Allowances {
 		if !beneficiary.IsValid() {
 			return fmt.Errorf("staking: sanity check failed: account %s allowance has invalid beneficiary address %s", addr, beneficiary)
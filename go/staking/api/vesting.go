@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
+)
+
+var _ prettyprint.PrettyPrinter = (*VestingSchedule)(nil)
+
+// VestingSchedule is a time-locked vesting schedule attached to a general account.
+//
+// The whole vesting amount is locked until the cliff epoch. Afterwards, the amount is unlocked
+// linearly per epoch between the start and the end epochs so that it is fully unlocked at the end
+// epoch. Locked tokens cannot be transferred, burned, withdrawn or used to pay fees, but they can
+// be escrowed. Stake delegated by the account (either active or debonding) counts towards the
+// locked amount.
+//
+// NOTE: Vesting schedules can only be configured in the genesis document. There is currently no
+// transaction that would set or change the vesting schedule of an existing account.
+type VestingSchedule struct {
+	// Amount is the total amount of base units subject to vesting.
+	Amount quantity.Quantity `json:"amount"`
+	// Start is the epoch at which linear unlocking starts.
+	Start beacon.EpochTime `json:"start"`
+	// Cliff is the epoch before which no tokens are unlocked.
+	Cliff beacon.EpochTime `json:"cliff"`
+	// End is the epoch at which all tokens are unlocked.
+	End beacon.EpochTime `json:"end"`
+}
+
+// ValidateBasic performs basic vesting schedule validity checks.
+func (vs *VestingSchedule) ValidateBasic() error {
+	if !vs.Amount.IsValid() || vs.Amount.IsZero() {
+		return fmt.Errorf("invalid vesting amount")
+	}
+	if vs.Start >= vs.End {
+		return fmt.Errorf("vesting start epoch must be before end epoch")
+	}
+	if vs.Cliff < vs.Start || vs.Cliff > vs.End {
+		return fmt.Errorf("vesting cliff epoch must be between start and end epochs")
+	}
+	return nil
+}
+
+// LockedAmount returns the amount of base units that are still locked at the given epoch.
+func (vs *VestingSchedule) LockedAmount(epoch beacon.EpochTime) *quantity.Quantity {
+	switch {
+	case epoch < vs.Cliff:
+		return vs.Amount.Clone()
+	case epoch >= vs.End:
+		return quantity.NewQuantity()
+	default:
+	}
+
+	// Linearly unlock the amount between start and end epochs.
+	locked := vs.Amount.Clone()
+	_ = locked.Mul(quantity.NewFromUint64(uint64(vs.End - epoch)))
+	_ = locked.Quo(quantity.NewFromUint64(uint64(vs.End - vs.Start)))
+	return locked
+}
+
+// PrettyPrint writes a pretty-printed representation of VestingSchedule to the given writer.
+func (vs VestingSchedule) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sAmount: ", prefix)
+	token.PrettyPrintAmount(ctx, vs.Amount, w)
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%sStart:  epoch %d\n", prefix, vs.Start)
+	fmt.Fprintf(w, "%sCliff:  epoch %d\n", prefix, vs.Cliff)
+	fmt.Fprintf(w, "%sEnd:    epoch %d\n", prefix, vs.End)
+}
+
+// PrettyType returns a representation of VestingSchedule that can be used for pretty printing.
+func (vs VestingSchedule) PrettyType() (interface{}, error) {
+	return vs, nil
+}
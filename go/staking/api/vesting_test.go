@@ -0,0 +1,53 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestVestingSchedule(t *testing.T) {
+	require := require.New(t)
+
+	vs := VestingSchedule{
+		Amount: *quantity.NewFromUint64(1000),
+		Start:  10,
+		Cliff:  15,
+		End:    20,
+	}
+	require.NoError(vs.ValidateBasic(), "ValidateBasic")
+
+	for _, tc := range []struct {
+		epoch  beacon.EpochTime
+		locked uint64
+	}{
+		{0, 1000},
+		{10, 1000},
+		{14, 1000},
+		{15, 500},
+		{16, 400},
+		{19, 100},
+		{20, 0},
+		{100, 0},
+	} {
+		require.EqualValues(*quantity.NewFromUint64(tc.locked), *vs.LockedAmount(tc.epoch), "locked amount at epoch %d", tc.epoch)
+	}
+
+	var ga GeneralAccount
+	require.True(ga.LockedBalance(0).IsZero(), "account without vesting should have nothing locked")
+	ga.Vesting = &vs
+	require.EqualValues(*quantity.NewFromUint64(500), *ga.LockedBalance(15), "locked balance should be correct")
+
+	// Invalid schedules.
+	for _, invalid := range []VestingSchedule{
+		{Start: 10, Cliff: 15, End: 20},
+		{Amount: *quantity.NewFromUint64(1000), Start: 20, Cliff: 20, End: 20},
+		{Amount: *quantity.NewFromUint64(1000), Start: 10, Cliff: 5, End: 20},
+		{Amount: *quantity.NewFromUint64(1000), Start: 10, Cliff: 25, End: 20},
+	} {
+		require.Error(invalid.ValidateBasic(), "ValidateBasic should fail for invalid schedule")
+	}
+}
@@ -0,0 +1,274 @@
+package checkpoint
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+const (
+	// archiveVersion is the checkpoint archive format version.
+	archiveVersion = 1
+
+	archiveManifestFile = "manifest"
+	archiveChunksDir    = "chunks"
+
+	// maxArchiveManifestSize is the maximum size of the archive manifest.
+	maxArchiveManifestSize = 16 * 1024 * 1024
+)
+
+// archiveManifest is the manifest of a checkpoint archive.
+type archiveManifest struct {
+	// Version is the archive format version.
+	Version uint16 `json:"version"`
+	// Checkpoints are the archived checkpoints in restoration order.
+	Checkpoints []*Metadata `json:"checkpoints"`
+}
+
+func archiveChunkName(cpIdx int, chunkIdx int) string {
+	return archiveChunksDir + "/" + strconv.Itoa(cpIdx) + "/" + strconv.Itoa(chunkIdx)
+}
+
+// validateArchiveOrder makes sure that each delta checkpoint in the list is preceded by its base
+// unless the base root is already available in the given node database.
+func validateArchiveOrder(ndb db.NodeDB, cps []*Metadata) error {
+	seen := make(map[node.Root]bool)
+	for i, cp := range cps {
+		if i > 0 && cp.Root.Version < cps[i-1].Root.Version {
+			return fmt.Errorf("checkpoint %d is not ordered by version", i)
+		}
+		if cp.IsDelta() && !seen[*cp.Base] && (ndb == nil || !ndb.HasRoot(*cp.Base)) {
+			return fmt.Errorf("base of delta checkpoint %d is not available", i)
+		}
+		seen[cp.Root] = true
+	}
+	return nil
+}
+
+// validateArchiveRoots makes sure that all checkpoints in the list either have one of the trusted
+// roots or are (transitively) the base of such a checkpoint.
+func validateArchiveRoots(cps []*Metadata, trustedRoots []node.Root) error {
+	trusted := make(map[node.Root]bool, len(trustedRoots))
+	for _, root := range trustedRoots {
+		trusted[root] = true
+	}
+
+	// Walk the checkpoints in reverse restoration order so that each delta checkpoint marks its
+	// base as needed before the base is visited.
+	needed := make(map[node.Root]bool)
+	var found bool
+	for i := len(cps) - 1; i >= 0; i-- {
+		cp := cps[i]
+		switch {
+		case trusted[cp.Root]:
+			found = true
+		case needed[cp.Root]:
+		default:
+			return fmt.Errorf("%w: checkpoint %d (root %v) does not lead to a trusted root", ErrUntrustedRoot, i, cp.Root)
+		}
+		if cp.IsDelta() {
+			needed[*cp.Base] = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: no checkpoint for any of the trusted roots", ErrUntrustedRoot)
+	}
+	return nil
+}
+
+// ExportArchive writes the given checkpoints, together with all of their chunks, into a single
+// portable archive that can later be imported using ImportArchive.
+//
+// Checkpoints must be ordered by root version and any delta checkpoints must be preceded by
+// their base checkpoints.
+func ExportArchive(ctx context.Context, provider ChunkProvider, cps []*Metadata, w io.Writer) error {
+	if len(cps) == 0 {
+		return fmt.Errorf("checkpoint: no checkpoints to export")
+	}
+	if err := validateArchiveOrder(nil, cps); err != nil {
+		return fmt.Errorf("checkpoint: invalid checkpoints: %w", err)
+	}
+
+	tw := tar.NewWriter(w)
+
+	writeEntry := func(name string, data []byte) error {
+		if err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0o600,
+			Size: int64(len(data)),
+		}); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	manifest := archiveManifest{
+		Version:     archiveVersion,
+		Checkpoints: cps,
+	}
+	if err := writeEntry(archiveManifestFile, cbor.Marshal(manifest)); err != nil {
+		return fmt.Errorf("checkpoint: failed to write archive manifest: %w", err)
+	}
+
+	var buf bytes.Buffer
+	for cpIdx, cp := range cps {
+		for chunkIdx := range cp.Chunks {
+			chunk, err := cp.GetChunkMetadata(uint64(chunkIdx))
+			if err != nil {
+				return err
+			}
+
+			buf.Reset()
+			if err = provider.GetCheckpointChunk(ctx, chunk, &buf); err != nil {
+				return fmt.Errorf("checkpoint: failed to fetch chunk %d of checkpoint %d: %w", chunkIdx, cpIdx, err)
+			}
+			if err = writeEntry(archiveChunkName(cpIdx, chunkIdx), buf.Bytes()); err != nil {
+				return fmt.Errorf("checkpoint: failed to write chunk %d of checkpoint %d: %w", chunkIdx, cpIdx, err)
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("checkpoint: failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// ImportArchive restores all checkpoints from an archive previously created by ExportArchive
+// into the given node database and returns the metadata of the restored checkpoints.
+//
+// The archive itself is untrusted, so the caller must provide the trusted roots (e.g., as obtained
+// from verified consensus state) that the archive is expected to restore. Every checkpoint in the
+// archive must either have one of the trusted roots (including namespace, version and root type)
+// or be needed as a base of such a checkpoint, otherwise the import is rejected before anything
+// is restored.
+//
+// All chunks are fully verified against the checkpoint roots during restoration. Checkpoints of
+// the same root version are restored as part of a single multipart insert and the resulting roots
+// are finalized afterwards. Versions whose roots already exist in the node database are skipped.
+func ImportArchive(ctx context.Context, ndb db.NodeDB, r io.Reader, trustedRoots []node.Root) ([]*Metadata, error) {
+	if len(trustedRoots) == 0 {
+		return nil, fmt.Errorf("%w: no trusted roots given", ErrUntrustedRoot)
+	}
+
+	tr := tar.NewReader(r)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read manifest: %s", ErrArchiveMalformed, err)
+	}
+	if hdr.Name != archiveManifestFile || hdr.Size > maxArchiveManifestSize {
+		return nil, fmt.Errorf("%w: missing manifest", ErrArchiveMalformed)
+	}
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read manifest: %s", ErrArchiveMalformed, err)
+	}
+	var manifest archiveManifest
+	if err = cbor.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: corrupted manifest: %s", ErrArchiveMalformed, err)
+	}
+	if manifest.Version != archiveVersion {
+		return nil, fmt.Errorf("%w: unsupported archive version %d", ErrArchiveMalformed, manifest.Version)
+	}
+	cps := manifest.Checkpoints
+	if len(cps) == 0 {
+		return nil, fmt.Errorf("%w: no checkpoints", ErrArchiveMalformed)
+	}
+	if err = validateArchiveOrder(ndb, cps); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrArchiveMalformed, err)
+	}
+	if err = validateArchiveRoots(cps, trustedRoots); err != nil {
+		return nil, err
+	}
+
+	rs, err := NewRestorer(ndb)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restore checkpoints, grouped by root version.
+	for start := 0; start < len(cps); {
+		end := start + 1
+		for end < len(cps) && cps[end].Root.Version == cps[start].Root.Version {
+			end++
+		}
+
+		if err = importVersion(ctx, ndb, rs, tr, cps, start, end); err != nil {
+			return nil, fmt.Errorf("checkpoint: failed to import version %d: %w", cps[start].Root.Version, err)
+		}
+		start = end
+	}
+	return cps, nil
+}
+
+func importVersion(ctx context.Context, ndb db.NodeDB, rs Restorer, tr *tar.Reader, cps []*Metadata, start, end int) (err error) {
+	var roots []node.Root
+	skip := true
+	for _, cp := range cps[start:end] {
+		roots = append(roots, cp.Root)
+		skip = skip && ndb.HasRoot(cp.Root)
+	}
+	if latest, ok := ndb.GetLatestVersion(); skip && ok && latest >= roots[0].Version {
+		// All roots already exist, only skip the chunks.
+		for cpIdx := start; cpIdx < end; cpIdx++ {
+			for chunkIdx := range cps[cpIdx].Chunks {
+				if err = nextArchiveChunk(tr, cpIdx, chunkIdx); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err = ndb.StartMultipartInsert(roots[0].Version); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = rs.AbortRestore(ctx)
+			_ = ndb.AbortMultipartInsert()
+		}
+	}()
+
+	for cpIdx := start; cpIdx < end; cpIdx++ {
+		cp := cps[cpIdx]
+		if err = rs.StartRestore(ctx, cp); err != nil {
+			return err
+		}
+
+		var done bool
+		for chunkIdx := range cp.Chunks {
+			if err = nextArchiveChunk(tr, cpIdx, chunkIdx); err != nil {
+				return err
+			}
+			if done, err = rs.RestoreChunk(ctx, uint64(chunkIdx), tr); err != nil {
+				return fmt.Errorf("failed to restore chunk %d of checkpoint %d: %w", chunkIdx, cpIdx, err)
+			}
+		}
+		if !done {
+			return fmt.Errorf("checkpoint %d was not fully restored", cpIdx)
+		}
+	}
+
+	return ndb.Finalize(roots)
+}
+
+func nextArchiveChunk(tr *tar.Reader, cpIdx int, chunkIdx int) error {
+	hdr, err := tr.Next()
+	if err != nil {
+		return fmt.Errorf("%w: missing chunk %d of checkpoint %d: %s", ErrArchiveMalformed, chunkIdx, cpIdx, err)
+	}
+	if expected := archiveChunkName(cpIdx, chunkIdx); hdr.Name != expected {
+		return fmt.Errorf("%w: unexpected entry %s (expected %s)", ErrArchiveMalformed, hdr.Name, expected)
+	}
+	return nil
+}
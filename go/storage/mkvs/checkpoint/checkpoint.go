@@ -3,6 +3,7 @@ package checkpoint
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -13,6 +14,16 @@ import (
 
 const moduleName = "storage/mkvs/checkpoint"
 
+const (
+	// MinimumVersion is the minimum supported checkpoint version.
+	MinimumVersion = 1
+	// LatestVersion is the latest checkpoint version.
+	//
+	// Version 2 adds the chunk codec to checkpoint metadata. Version 1 checkpoints always use
+	// snappy-compressed chunks.
+	LatestVersion = 2
+)
+
 var (
 	// ErrCheckpointNotFound is the error when a checkpoint is not found.
 	ErrCheckpointNotFound = errors.New(moduleName, 1, "checkpoint: not found")
@@ -35,6 +46,19 @@ var (
 
 	// ErrChunkCorrupted is the error when a chunk is corrupted.
 	ErrChunkCorrupted = errors.New(moduleName, 7, "chunk: corrupted chunk")
+
+	// ErrBaseRootNotFound is the error when the base root of a delta checkpoint is not available.
+	ErrBaseRootNotFound = errors.New(moduleName, 8, "checkpoint: base root not found")
+
+	// ErrUnsupportedFormat is the error when a checkpoint has an unsupported version or codec.
+	ErrUnsupportedFormat = errors.New(moduleName, 9, "checkpoint: unsupported checkpoint format")
+
+	// ErrArchiveMalformed is the error when a checkpoint archive is malformed.
+	ErrArchiveMalformed = errors.New(moduleName, 10, "checkpoint: malformed archive")
+
+	// ErrUntrustedRoot is the error when a checkpoint archive contains checkpoints that do not
+	// lead to any of the trusted roots.
+	ErrUntrustedRoot = errors.New(moduleName, 11, "checkpoint: untrusted root")
 )
 
 // ChunkProvider is a chunk provider.
@@ -54,14 +78,42 @@ type GetCheckpointsRequest struct {
 	// RootVersion specifies an optional root version to limit the request to. If specified, only
 	// checkpoints for roots with the specific version will be considered.
 	RootVersion *uint64 `json:"root_version,omitempty"`
+
+	// IncludeDeltas specifies whether delta checkpoints should also be returned. Since delta
+	// checkpoints can only be restored on top of their base, they are omitted by default.
+	IncludeDeltas bool `json:"include_deltas,omitempty"`
+}
+
+// GetCheckpointsAllVersions returns a list of checkpoint metadata for all known checkpoints of
+// all supported checkpoint versions. The version field of the request is ignored.
+func GetCheckpointsAllVersions(ctx context.Context, provider ChunkProvider, request *GetCheckpointsRequest) ([]*Metadata, error) {
+	var cps []*Metadata
+	for version := uint16(MinimumVersion); version <= LatestVersion; version++ {
+		rq := *request
+		rq.Version = version
+
+		vcps, err := provider.GetCheckpoints(ctx, &rq)
+		if err != nil {
+			return nil, err
+		}
+		cps = append(cps, vcps...)
+	}
+	return cps, nil
 }
 
 // Creator is a checkpoint creator.
 type Creator interface {
 	ChunkProvider
 
-	// CreateCheckpoint creates a new checkpoint at the given root.
-	CreateCheckpoint(ctx context.Context, root node.Root, chunkSize uint64) (*Metadata, error)
+	// CreateCheckpoint creates a new checkpoint at the given root, compressing chunks using the
+	// given codec.
+	CreateCheckpoint(ctx context.Context, root node.Root, chunkSize uint64, codec ChunkCodec) (*Metadata, error)
+
+	// CreateDeltaCheckpoint creates a new delta checkpoint at the given root which only contains
+	// chunks covering the key ranges that changed since the given base root.
+	//
+	// A checkpoint for the base root must already exist.
+	CreateDeltaCheckpoint(ctx context.Context, root node.Root, base node.Root, chunkSize uint64, codec ChunkCodec) (*Metadata, error)
 
 	// GetCheckpoint retrieves checkpoint metadata for a specific checkpoint.
 	GetCheckpoint(ctx context.Context, version uint16, root node.Root) (*Metadata, error)
@@ -74,6 +126,9 @@ type Creator interface {
 type Restorer interface {
 	// StartRestore starts a checkpoint restoration process.
 	//
+	// In case the checkpoint is a delta checkpoint, its base root must already be present in the
+	// underlying node database.
+	//
 	// Multipart management in the underlying database is the responsibility of the caller.
 	StartRestore(ctx context.Context, checkpoint *Metadata) error
 
@@ -120,10 +175,11 @@ func NewCreateRestorer(creator Creator, restorer Restorer) CreateRestorer {
 
 // ChunkMetadata is chunk metadata.
 type ChunkMetadata struct {
-	Version uint16    `json:"version"`
-	Root    node.Root `json:"root"`
-	Index   uint64    `json:"index"`
-	Digest  hash.Hash `json:"digest"`
+	Version uint16     `json:"version"`
+	Root    node.Root  `json:"root"`
+	Index   uint64     `json:"index"`
+	Digest  hash.Hash  `json:"digest"`
+	Codec   ChunkCodec `json:"codec,omitempty"`
 }
 
 // Metadata is checkpoint metadata.
@@ -131,6 +187,32 @@ type Metadata struct {
 	Version uint16      `json:"version"`
 	Root    node.Root   `json:"root"`
 	Chunks  []hash.Hash `json:"chunks"`
+
+	// Codec is the codec used to compress chunks. It may only be set for version 2 checkpoints.
+	Codec ChunkCodec `json:"codec,omitempty"`
+
+	// Base is the root of the checkpoint that this checkpoint is a delta against. In case it is
+	// not set, this is a full checkpoint.
+	Base *node.Root `json:"base,omitempty"`
+}
+
+// IsDelta returns true iff the checkpoint is a delta checkpoint.
+func (m *Metadata) IsDelta() bool {
+	return m.Base != nil
+}
+
+// ValidateFormat checks whether the checkpoint version and codec are supported.
+func (m *Metadata) ValidateFormat() error {
+	switch {
+	case m.Version < MinimumVersion || m.Version > LatestVersion:
+		return fmt.Errorf("%w: version %d", ErrUnsupportedFormat, m.Version)
+	case m.Version == 1 && m.Codec != ChunkCodecSnappy:
+		return fmt.Errorf("%w: codec not supported in version 1", ErrUnsupportedFormat)
+	case !m.Codec.IsValid():
+		return fmt.Errorf("%w: codec %s", ErrUnsupportedFormat, m.Codec)
+	default:
+		return nil
+	}
 }
 
 // EncodedHash returns the encoded cryptographic hash of the checkpoint metadata.
@@ -149,5 +231,6 @@ func (m Metadata) GetChunkMetadata(idx uint64) (*ChunkMetadata, error) {
 		Root:    m.Root,
 		Index:   idx,
 		Digest:  m.Chunks[int(idx)],
+		Codec:   m.Codec,
 	}, nil
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -69,13 +70,13 @@ func testFileCheckpointCreator(t *testing.T, factory dbApi.Factory) {
 	require.NoError(err, "GetCheckpoints")
 	require.Len(cps, 0)
 
-	_, err = fc.GetCheckpoint(ctx, 1, root)
+	_, err = fc.GetCheckpoint(ctx, LatestVersion, root)
 	require.Error(err, "GetCheckpoint should fail with non-existent checkpoint")
 
 	// Create a checkpoint and check that it has been created correctly.
-	cp, err := fc.CreateCheckpoint(ctx, root, 16*1024)
+	cp, err := fc.CreateCheckpoint(ctx, root, 16*1024, ChunkCodecSnappy)
 	require.NoError(err, "CreateCheckpoint")
-	require.EqualValues(1, cp.Version, "version should be correct")
+	require.EqualValues(LatestVersion, cp.Version, "version should be correct")
 	require.EqualValues(root, cp.Root, "checkpoint root should be correct")
 	require.Len(cp.Chunks, 2, "there should be the correct number of chunks")
 
@@ -91,17 +92,17 @@ func testFileCheckpointCreator(t *testing.T, factory dbApi.Factory) {
 	require.EqualValues(expectedChunks, cp.Chunks, "chunk hashes should be correct")
 
 	// There should now be one checkpoint.
-	cps, err = fc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1})
+	cps, err = fc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: LatestVersion})
 	require.NoError(err, "GetCheckpoints")
 	require.Len(cps, 1, "there should be one checkpoint")
 	require.Equal(cp, cps[0], "checkpoint returned by GetCheckpoint should be correct")
 
-	gcp, err := fc.GetCheckpoint(ctx, 1, root)
+	gcp, err := fc.GetCheckpoint(ctx, LatestVersion, root)
 	require.NoError(err, "GetCheckpoint")
 	require.Equal(cp, gcp)
 
 	// Try re-creating the same checkpoint again and make sure we get the same metadata.
-	existingCp, err := fc.CreateCheckpoint(ctx, root, 16*1024)
+	existingCp, err := fc.CreateCheckpoint(ctx, root, 16*1024, ChunkCodecSnappy)
 	require.NoError(err, "CreateCheckpoint on an existing root should work")
 	require.Equal(cp, existingCp, "created checkpoint should be correct")
 
@@ -138,7 +139,7 @@ func testFileCheckpointCreator(t *testing.T, factory dbApi.Factory) {
 	require.True(errors.Is(err, ErrNoRestoreInProgress))
 
 	// Generate a bogus manifest which does not verify by corrupting chunk at index 1.
-	bogusCp, err := fc.GetCheckpoint(ctx, 1, root)
+	bogusCp, err := fc.GetCheckpoint(ctx, LatestVersion, root)
 	require.NoError(err, "GetCheckpoint")
 	require.Equal(cp, bogusCp)
 
@@ -234,11 +235,11 @@ func testFileCheckpointCreator(t *testing.T, factory dbApi.Factory) {
 	}
 
 	// Deleting a checkpoint should work.
-	err = fc.DeleteCheckpoint(ctx, 1, root)
+	err = fc.DeleteCheckpoint(ctx, LatestVersion, root)
 	require.NoError(err, "DeleteCheckpoint")
 
 	// There should now be no checkpoints.
-	cps, err = fc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1})
+	cps, err = fc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: LatestVersion})
 	require.NoError(err, "GetCheckpoints")
 	require.Len(cps, 0, "there should be no checkpoints")
 
@@ -246,11 +247,11 @@ func testFileCheckpointCreator(t *testing.T, factory dbApi.Factory) {
 	_, err = os.Stat(filepath.Join(dir, "checkpoints", strconv.FormatUint(root.Version, 10)))
 	require.True(os.IsNotExist(err), "there should be no empty directories after deletion")
 
-	_, err = fc.GetCheckpoint(ctx, 1, root)
+	_, err = fc.GetCheckpoint(ctx, LatestVersion, root)
 	require.Error(err, "GetCheckpoint should fail with non-existent checkpoint")
 
 	// Deleting a non-existent checkpoint should fail.
-	err = fc.DeleteCheckpoint(ctx, 1, root)
+	err = fc.DeleteCheckpoint(ctx, LatestVersion, root)
 	require.Error(err, "DeleteCheckpoint on a non-existent checkpoint should fail")
 
 	// Fetching a non-existent chunk should fail.
@@ -260,7 +261,7 @@ func testFileCheckpointCreator(t *testing.T, factory dbApi.Factory) {
 	// Create a checkpoint with unknown root.
 	invalidRoot := root
 	invalidRoot.Hash.FromBytes([]byte("mkvs checkpoint test invalid root"))
-	_, err = fc.CreateCheckpoint(ctx, invalidRoot, 16*1024)
+	_, err = fc.CreateCheckpoint(ctx, invalidRoot, 16*1024, ChunkCodecSnappy)
 	require.Error(err, "CreateCheckpoint should fail for invalid root")
 }
 
@@ -307,9 +308,9 @@ func testOversizedChunks(t *testing.T, factory dbApi.Factory) {
 	require.NoError(err, "NewFileCreator")
 
 	// Create a checkpoint and check that it has been created correctly.
-	cp, err := fc.CreateCheckpoint(ctx, root, 128)
+	cp, err := fc.CreateCheckpoint(ctx, root, 128, ChunkCodecSnappy)
 	require.NoError(err, "CreateCheckpoint")
-	require.EqualValues(1, cp.Version, "version should be correct")
+	require.EqualValues(LatestVersion, cp.Version, "version should be correct")
 	require.EqualValues(root, cp.Root, "checkpoint root should be correct")
 	require.Len(cp.Chunks, 100, "there should be the correct number of chunks")
 }
@@ -410,7 +411,7 @@ func testPruneGapAfterCheckpointRestore(t *testing.T, factory dbApi.Factory) {
 	require.NoError(err, "NewFileCreator")
 
 	// Create a checkpoint and check that it has been created correctly.
-	cp, err := fc.CreateCheckpoint(ctx, root, 16*1024)
+	cp, err := fc.CreateCheckpoint(ctx, root, 16*1024, ChunkCodecSnappy)
 	require.NoError(err, "CreateCheckpoint")
 
 	// Restore checkpoints in the second database.
@@ -491,3 +492,427 @@ func testPruneGapAfterCheckpointRestore(t *testing.T, factory dbApi.Factory) {
 	err = ndb2.Prune(checkpointRootVersion)
 	require.NoError(err, "Prune(%d)", checkpointRootVersion)
 }
+
+func TestDeltaCheckpoint(t *testing.T) {
+	dbTesting.TestMultipleBackends(t, db.Backends, testDeltaCheckpoint)
+}
+
+func testDeltaCheckpoint(t *testing.T, factory dbApi.Factory) {
+	require := require.New(t)
+
+	// Generate some data.
+	dir, err := os.MkdirTemp("", "mkvs.checkpoint")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	ndb, err := factory.New(&dbApi.Config{
+		DB:           filepath.Join(dir, "db"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb, node.RootTypeState)
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(strconv.Itoa(i)), []byte(strconv.Itoa(i)))
+		require.NoError(err, "Insert")
+	}
+
+	_, rootHash, err := tree.Commit(ctx, testNs, 1)
+	require.NoError(err, "Commit")
+	baseRoot := node.Root{
+		Namespace: testNs,
+		Version:   1,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+	err = ndb.Finalize([]node.Root{baseRoot})
+	require.NoError(err, "Finalize")
+	tree.Close()
+
+	// Modify a few keys in the next version.
+	tree = mkvs.NewWithRoot(nil, ndb, baseRoot)
+	err = tree.Insert(ctx, []byte("new key"), []byte("new value"))
+	require.NoError(err, "Insert")
+	err = tree.Insert(ctx, []byte("500"), []byte("updated value"))
+	require.NoError(err, "Insert")
+	err = tree.Remove(ctx, []byte("999"))
+	require.NoError(err, "Remove")
+
+	_, rootHash, err = tree.Commit(ctx, testNs, 2)
+	require.NoError(err, "Commit")
+	root := node.Root{
+		Namespace: testNs,
+		Version:   2,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+	err = ndb.Finalize([]node.Root{root})
+	require.NoError(err, "Finalize")
+	tree.Close()
+
+	fc, err := NewFileCreator(filepath.Join(dir, "checkpoints"), ndb)
+	require.NoError(err, "NewFileCreator")
+
+	// Creating a delta checkpoint without a base checkpoint should fail.
+	_, err = fc.CreateDeltaCheckpoint(ctx, root, baseRoot, 1024, ChunkCodecSnappy)
+	require.Error(err, "CreateDeltaCheckpoint should fail without a base checkpoint")
+
+	baseCp, err := fc.CreateCheckpoint(ctx, baseRoot, 1024, ChunkCodecSnappy)
+	require.NoError(err, "CreateCheckpoint")
+	require.False(baseCp.IsDelta())
+
+	cp, err := fc.CreateDeltaCheckpoint(ctx, root, baseRoot, 1024, ChunkCodecSnappy)
+	require.NoError(err, "CreateDeltaCheckpoint")
+	require.True(cp.IsDelta())
+	require.EqualValues(baseRoot, *cp.Base, "delta checkpoint base should be correct")
+	require.NotEmpty(cp.Chunks, "delta checkpoint should contain chunks")
+	require.Less(len(cp.Chunks), len(baseCp.Chunks), "delta checkpoint should contain fewer chunks")
+
+	// Delta checkpoints should only be returned when requested.
+	cps, err := fc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: LatestVersion})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 1, "only the full checkpoint should be returned")
+	require.Equal(baseCp, cps[0])
+	cps, err = fc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: LatestVersion, IncludeDeltas: true})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 2, "both checkpoints should be returned")
+
+	// Create a fresh node database to restore into.
+	ndb2, err := factory.New(&dbApi.Config{
+		DB:           filepath.Join(dir, "db2"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+
+	// Restoring a delta checkpoint without its base should fail.
+	rs, err := NewRestorer(ndb2)
+	require.NoError(err, "NewRestorer")
+	err = rs.StartRestore(ctx, cp)
+	require.ErrorIs(err, ErrBaseRootNotFound)
+
+	// Restoring an invalid chain should fail.
+	err = RestoreChain(ctx, ndb2, fc, []*Metadata{cp, baseCp})
+	require.Error(err, "RestoreChain should fail with an invalid chain")
+
+	err = RestoreChain(ctx, ndb2, fc, []*Metadata{baseCp, cp})
+	require.NoError(err, "RestoreChain")
+	require.True(ndb2.HasRoot(baseRoot), "base root should be restored")
+	require.True(ndb2.HasRoot(root), "delta root should be restored")
+
+	// Restoring the same chain again should skip restored checkpoints.
+	err = RestoreChain(ctx, ndb2, fc, []*Metadata{baseCp, cp})
+	require.NoError(err, "RestoreChain")
+
+	// Verify that everything has been restored.
+	tree = mkvs.NewWithRoot(nil, ndb2, root)
+	defer tree.Close()
+	for i := 0; i < 999; i++ {
+		expected := []byte(strconv.Itoa(i))
+		if i == 500 {
+			expected = []byte("updated value")
+		}
+
+		var value []byte
+		value, err = tree.Get(ctx, []byte(strconv.Itoa(i)))
+		require.NoError(err, "Get(%d)", i)
+		require.Equal(expected, value)
+	}
+	value, err := tree.Get(ctx, []byte("999"))
+	require.NoError(err, "Get")
+	require.Nil(value, "removed key should not exist")
+	value, err = tree.Get(ctx, []byte("new key"))
+	require.NoError(err, "Get")
+	require.Equal([]byte("new value"), value)
+}
+
+func TestChunkCodecs(t *testing.T) {
+	dbTesting.TestMultipleBackends(t, db.Backends, testChunkCodecs)
+}
+
+func testChunkCodecs(t *testing.T, factory dbApi.Factory) {
+	require := require.New(t)
+
+	// Generate some data.
+	dir, err := os.MkdirTemp("", "mkvs.checkpoint")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	ndb, err := factory.New(&dbApi.Config{
+		DB:           filepath.Join(dir, "db"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb, node.RootTypeState)
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(strconv.Itoa(i)), []byte(strconv.Itoa(i)))
+		require.NoError(err, "Insert")
+	}
+
+	_, rootHash, err := tree.Commit(ctx, testNs, 1)
+	require.NoError(err, "Commit")
+	root := node.Root{
+		Namespace: testNs,
+		Version:   1,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+	err = ndb.Finalize([]node.Root{root})
+	require.NoError(err, "Finalize")
+	tree.Close()
+
+	fc, err := NewFileCreator(filepath.Join(dir, "checkpoints"), ndb)
+	require.NoError(err, "NewFileCreator")
+
+	// Creating a checkpoint with an unknown codec should fail.
+	_, err = fc.CreateCheckpoint(ctx, root, 16*1024, ChunkCodec(42))
+	require.ErrorIs(err, ErrUnsupportedFormat)
+
+	cp, err := fc.CreateCheckpoint(ctx, root, 16*1024, ChunkCodecZstd)
+	require.NoError(err, "CreateCheckpoint")
+	require.EqualValues(LatestVersion, cp.Version, "version should be correct")
+	require.Equal(ChunkCodecZstd, cp.Codec, "codec should be correct")
+
+	chunk0, err := cp.GetChunkMetadata(0)
+	require.NoError(err, "GetChunkMetadata")
+	require.Equal(ChunkCodecZstd, chunk0.Codec, "chunk codec should be correct")
+
+	// Unsupported formats should be rejected.
+	ndb2, err := factory.New(&dbApi.Config{
+		DB:           filepath.Join(dir, "db2"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+
+	rs, err := NewRestorer(ndb2)
+	require.NoError(err, "NewRestorer")
+
+	for _, invalidCp := range []*Metadata{
+		{Version: LatestVersion + 1, Root: root, Chunks: cp.Chunks},
+		{Version: 1, Root: root, Chunks: cp.Chunks, Codec: ChunkCodecZstd},
+		{Version: LatestVersion, Root: root, Chunks: cp.Chunks, Codec: ChunkCodec(42)},
+	} {
+		err = rs.StartRestore(ctx, invalidCp)
+		require.ErrorIs(err, ErrUnsupportedFormat)
+	}
+
+	// Restoring a zstd-compressed checkpoint should work.
+	err = RestoreChain(ctx, ndb2, fc, []*Metadata{cp})
+	require.NoError(err, "RestoreChain")
+
+	tree = mkvs.NewWithRoot(nil, ndb2, root)
+	defer tree.Close()
+	for i := 0; i < 1000; i++ {
+		var value []byte
+		value, err = tree.Get(ctx, []byte(strconv.Itoa(i)))
+		require.NoError(err, "Get(%d)", i)
+		require.Equal([]byte(strconv.Itoa(i)), value)
+	}
+}
+
+func TestChunkCodecMaxSize(t *testing.T) {
+	require := require.New(t)
+
+	data := make([]byte, 64*1024)
+	for _, codec := range []ChunkCodec{ChunkCodecSnappy, ChunkCodecZstd} {
+		var buf bytes.Buffer
+		w, err := codec.newWriter(&buf)
+		require.NoError(err, "newWriter")
+		_, err = w.Write(data)
+		require.NoError(err, "Write")
+		require.NoError(w.Close(), "Close")
+		encoded := buf.Bytes()
+
+		// Decoding up to the maximum size should work.
+		r, err := codec.newReader(bytes.NewReader(encoded), uint64(len(data)))
+		require.NoError(err, "newReader")
+		decoded, err := io.ReadAll(r)
+		require.NoError(err, "ReadAll")
+		require.Equal(data, decoded)
+		r.Close()
+
+		// Decoding more than the maximum size should fail.
+		r, err = codec.newReader(bytes.NewReader(encoded), uint64(len(data)-1))
+		require.NoError(err, "newReader")
+		_, err = io.ReadAll(r)
+		require.Error(err, "decoding more than the maximum size should fail (codec: %s)", codec)
+		r.Close()
+	}
+
+	for _, tc := range []struct {
+		text  string
+		codec ChunkCodec
+	}{
+		{"", ChunkCodecSnappy},
+		{"snappy", ChunkCodecSnappy},
+		{"zstd", ChunkCodecZstd},
+	} {
+		codec, err := ParseChunkCodec(tc.text)
+		require.NoError(err, "ParseChunkCodec(%s)", tc.text)
+		require.Equal(tc.codec, codec)
+	}
+	_, err := ParseChunkCodec("gzip")
+	require.Error(err, "unknown codecs should be rejected")
+}
+
+func TestArchive(t *testing.T) {
+	dbTesting.TestMultipleBackends(t, db.Backends, testArchive)
+}
+
+func testArchive(t *testing.T, factory dbApi.Factory) {
+	require := require.New(t)
+
+	// Generate some data.
+	dir, err := os.MkdirTemp("", "mkvs.checkpoint")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	ndb, err := factory.New(&dbApi.Config{
+		DB:           filepath.Join(dir, "db"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb, node.RootTypeState)
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(strconv.Itoa(i)), []byte(strconv.Itoa(i)))
+		require.NoError(err, "Insert")
+	}
+
+	_, rootHash, err := tree.Commit(ctx, testNs, 1)
+	require.NoError(err, "Commit")
+	baseRoot := node.Root{
+		Namespace: testNs,
+		Version:   1,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+	err = ndb.Finalize([]node.Root{baseRoot})
+	require.NoError(err, "Finalize")
+	tree.Close()
+
+	tree = mkvs.NewWithRoot(nil, ndb, baseRoot)
+	err = tree.Insert(ctx, []byte("new key"), []byte("new value"))
+	require.NoError(err, "Insert")
+
+	_, rootHash, err = tree.Commit(ctx, testNs, 2)
+	require.NoError(err, "Commit")
+	root := node.Root{
+		Namespace: testNs,
+		Version:   2,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+	err = ndb.Finalize([]node.Root{root})
+	require.NoError(err, "Finalize")
+	tree.Close()
+
+	fc, err := NewFileCreator(filepath.Join(dir, "checkpoints"), ndb)
+	require.NoError(err, "NewFileCreator")
+	baseCp, err := fc.CreateCheckpoint(ctx, baseRoot, 1024, ChunkCodecSnappy)
+	require.NoError(err, "CreateCheckpoint")
+	cp, err := fc.CreateDeltaCheckpoint(ctx, root, baseRoot, 1024, ChunkCodecZstd)
+	require.NoError(err, "CreateDeltaCheckpoint")
+
+	// Exporting a delta checkpoint without its base should fail.
+	var buf bytes.Buffer
+	err = ExportArchive(ctx, fc, []*Metadata{cp}, &buf)
+	require.Error(err, "ExportArchive should fail without the base checkpoint")
+
+	buf.Reset()
+	err = ExportArchive(ctx, fc, []*Metadata{baseCp, cp}, &buf)
+	require.NoError(err, "ExportArchive")
+	archive := buf.Bytes()
+
+	newNodeDB := func(name string) dbApi.NodeDB {
+		var ndb dbApi.NodeDB
+		ndb, err = factory.New(&dbApi.Config{
+			DB:           filepath.Join(dir, name),
+			Namespace:    testNs,
+			MaxCacheSize: 16 * 1024 * 1024,
+		})
+		require.NoError(err, "New")
+		return ndb
+	}
+
+	// Importing an archive with corrupted chunks should fail.
+	var corrupted bytes.Buffer
+	err = ExportArchive(ctx, &corruptingChunkProvider{fc}, []*Metadata{baseCp, cp}, &corrupted)
+	require.NoError(err, "ExportArchive")
+	trustedRoots := []node.Root{root}
+	_, err = ImportArchive(ctx, newNodeDB("db-corrupted"), &corrupted, trustedRoots)
+	require.ErrorIs(err, ErrChunkCorrupted)
+
+	_, err = ImportArchive(ctx, newNodeDB("db-truncated"), bytes.NewReader(archive[:len(archive)/2]), trustedRoots)
+	require.Error(err, "ImportArchive should fail with a truncated archive")
+
+	// Importing an archive without a matching trusted root should fail.
+	ndb2 := newNodeDB("db2")
+	_, err = ImportArchive(ctx, ndb2, bytes.NewReader(archive), nil)
+	require.ErrorIs(err, ErrUntrustedRoot, "ImportArchive should fail without trusted roots")
+	otherRoot := root
+	otherRoot.Hash = hash.NewFromBytes([]byte("other root"))
+	_, err = ImportArchive(ctx, ndb2, bytes.NewReader(archive), []node.Root{otherRoot})
+	require.ErrorIs(err, ErrUntrustedRoot, "ImportArchive should fail with a mismatched root")
+	otherRoot = root
+	otherRoot.Version = 3
+	_, err = ImportArchive(ctx, ndb2, bytes.NewReader(archive), []node.Root{otherRoot})
+	require.ErrorIs(err, ErrUntrustedRoot, "ImportArchive should fail with a mismatched root version")
+	otherRoot = root
+	otherRoot.Namespace = common.NewTestNamespaceFromSeed([]byte("other namespace"), 0)
+	_, err = ImportArchive(ctx, ndb2, bytes.NewReader(archive), []node.Root{otherRoot})
+	require.ErrorIs(err, ErrUntrustedRoot, "ImportArchive should fail with a mismatched root namespace")
+	otherRoot = root
+	otherRoot.Type = node.RootTypeIO
+	_, err = ImportArchive(ctx, ndb2, bytes.NewReader(archive), []node.Root{otherRoot})
+	require.ErrorIs(err, ErrUntrustedRoot, "ImportArchive should fail with a mismatched root type")
+	_, err = ImportArchive(ctx, ndb2, bytes.NewReader(archive), []node.Root{baseRoot})
+	require.ErrorIs(err, ErrUntrustedRoot, "ImportArchive should fail with checkpoints not leading to a trusted root")
+	_, ok := ndb2.GetLatestVersion()
+	require.False(ok, "nothing should be restored on root mismatch")
+
+	cps, err := ImportArchive(ctx, ndb2, bytes.NewReader(archive), trustedRoots)
+	require.NoError(err, "ImportArchive")
+	require.Equal([]*Metadata{baseCp, cp}, cps)
+	require.True(ndb2.HasRoot(baseRoot), "base root should be restored")
+	require.True(ndb2.HasRoot(root), "root should be restored")
+
+	// Importing the same archive again should be a no-op.
+	_, err = ImportArchive(ctx, ndb2, bytes.NewReader(archive), trustedRoots)
+	require.NoError(err, "ImportArchive")
+
+	tree = mkvs.NewWithRoot(nil, ndb2, root)
+	defer tree.Close()
+	for i := 0; i < 1000; i++ {
+		var value []byte
+		value, err = tree.Get(ctx, []byte(strconv.Itoa(i)))
+		require.NoError(err, "Get(%d)", i)
+		require.Equal([]byte(strconv.Itoa(i)), value)
+	}
+	value, err := tree.Get(ctx, []byte("new key"))
+	require.NoError(err, "Get")
+	require.Equal([]byte("new value"), value)
+}
+
+type corruptingChunkProvider struct {
+	ChunkProvider
+}
+
+func (p *corruptingChunkProvider) GetCheckpointChunk(ctx context.Context, chunk *ChunkMetadata, w io.Writer) error {
+	var buf bytes.Buffer
+	if err := p.ChunkProvider.GetCheckpointChunk(ctx, chunk, &buf); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+	data[len(data)-1] ^= 0xff
+	_, err := w.Write(data)
+	return err
+}
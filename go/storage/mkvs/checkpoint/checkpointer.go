@@ -39,6 +39,13 @@ type CheckpointerConfig struct {
 	// RootsPerVersion is the number of roots per version.
 	RootsPerVersion int
 
+	// ChunkCodec is the codec used to compress checkpoint chunks.
+	ChunkCodec ChunkCodec
+
+	// MaxDeltaCheckpoints is the maximum number of consecutive delta checkpoints that can be
+	// created on top of a full checkpoint. If zero, only full checkpoints are created.
+	MaxDeltaCheckpoints uint64
+
 	// Parameters are the checkpoint creation parameters.
 	Parameters *CreationParameters
 	// GetParameters can be used instead of specifying Parameters to dynamically fetch the current
@@ -162,7 +169,7 @@ func (c *checkpointer) checkpoint(ctx context.Context, version uint64, params *C
 
 		// If there is an error, make sure to remove any created checkpoints.
 		for _, root := range roots {
-			_ = c.creator.DeleteCheckpoint(ctx, checkpointVersion, root)
+			_ = c.creator.DeleteCheckpoint(ctx, LatestVersion, root)
 		}
 	}()
 
@@ -172,12 +179,24 @@ func (c *checkpointer) checkpoint(ctx context.Context, version uint64, params *C
 	)
 
 	for _, root := range roots {
+		var base *node.Root
+		if base, err = c.deltaBase(ctx, root); err != nil {
+			return fmt.Errorf("checkpointer: failed to determine delta checkpoint base: %w", err)
+		}
+
 		c.logger.Info("creating new checkpoint",
 			"root", root,
+			"base", base,
 			"chunk_size", params.ChunkSize,
+			"codec", c.cfg.ChunkCodec,
 		)
 
-		_, err = c.creator.CreateCheckpoint(ctx, root, params.ChunkSize)
+		switch base {
+		case nil:
+			_, err = c.creator.CreateCheckpoint(ctx, root, params.ChunkSize, c.cfg.ChunkCodec)
+		default:
+			_, err = c.creator.CreateDeltaCheckpoint(ctx, root, *base, params.ChunkSize, c.cfg.ChunkCodec)
+		}
 		if err != nil {
 			c.logger.Error("failed to create checkpoint",
 				"root", root,
@@ -189,11 +208,54 @@ func (c *checkpointer) checkpoint(ctx context.Context, version uint64, params *C
 	return nil
 }
 
+// deltaBase returns the root that a delta checkpoint for the given root should be based on. In
+// case a full checkpoint should be created instead, nil is returned.
+func (c *checkpointer) deltaBase(ctx context.Context, root node.Root) (*node.Root, error) {
+	if c.cfg.MaxDeltaCheckpoints == 0 || db.PolicyForRoot(root).NoChildRoots {
+		return nil, nil
+	}
+
+	cps, err := GetCheckpointsAllVersions(ctx, c.creator, &GetCheckpointsRequest{
+		Namespace:     c.cfg.Namespace,
+		IncludeDeltas: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the latest earlier checkpoint for the same root type.
+	var latest *Metadata
+	cpsByRoot := make(map[node.Root]*Metadata)
+	for _, cp := range cps {
+		cpsByRoot[cp.Root] = cp
+
+		if cp.Root.Type != root.Type || cp.Root.Version >= root.Version {
+			continue
+		}
+		if latest == nil || cp.Root.Version > latest.Root.Version {
+			latest = cp
+		}
+	}
+	if latest == nil || !c.ndb.HasRoot(latest.Root) {
+		return nil, nil
+	}
+
+	// Make sure that the delta chain does not get too long.
+	var numDeltas uint64
+	for cp := latest; cp != nil && cp.IsDelta(); cp = cpsByRoot[*cp.Base] {
+		numDeltas++
+	}
+	if numDeltas >= c.cfg.MaxDeltaCheckpoints {
+		return nil, nil
+	}
+	return &latest.Root, nil
+}
+
 func (c *checkpointer) maybeCheckpoint(ctx context.Context, version uint64, params *CreationParameters) error {
 	// Get a list of all current checkpoints.
-	cps, err := c.creator.GetCheckpoints(ctx, &GetCheckpointsRequest{
-		Version:   checkpointVersion,
-		Namespace: c.cfg.Namespace,
+	cps, err := GetCheckpointsAllVersions(ctx, c.creator, &GetCheckpointsRequest{
+		Namespace:     c.cfg.Namespace,
+		IncludeDeltas: true,
 	})
 	if err != nil {
 		return fmt.Errorf("checkpointer: failed to get existing checkpoints: %w", err)
@@ -203,7 +265,10 @@ func (c *checkpointer) maybeCheckpoint(ctx context.Context, version uint64, para
 	var lastCheckpointVersion uint64
 	var cpVersions []uint64
 	cpsByVersion := make(map[uint64][]node.Root)
+	cpsByRoot := make(map[node.Root]*Metadata)
 	for _, cp := range cps {
+		cpsByRoot[cp.Root] = cp
+
 		if cpsByVersion[cp.Root.Version] == nil {
 			cpVersions = append(cpVersions, cp.Root.Version)
 		}
@@ -264,9 +329,22 @@ func (c *checkpointer) maybeCheckpoint(ctx context.Context, version uint64, para
 			"num_kept", params.NumKept,
 		)
 
+		// Make sure to keep any checkpoints that kept delta checkpoints are based on.
+		requiredVersions := make(map[uint64]bool)
+		for _, version := range cpVersions[len(cpVersions)-int(params.NumKept):] {
+			for _, root := range cpsByVersion[version] {
+				for cp := cpsByRoot[root]; cp != nil && cp.IsDelta(); cp = cpsByRoot[*cp.Base] {
+					requiredVersions[cp.Base.Version] = true
+				}
+			}
+		}
+
 		for _, version := range cpVersions[:len(cpVersions)-int(params.NumKept)] {
+			if requiredVersions[version] {
+				continue
+			}
 			for _, root := range cpsByVersion[version] {
-				if err = c.creator.DeleteCheckpoint(ctx, checkpointVersion, root); err != nil {
+				if err = c.creator.DeleteCheckpoint(ctx, cpsByRoot[root].Version, root); err != nil {
 					c.logger.Warn("failed to garbage collect checkpoint",
 						"root", root,
 						"err", err,
@@ -1,27 +1,46 @@
 package checkpoint
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 
-	"github.com/golang/snappy"
-
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
 )
 
+// deltaChunkHeader is the header of a delta checkpoint chunk. It specifies the key range that the
+// chunk replaces when applied on top of the base root.
+type deltaChunkHeader struct {
+	// Start is the first key of the range.
+	Start node.Key `json:"start"`
+	// End is the first key after the range. In case it is empty, the range is unbounded.
+	End node.Key `json:"end,omitempty"`
+}
+
+func (h *deltaChunkHeader) contains(key node.Key) bool {
+	if bytes.Compare(key, h.Start) < 0 {
+		return false
+	}
+	// Note that an unbounded end may decode as either a nil or an empty key.
+	return len(h.End) == 0 || bytes.Compare(key, h.End) < 0
+}
+
 func createChunk(
 	ctx context.Context,
 	tree mkvs.Tree,
 	root node.Root,
 	offset node.Key,
 	chunkSize uint64,
+	codec ChunkCodec,
+	delta bool,
 	w io.Writer,
 ) (
 	chunkHash hash.Hash,
@@ -60,8 +79,22 @@ func createChunk(
 	nextOffset = it.Key()
 
 	hb := hash.NewBuilder()
-	sw := snappy.NewBufferedWriter(io.MultiWriter(w, hb))
+	sw, err := codec.newWriter(io.MultiWriter(w, hb))
+	if err != nil {
+		err = fmt.Errorf("chunk: failed to create writer: %w", err)
+		return
+	}
 	enc := cbor.NewEncoder(sw)
+	if delta {
+		hdr := deltaChunkHeader{
+			Start: offset,
+			End:   nextOffset,
+		}
+		if err = enc.Encode(hdr); err != nil {
+			err = fmt.Errorf("chunk: failed to encode chunk header: %w", err)
+			return
+		}
+	}
 	for _, entry := range proof.Entries {
 		if err = enc.Encode(entry); err != nil {
 			err = fmt.Errorf("chunk: failed to encode chunk part: %w", err)
@@ -78,18 +111,147 @@ func createChunk(
 }
 
 func restoreChunk(ctx context.Context, ndb db.NodeDB, chunk *ChunkMetadata, r io.Reader) error {
+	ptr, err := verifyChunk(ctx, chunk, r, nil)
+	if err != nil {
+		return err
+	}
+
+	// Import chunk into the node database.
+	emptyRoot := node.Root{
+		Namespace: chunk.Root.Namespace,
+		Version:   chunk.Root.Version,
+		Type:      chunk.Root.Type,
+	}
+	emptyRoot.Hash.Empty()
+
+	batch, err := ndb.NewBatch(emptyRoot, chunk.Root.Version, true)
+	if err != nil {
+		return fmt.Errorf("chunk: failed to create batch: %w", err)
+	}
+	defer batch.Reset()
+
+	subtree := batch.MaybeStartSubtree(nil, 0, ptr)
+	if err = doRestoreChunk(ctx, batch, subtree, 0, ptr, nil); err != nil {
+		return fmt.Errorf("chunk: node import failed: %w", err)
+	}
+	if err = subtree.Commit(); err != nil {
+		return fmt.Errorf("chunk: node import failed: %w", err)
+	}
+	if err = batch.Commit(chunk.Root); err != nil {
+		return fmt.Errorf("chunk: node import failed: %w", err)
+	}
+
+	return nil
+}
+
+// verifyDeltaChunk verifies the given delta chunk and returns its header together with all of the
+// entries in the key range covered by the chunk.
+func verifyDeltaChunk(ctx context.Context, chunk *ChunkMetadata, r io.Reader) (*deltaChunkHeader, writelog.WriteLog, error) {
+	var hdr deltaChunkHeader
+	ptr, err := verifyChunk(ctx, chunk, r, &hdr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entries writelog.WriteLog
+	collectDeltaEntries(ptr, &hdr, &entries)
+	return &hdr, entries, nil
+}
+
+func collectDeltaEntries(ptr *node.Pointer, hdr *deltaChunkHeader, entries *writelog.WriteLog) {
+	if ptr == nil {
+		return
+	}
+
+	switch n := ptr.Node.(type) {
+	case *node.InternalNode:
+		// Traverse in key order so that the resulting entries are sorted.
+		collectDeltaEntries(n.LeafNode, hdr, entries)
+		collectDeltaEntries(n.Left, hdr, entries)
+		collectDeltaEntries(n.Right, hdr, entries)
+	case *node.LeafNode:
+		if hdr.contains(n.Key) {
+			*entries = append(*entries, writelog.LogEntry{Key: n.Key, Value: n.Value})
+		}
+	}
+}
+
+// applyDeltaChunk replaces the key range covered by a verified delta chunk in the given tree.
+func applyDeltaChunk(ctx context.Context, tree mkvs.Tree, hdr *deltaChunkHeader, entries writelog.WriteLog) error {
+	// Collect all existing keys in the range as they need to be removed.
+	var removed []node.Key
+	it := tree.NewIterator(ctx)
+	for it.Seek(hdr.Start); it.Valid() && hdr.contains(it.Key()); it.Next() {
+		removed = append(removed, it.Key())
+	}
+	err := it.Err()
+	it.Close()
+	if err != nil {
+		return fmt.Errorf("chunk: failed to iterate: %w", err)
+	}
+
+	for _, key := range removed {
+		if err = tree.Remove(ctx, key); err != nil {
+			return fmt.Errorf("chunk: failed to remove key: %w", err)
+		}
+	}
+	for _, entry := range entries {
+		if err = tree.Insert(ctx, entry.Key, entry.Value); err != nil {
+			return fmt.Errorf("chunk: failed to insert key: %w", err)
+		}
+	}
+	return nil
+}
+
+// verifyChunk reconstructs the proof contained in the given chunk and verifies it against the
+// chunk root. In case header is non-nil, the first item in the chunk is decoded into it.
+func verifyChunk(ctx context.Context, chunk *ChunkMetadata, r io.Reader, header any) (*node.Pointer, error) {
+	if !chunk.Codec.IsValid() {
+		return nil, fmt.Errorf("%w: codec %s", ErrUnsupportedFormat, chunk.Codec)
+	}
+
 	hb := hash.NewBuilder()
 	tr := io.TeeReader(r, hb)
-	sr := snappy.NewReader(tr)
+
+	// verifyIntegrity reads everything until EOF and verifies the overall chunk integrity.
+	verifyIntegrity := func() error {
+		_, _ = io.Copy(io.Discard, tr)
+
+		chunkHash := hb.Build()
+		if !chunk.Digest.Equal(&chunkHash) {
+			return fmt.Errorf("%w: digest incorrect (expected: %s got: %s)",
+				ErrChunkCorrupted,
+				chunk.Digest,
+				chunkHash,
+			)
+		}
+		return nil
+	}
+
+	sr, err := chunk.Codec.newReader(tr, MaxChunkSize)
+	if err != nil {
+		// Some decompressors already read from the chunk when being created.
+		if ierr := verifyIntegrity(); ierr != nil {
+			return nil, ierr
+		}
+		return nil, fmt.Errorf("%w: failed to create chunk reader: %s", ErrChunkProofVerificationFailed, err)
+	}
+	defer sr.Close()
 	dec := cbor.NewDecoder(sr)
 
-	// Reconstruct the proof.
 	var decodeErr error
+	if header != nil {
+		if err := dec.Decode(header); err != nil {
+			decodeErr = fmt.Errorf("failed to decode chunk header: %w", err)
+		}
+	}
+
+	// Reconstruct the proof.
 	var p syncer.Proof
 	p.V = checkpointProofsVersion
-	for {
+	for decodeErr == nil {
 		if ctx.Err() != nil {
-			return ctx.Err()
+			return nil, ctx.Err()
 		}
 
 		var entry []byte
@@ -99,9 +261,6 @@ func restoreChunk(ctx context.Context, ndb db.NodeDB, chunk *ChunkMetadata, r io
 			}
 
 			decodeErr = fmt.Errorf("failed to decode chunk: %w", err)
-
-			// Read everything until EOF so we can verify the overall chunk integrity.
-			_, _ = io.Copy(io.Discard, tr)
 			break
 		}
 
@@ -109,54 +268,25 @@ func restoreChunk(ctx context.Context, ndb db.NodeDB, chunk *ChunkMetadata, r io
 	}
 	p.UntrustedRoot = chunk.Root.Hash
 
-	// Verify overall chunk integrity.
-	chunkHash := hb.Build()
-	if !chunk.Digest.Equal(&chunkHash) {
-		return fmt.Errorf("%w: digest incorrect (expected: %s got: %s)",
-			ErrChunkCorrupted,
-			chunk.Digest,
-			chunkHash,
-		)
+	// Verify overall chunk integrity. The decompressor must be closed first as it may still be
+	// reading in the background.
+	_ = sr.Close()
+	if err = verifyIntegrity(); err != nil {
+		return nil, err
 	}
 
 	// Treat decode errors after integrity verification as proof verification failures.
 	if decodeErr != nil {
-		return fmt.Errorf("%w: %s", ErrChunkProofVerificationFailed, decodeErr.Error())
+		return nil, fmt.Errorf("%w: %s", ErrChunkProofVerificationFailed, decodeErr.Error())
 	}
 
 	// Verify the proof.
 	var pv syncer.ProofVerifier
 	ptr, err := pv.VerifyProof(ctx, chunk.Root.Hash, &p)
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrChunkProofVerificationFailed, err.Error())
-	}
-
-	// Import chunk into the node database.
-	emptyRoot := node.Root{
-		Namespace: chunk.Root.Namespace,
-		Version:   chunk.Root.Version,
-		Type:      chunk.Root.Type,
-	}
-	emptyRoot.Hash.Empty()
-
-	batch, err := ndb.NewBatch(emptyRoot, chunk.Root.Version, true)
-	if err != nil {
-		return fmt.Errorf("chunk: failed to create batch: %w", err)
+		return nil, fmt.Errorf("%w: %s", ErrChunkProofVerificationFailed, err.Error())
 	}
-	defer batch.Reset()
-
-	subtree := batch.MaybeStartSubtree(nil, 0, ptr)
-	if err = doRestoreChunk(ctx, batch, subtree, 0, ptr, nil); err != nil {
-		return fmt.Errorf("chunk: node import failed: %w", err)
-	}
-	if err = subtree.Commit(); err != nil {
-		return fmt.Errorf("chunk: node import failed: %w", err)
-	}
-	if err = batch.Commit(chunk.Root); err != nil {
-		return fmt.Errorf("chunk: node import failed: %w", err)
-	}
-
-	return nil
+	return ptr, nil
 }
 
 func doRestoreChunk(
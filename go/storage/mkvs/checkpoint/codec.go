@@ -0,0 +1,141 @@
+package checkpoint
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// MaxChunkSize is the maximum size of a decoded checkpoint chunk.
+//
+// Chunks are received from untrusted peers so the decoded size must be bounded in order to prevent
+// decompression bombs from exhausting memory.
+const MaxChunkSize = 128 * 1024 * 1024
+
+// ChunkCodec is a checkpoint chunk compression codec.
+type ChunkCodec uint8
+
+const (
+	// ChunkCodecSnappy is the snappy chunk codec.
+	ChunkCodecSnappy ChunkCodec = 0
+	// ChunkCodecZstd is the zstd chunk codec.
+	ChunkCodecZstd ChunkCodec = 1
+)
+
+// String returns a string representation of the chunk codec.
+func (c ChunkCodec) String() string {
+	switch c {
+	case ChunkCodecSnappy:
+		return "snappy"
+	case ChunkCodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("[unknown chunk codec: %d]", c)
+	}
+}
+
+// IsValid returns true iff the chunk codec is supported.
+func (c ChunkCodec) IsValid() bool {
+	switch c {
+	case ChunkCodecSnappy, ChunkCodecZstd:
+		return true
+	default:
+		return false
+	}
+}
+
+// MarshalText encodes a chunk codec into text form.
+func (c ChunkCodec) MarshalText() ([]byte, error) {
+	if !c.IsValid() {
+		return nil, fmt.Errorf("checkpoint: unknown chunk codec: %d", c)
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a text marshalled chunk codec.
+func (c *ChunkCodec) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "snappy":
+		*c = ChunkCodecSnappy
+	case "zstd":
+		*c = ChunkCodecZstd
+	default:
+		return fmt.Errorf("checkpoint: unknown chunk codec: %s", string(text))
+	}
+	return nil
+}
+
+// ParseChunkCodec parses a chunk codec from its text form. An empty string results in the default
+// (snappy) chunk codec.
+func ParseChunkCodec(text string) (ChunkCodec, error) {
+	var codec ChunkCodec
+	if text == "" {
+		return codec, nil
+	}
+	err := codec.UnmarshalText([]byte(text))
+	return codec, err
+}
+
+// newWriter returns a writer that compresses everything written to it into w.
+func (c ChunkCodec) newWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case ChunkCodecSnappy:
+		return snappy.NewBufferedWriter(w), nil
+	case ChunkCodecZstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("%w: codec %s", ErrUnsupportedFormat, c)
+	}
+}
+
+// newReader returns a reader that decompresses everything read from r. Reading fails in case the
+// decompressed output exceeds maxSize bytes.
+func (c ChunkCodec) newReader(r io.Reader, maxSize uint64) (io.ReadCloser, error) {
+	switch c {
+	case ChunkCodecSnappy:
+		return &limitedReadCloser{
+			ReadCloser: io.NopCloser(snappy.NewReader(r)),
+			maxSize:    maxSize,
+		}, nil
+	case ChunkCodecZstd:
+		zr, err := zstd.NewReader(r,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxMemory(maxSize),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &limitedReadCloser{
+			ReadCloser: zr.IOReadCloser(),
+			maxSize:    maxSize,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: codec %s", ErrUnsupportedFormat, c)
+	}
+}
+
+// limitedReadCloser is a reader that fails once more than maxSize bytes have been read.
+type limitedReadCloser struct {
+	io.ReadCloser
+
+	maxSize uint64
+	read    uint64
+}
+
+func (lr *limitedReadCloser) Read(p []byte) (int, error) {
+	if lr.read > lr.maxSize {
+		return 0, fmt.Errorf("decoded chunk exceeds maximum size (%d bytes)", lr.maxSize)
+	}
+	// Allow reading one byte past the limit so that exceeding it can be detected.
+	if remaining := lr.maxSize - lr.read + 1; uint64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := lr.ReadCloser.Read(p)
+	lr.read += uint64(n)
+	if lr.read > lr.maxSize {
+		return n, fmt.Errorf("decoded chunk exceeds maximum size (%d bytes)", lr.maxSize)
+	}
+	return n, err
+}
@@ -1,11 +1,14 @@
 package checkpoint
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -19,9 +22,9 @@ import (
 const (
 	chunksDir              = "chunks"
 	checkpointMetadataFile = "meta"
-	checkpointVersion      = 1
+	checkpointVersion      = LatestVersion
 
-	// Versions 1 of checkpoint chunks use proofs version 0. Consider bumping
+	// Versions 1 and 2 of checkpoint chunks use proofs version 0. Consider bumping
 	// this to latest version when introducing new checkpoint versions.
 	checkpointProofsVersion = 0
 )
@@ -31,7 +34,39 @@ type fileCreator struct {
 	ndb     db.NodeDB
 }
 
-func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chunkSize uint64) (meta *Metadata, err error) {
+func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chunkSize uint64, codec ChunkCodec) (*Metadata, error) {
+	return fc.createCheckpoint(ctx, root, nil, chunkSize, codec)
+}
+
+func (fc *fileCreator) CreateDeltaCheckpoint(ctx context.Context, root node.Root, base node.Root, chunkSize uint64, codec ChunkCodec) (*Metadata, error) {
+	if root.Type != base.Type || !root.Namespace.Equal(&base.Namespace) || root.Version <= base.Version {
+		return nil, fmt.Errorf("checkpoint: base root must be an earlier root of the same type")
+	}
+	// The base checkpoint may have been created using any supported checkpoint version.
+	if _, err := fc.readMetadata(base); err != nil {
+		return nil, fmt.Errorf("checkpoint: failed to get base checkpoint: %w", err)
+	}
+	if root.Hash.Equal(&base.Hash) {
+		// Nothing has changed, so there is nothing to gain from a delta checkpoint.
+		return fc.createCheckpoint(ctx, root, nil, chunkSize, codec)
+	}
+	return fc.createCheckpoint(ctx, root, &base, chunkSize, codec)
+}
+
+func (fc *fileCreator) createCheckpoint(
+	ctx context.Context,
+	root node.Root,
+	base *node.Root,
+	chunkSize uint64,
+	codec ChunkCodec,
+) (meta *Metadata, err error) {
+	if !codec.IsValid() {
+		return nil, fmt.Errorf("%w: codec %s", ErrUnsupportedFormat, codec)
+	}
+	if chunkSize > MaxChunkSize {
+		return nil, fmt.Errorf("checkpoint: chunk size exceeds maximum (%d bytes)", MaxChunkSize)
+	}
+
 	tree := mkvs.NewWithRoot(nil, fc.ndb, root)
 	defer tree.Close()
 
@@ -67,10 +102,26 @@ func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chu
 		return nil, fmt.Errorf("checkpoint: failed to create chunk directory: %w", err)
 	}
 
+	// Determine the offsets of chunks that need to be created. For full checkpoints, chunks are
+	// created until the whole tree is covered, while for delta checkpoints, chunks only need to
+	// cover the changed keys.
+	var changedKeys []node.Key
+	if base != nil {
+		if changedKeys, err = fc.getChangedKeys(ctx, *base, root); err != nil {
+			return nil, fmt.Errorf("checkpoint: failed to determine changed keys: %w", err)
+		}
+	}
+
 	// Create chunks until we are done.
 	var chunks []hash.Hash
-	var nextOffset node.Key
+	var offset, nextOffset node.Key
 	for chunkIndex := 0; ; chunkIndex++ {
+		if base != nil && len(changedKeys) > 0 {
+			offset = changedKeys[0]
+		} else {
+			offset = nextOffset
+		}
+
 		dataFilename := filepath.Join(chunksDir, strconv.Itoa(chunkIndex))
 
 		// Generate chunk.
@@ -80,7 +131,7 @@ func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chu
 		}
 
 		var chunkHash hash.Hash
-		chunkHash, nextOffset, err = createChunk(ctx, tree, root, nextOffset, chunkSize, f)
+		chunkHash, nextOffset, err = createChunk(ctx, tree, root, offset, chunkSize, codec, base != nil, f)
 		f.Close()
 		if err != nil {
 			return nil, fmt.Errorf("checkpoint: failed to create chunk %d: %w", chunkIndex, err)
@@ -88,8 +139,13 @@ func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chu
 
 		chunks = append(chunks, chunkHash)
 
+		// Skip any changed keys that are covered by the chunk.
+		for len(changedKeys) > 0 && (nextOffset == nil || bytes.Compare(changedKeys[0], nextOffset) < 0) {
+			changedKeys = changedKeys[1:]
+		}
+
 		// Check if we are finished.
-		if nextOffset == nil {
+		if nextOffset == nil || (base != nil && len(changedKeys) == 0) {
 			break
 		}
 	}
@@ -99,6 +155,8 @@ func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chu
 		Version: checkpointVersion,
 		Root:    root,
 		Chunks:  chunks,
+		Codec:   codec,
+		Base:    base,
 	}
 
 	if err = os.WriteFile(filepath.Join(checkpointDir, checkpointMetadataFile), cbor.Marshal(meta), 0o600); err != nil {
@@ -107,9 +165,90 @@ func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chu
 	return meta, nil
 }
 
+// getChangedKeys returns a sorted list of keys that differ between the base and the given root.
+//
+// In case a write log between the roots is available, it is used to determine the set of changed
+// keys. Otherwise both trees are iterated and compared.
+func (fc *fileCreator) getChangedKeys(ctx context.Context, base node.Root, root node.Root) ([]node.Key, error) {
+	wl, err := fc.ndb.GetWriteLog(ctx, base, root)
+	switch {
+	case err == nil:
+		keys := make(map[string]struct{})
+		for {
+			more, wlErr := wl.Next()
+			if wlErr != nil {
+				return nil, wlErr
+			}
+			if !more {
+				break
+			}
+
+			entry, wlErr := wl.Value()
+			if wlErr != nil {
+				return nil, wlErr
+			}
+			keys[string(entry.Key)] = struct{}{}
+		}
+
+		changed := make([]node.Key, 0, len(keys))
+		for key := range keys {
+			changed = append(changed, node.Key(key))
+		}
+		sort.Slice(changed, func(i, j int) bool { return bytes.Compare(changed[i], changed[j]) < 0 })
+		return changed, nil
+	case errors.Is(err, db.ErrWriteLogNotFound):
+	default:
+		return nil, err
+	}
+
+	baseTree := mkvs.NewWithRoot(nil, fc.ndb, base)
+	defer baseTree.Close()
+	tree := mkvs.NewWithRoot(nil, fc.ndb, root)
+	defer tree.Close()
+
+	bit := baseTree.NewIterator(ctx)
+	defer bit.Close()
+	it := tree.NewIterator(ctx)
+	defer it.Close()
+
+	var changed []node.Key
+	bit.Rewind()
+	it.Rewind()
+	for bit.Valid() || it.Valid() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		switch {
+		case !it.Valid() || (bit.Valid() && bytes.Compare(bit.Key(), it.Key()) < 0):
+			// Key has been removed.
+			changed = append(changed, bit.Key())
+			bit.Next()
+		case !bit.Valid() || bytes.Compare(bit.Key(), it.Key()) > 0:
+			// Key has been inserted.
+			changed = append(changed, it.Key())
+			it.Next()
+		default:
+			// Key exists in both trees, check if the value has changed.
+			if !bytes.Equal(bit.Value(), it.Value()) {
+				changed = append(changed, it.Key())
+			}
+			bit.Next()
+			it.Next()
+		}
+	}
+	if bit.Err() != nil {
+		return nil, bit.Err()
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	return changed, nil
+}
+
 func (fc *fileCreator) GetCheckpoints(_ context.Context, request *GetCheckpointsRequest) ([]*Metadata, error) {
-	// Currently we only support a single version so we report no checkpoints for other versions.
-	if request.Version != checkpointVersion {
+	// Report no checkpoints for unsupported versions.
+	if request.Version < MinimumVersion || request.Version > LatestVersion {
 		return []*Metadata{}, nil
 	}
 
@@ -135,6 +274,12 @@ func (fc *fileCreator) GetCheckpoints(_ context.Context, request *GetCheckpoints
 		if err = cbor.Unmarshal(data, &cp); err != nil {
 			return nil, fmt.Errorf("checkpoint: corrupted checkpoint metadata at %s: %w", m, err)
 		}
+		if cp.Version != request.Version {
+			continue
+		}
+		if cp.IsDelta() && !request.IncludeDeltas {
+			continue
+		}
 
 		cps = append(cps, &cp)
 	}
@@ -142,11 +287,18 @@ func (fc *fileCreator) GetCheckpoints(_ context.Context, request *GetCheckpoints
 }
 
 func (fc *fileCreator) GetCheckpoint(_ context.Context, version uint16, root node.Root) (*Metadata, error) {
-	// Currently we only support a single version.
-	if version != checkpointVersion {
+	cp, err := fc.readMetadata(root)
+	if err != nil {
+		return nil, err
+	}
+	if cp.Version != version {
 		return nil, ErrCheckpointNotFound
 	}
+	return cp, nil
+}
 
+// readMetadata reads the metadata of the checkpoint for the given root, regardless of its version.
+func (fc *fileCreator) readMetadata(root node.Root) (*Metadata, error) {
 	checkpointFilename := filepath.Join(
 		fc.dataDir,
 		strconv.FormatUint(root.Version, 10),
@@ -166,19 +318,22 @@ func (fc *fileCreator) GetCheckpoint(_ context.Context, version uint16, root nod
 }
 
 func (fc *fileCreator) DeleteCheckpoint(_ context.Context, version uint16, root node.Root) error {
-	// Currently we only support a single version.
-	if version != checkpointVersion {
+	cp, err := fc.readMetadata(root)
+	if err != nil {
+		return err
+	}
+	if cp.Version != version {
 		return ErrCheckpointNotFound
 	}
 
 	versionDir := filepath.Join(fc.dataDir, strconv.FormatUint(root.Version, 10))
 	checkpointDir := filepath.Join(versionDir, root.Hash.String())
 	checkpointFilename := filepath.Join(checkpointDir, checkpointMetadataFile)
-	if err := os.Remove(checkpointFilename); err != nil {
+	if err = os.Remove(checkpointFilename); err != nil {
 		return ErrCheckpointNotFound
 	}
 
-	if err := os.RemoveAll(checkpointDir); err != nil {
+	if err = os.RemoveAll(checkpointDir); err != nil {
 		return fmt.Errorf("checkpoint: failed to remove checkpoint directory: %w", err)
 	}
 
@@ -205,8 +360,8 @@ func (fc *fileCreator) DeleteCheckpoint(_ context.Context, version uint16, root
 }
 
 func (fc *fileCreator) GetCheckpointChunk(_ context.Context, chunk *ChunkMetadata, w io.Writer) error {
-	// Currently we only support a single version.
-	if chunk.Version != checkpointVersion {
+	cp, err := fc.readMetadata(chunk.Root)
+	if err != nil || cp.Version != chunk.Version {
 		return ErrChunkNotFound
 	}
 
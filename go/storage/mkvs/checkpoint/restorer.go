@@ -1,12 +1,16 @@
 package checkpoint
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
 // restorer is a checkpoint restorer.
@@ -20,6 +24,13 @@ type restorer struct {
 	currentCheckpoint *Metadata
 	// pendingChunks is a set of pending chunks.
 	pendingChunks map[uint64]bool
+
+	// deltaLock serializes use of deltaTree. Modifying deltaTree requires holding both the main
+	// lock and deltaLock (in that order) so that it can be read while holding either of them.
+	deltaLock sync.Mutex
+	// deltaTree is the tree that delta chunks are applied to in case a delta checkpoint is being
+	// restored.
+	deltaTree mkvs.Tree
 }
 
 // Implements Restorer.
@@ -30,6 +41,18 @@ func (rs *restorer) StartRestore(_ context.Context, checkpoint *Metadata) error
 	if rs.currentCheckpoint != nil {
 		return ErrRestoreAlreadyInProgress
 	}
+	if err := checkpoint.ValidateFormat(); err != nil {
+		return err
+	}
+
+	if checkpoint.IsDelta() {
+		if !rs.ndb.HasRoot(*checkpoint.Base) {
+			return ErrBaseRootNotFound
+		}
+		rs.deltaLock.Lock()
+		rs.deltaTree = mkvs.NewWithRoot(nil, rs.ndb, *checkpoint.Base)
+		rs.deltaLock.Unlock()
+	}
 
 	rs.currentCheckpoint = checkpoint
 	rs.pendingChunks = make(map[uint64]bool)
@@ -44,10 +67,23 @@ func (rs *restorer) AbortRestore(context.Context) error {
 	rs.Lock()
 	defer rs.Unlock()
 
+	rs.resetLocked()
+
+	return nil
+}
+
+func (rs *restorer) resetLocked() {
 	rs.pendingChunks = nil
 	rs.currentCheckpoint = nil
 
-	return nil
+	// Wait for any in-progress delta chunk restores before closing the tree.
+	rs.deltaLock.Lock()
+	defer rs.deltaLock.Unlock()
+
+	if rs.deltaTree != nil {
+		rs.deltaTree.Close()
+		rs.deltaTree = nil
+	}
 }
 
 func (rs *restorer) GetCurrentCheckpoint() *Metadata {
@@ -64,26 +100,32 @@ func (rs *restorer) GetCurrentCheckpoint() *Metadata {
 
 // Implements Restorer.
 func (rs *restorer) RestoreChunk(ctx context.Context, idx uint64, r io.Reader) (bool, error) {
-	chunk, err := func() (*ChunkMetadata, error) {
+	chunk, deltaTree, err := func() (*ChunkMetadata, mkvs.Tree, error) {
 		rs.Lock()
 		defer rs.Unlock()
 
 		if rs.currentCheckpoint == nil {
-			return nil, ErrNoRestoreInProgress
+			return nil, nil, ErrNoRestoreInProgress
 		}
 
 		// Check if the given chunk is still pending.
 		if !rs.pendingChunks[idx] {
-			return nil, ErrChunkAlreadyRestored
+			return nil, nil, ErrChunkAlreadyRestored
 		}
 
-		return rs.currentCheckpoint.GetChunkMetadata(idx)
+		chunk, err := rs.currentCheckpoint.GetChunkMetadata(idx)
+		return chunk, rs.deltaTree, err
 	}()
 	if err != nil {
 		return false, err
 	}
 
-	err = restoreChunk(ctx, rs.ndb, chunk, r)
+	switch deltaTree {
+	case nil:
+		err = restoreChunk(ctx, rs.ndb, chunk, r)
+	default:
+		err = rs.restoreDeltaChunk(ctx, deltaTree, chunk, r)
+	}
 	switch {
 	case err == nil:
 	case errors.Is(err, ErrChunkProofVerificationFailed):
@@ -103,15 +145,117 @@ func (rs *restorer) RestoreChunk(ctx context.Context, idx uint64, r io.Reader) (
 
 	// If there are no more pending chunks, restore is done.
 	if len(rs.pendingChunks) == 0 {
-		rs.pendingChunks = nil
-		rs.currentCheckpoint = nil
+		if deltaTree != nil {
+			// Persist the resulting tree, making sure that it matches the checkpoint root.
+			rs.deltaLock.Lock()
+			_, err = deltaTree.CommitKnown(ctx, chunk.Root, mkvs.AsChunk())
+			rs.deltaLock.Unlock()
+			switch {
+			case err == nil:
+			case errors.Is(err, mkvs.ErrKnownRootMismatch):
+				rs.resetLocked()
+				return false, fmt.Errorf("%w: %s", ErrChunkProofVerificationFailed, err.Error())
+			default:
+				rs.resetLocked()
+				return false, fmt.Errorf("checkpoint: failed to commit delta checkpoint: %w", err)
+			}
+		}
+
+		rs.resetLocked()
 		return true, nil
 	}
 
 	return false, nil
 }
 
+func (rs *restorer) restoreDeltaChunk(ctx context.Context, tree mkvs.Tree, chunk *ChunkMetadata, r io.Reader) error {
+	hdr, entries, err := verifyDeltaChunk(ctx, chunk, r)
+	if err != nil {
+		return err
+	}
+
+	rs.deltaLock.Lock()
+	defer rs.deltaLock.Unlock()
+
+	// The restore may have been aborted (and the tree closed) while the chunk was being verified.
+	if rs.deltaTree != tree {
+		return ErrNoRestoreInProgress
+	}
+
+	return applyDeltaChunk(ctx, tree, hdr, entries)
+}
+
 // NewRestorer creates a new checkpoint restorer.
 func NewRestorer(ndb db.NodeDB) (Restorer, error) {
 	return &restorer{ndb: ndb}, nil
 }
+
+// RestoreChain restores a chain of checkpoints, where the first checkpoint is either a full
+// checkpoint or a delta checkpoint whose base root is already present in the node database and
+// each subsequent checkpoint is a delta checkpoint against the previous one. Each restored root is
+// finalized before proceeding with the next checkpoint in the chain.
+//
+// Checkpoints whose roots have already been restored and finalized are skipped, so an interrupted
+// chain restore can be resumed by calling this function again with the same chain.
+func RestoreChain(ctx context.Context, ndb db.NodeDB, provider ChunkProvider, chain []*Metadata) error {
+	for i, cp := range chain {
+		if i > 0 && (!cp.IsDelta() || !cp.Base.Equal(&chain[i-1].Root)) {
+			return fmt.Errorf("checkpoint: checkpoint %d is not a delta against the previous checkpoint", i)
+		}
+	}
+
+	rs, err := NewRestorer(ndb)
+	if err != nil {
+		return err
+	}
+
+	for _, cp := range chain {
+		if latest, ok := ndb.GetLatestVersion(); ok && latest >= cp.Root.Version && ndb.HasRoot(cp.Root) {
+			continue
+		}
+
+		if err = restoreCheckpoint(ctx, ndb, rs, provider, cp); err != nil {
+			return fmt.Errorf("checkpoint: failed to restore checkpoint at version %d: %w", cp.Root.Version, err)
+		}
+	}
+	return nil
+}
+
+func restoreCheckpoint(ctx context.Context, ndb db.NodeDB, rs Restorer, provider ChunkProvider, cp *Metadata) (err error) {
+	if err = ndb.StartMultipartInsert(cp.Root.Version); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = rs.AbortRestore(ctx)
+			_ = ndb.AbortMultipartInsert()
+		}
+	}()
+
+	if err = rs.StartRestore(ctx, cp); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for idx := range cp.Chunks {
+		var chunk *ChunkMetadata
+		if chunk, err = cp.GetChunkMetadata(uint64(idx)); err != nil {
+			return err
+		}
+
+		buf.Reset()
+		if err = provider.GetCheckpointChunk(ctx, chunk, &buf); err != nil {
+			return fmt.Errorf("failed to fetch chunk %d: %w", idx, err)
+		}
+
+		var done bool
+		if done, err = rs.RestoreChunk(ctx, uint64(idx), &buf); err != nil {
+			return fmt.Errorf("failed to restore chunk %d: %w", idx, err)
+		}
+		if done {
+			break
+		}
+	}
+
+	return ndb.Finalize([]node.Root{cp.Root})
+}
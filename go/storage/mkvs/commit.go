@@ -21,19 +21,29 @@ func NoPersist() CommitOption {
 	}
 }
 
+// AsChunk returns a commit option that makes the Commit persist the root using a chunk batch as
+// is required while a multipart insert is in progress. In this case the new root is not linked to
+// the old root, no write log is stored and no nodes are marked for removal.
+func AsChunk() CommitOption {
+	return func(o *commitOptions) {
+		o.chunk = true
+	}
+}
+
 type commitOptions struct {
 	noPersist bool
+	chunk     bool
 }
 
 // Implements Tree.
-func (t *tree) CommitKnown(ctx context.Context, root node.Root) (writelog.WriteLog, error) {
+func (t *tree) CommitKnown(ctx context.Context, root node.Root, options ...CommitOption) (writelog.WriteLog, error) {
 	writeLog, _, err := t.commitWithHooks(ctx, root.Namespace, root.Version, func(rootHash hash.Hash) error {
 		if !rootHash.Equal(&root.Hash) {
 			return ErrKnownRootMismatch
 		}
 
 		return nil
-	})
+	}, options...)
 	return writeLog, err
 }
 
@@ -62,17 +72,21 @@ func (t *tree) commitWithHooks(
 	}
 
 	oldRoot := t.cache.getSyncRoot()
-	if oldRoot.IsEmpty() {
-		oldRoot.Namespace = namespace
-		oldRoot.Version = version
-		oldRoot.Type = t.rootType
+	if oldRoot.IsEmpty() || opts.chunk {
+		// Chunk commits are never linked to the old root.
+		oldRoot = node.Root{
+			Namespace: namespace,
+			Version:   version,
+			Type:      t.rootType,
+		}
+		oldRoot.Hash.Empty()
 	}
 
 	var batch db.Batch
 	var err error
 	switch opts.noPersist {
 	case false:
-		batch, err = t.cache.db.NewBatch(oldRoot, version, false)
+		batch, err = t.cache.db.NewBatch(oldRoot, version, opts.chunk)
 	case true:
 		// Do not persist anything -- use a dummy batch.
 		nopDb, _ := db.NewNopNodeDB()
@@ -128,13 +142,15 @@ func (t *tree) commitWithHooks(
 		Type:      oldRoot.Type,
 		Hash:      rootHash,
 	}
-	if err := batch.PutWriteLog(log, logAnns); err != nil {
-		return nil, hash.Hash{}, err
-	}
+	if !opts.chunk {
+		if err := batch.PutWriteLog(log, logAnns); err != nil {
+			return nil, hash.Hash{}, err
+		}
 
-	// Store removed nodes.
-	if err := batch.RemoveNodes(t.pendingRemovedNodes); err != nil {
-		return nil, hash.Hash{}, err
+		// Store removed nodes.
+		if err := batch.RemoveNodes(t.pendingRemovedNodes); err != nil {
+			return nil, hash.Hash{}, err
+		}
 	}
 
 	// And finally commit to the database.
@@ -707,7 +707,7 @@ func TestBadgerV3InitialFill(t *testing.T) {
 		Namespace: testNs,
 		Version:   2,
 		Hash:      newRootHash,
-	}, 1024*1024)
+	}, 1024*1024, checkpoint.ChunkCodecSnappy)
 	require.NoError(t, err, "CreateCheckpoint")
 
 	// New db, start restoring the chunk into it.
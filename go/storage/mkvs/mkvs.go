@@ -96,7 +96,7 @@ type Tree interface {
 	//
 	// In case the computed root doesn't match the known root, the update
 	// is NOT committed and ErrKnownRootMismatch is returned.
-	CommitKnown(ctx context.Context, root node.Root) (writelog.WriteLog, error)
+	CommitKnown(ctx context.Context, root node.Root, options ...CommitOption) (writelog.WriteLog, error)
 
 	// Commit commits tree updates to the underlying database and returns
 	// the write log and new merkle root.
@@ -184,10 +184,10 @@ func testBasic(t *testing.T, localBackend api.LocalBackend, backend api.Backend,
 	// Test checkpoints.
 	t.Run("Checkpoints", func(t *testing.T) {
 		// Create a new checkpoint with the local backend.
-		cp, err := localBackend.Checkpointer().CreateCheckpoint(ctx, newRoot, 16*1024)
+		cp, err := localBackend.Checkpointer().CreateCheckpoint(ctx, newRoot, 16*1024, checkpoint.ChunkCodecSnappy)
 		require.NoError(t, err, "CreateCheckpoint")
 
-		cps, err := backend.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{Version: 1, Namespace: namespace})
+		cps, err := backend.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{Version: checkpoint.LatestVersion, Namespace: namespace})
 		require.NoError(t, err, "GetCheckpoints")
 		require.Contains(t, cps, cp, "GetCheckpoints should return correct checkpoint metadata")
 		require.Len(t, cp.Chunks, 1, "checkpoint should have a single chunk")
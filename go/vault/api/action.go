@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"slices"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
@@ -27,6 +28,12 @@ type PendingAction struct {
 	AuthorizedBy []staking.Address `json:"authorized_by"`
 	// Action is the pending action itself.
 	Action Action `json:"action"`
+	// ExecuteAt is the epoch at which a queued action will be executed. It is only set once the
+	// action has been authorized by an authority that has an execution delay configured.
+	ExecuteAt beacon.EpochTime `json:"execute_at,omitempty"`
+	// SuspendAuthorizedBy contains the suspend authority addresses that have authorized suspending
+	// the vault while the action is queued.
+	SuspendAuthorizedBy []staking.Address `json:"suspend_authorized_by,omitempty"`
 }
 
 // ContainsAuthorizationFrom returns true iff the given address is among the action authorizers.
@@ -34,6 +41,11 @@ func (pa *PendingAction) ContainsAuthorizationFrom(addr staking.Address) bool {
 	return slices.Contains(pa.AuthorizedBy, addr)
 }
 
+// IsQueued returns true iff the action has been authorized and is waiting for execution.
+func (pa *PendingAction) IsQueued() bool {
+	return pa.ExecuteAt != 0
+}
+
 // Action is a vault action.
 type Action struct {
 	// Suspend is the suspend action.
@@ -46,6 +58,8 @@ type Action struct {
 	UpdateWithdrawPolicy *ActionUpdateWithdrawPolicy `json:"update_withdraw_policy,omitempty"`
 	// UpdateAuthority is the authority update action.
 	UpdateAuthority *ActionUpdateAuthority `json:"update_authority,omitempty"`
+	// UpdateSpendingPolicy is the spending policy update action.
+	UpdateSpendingPolicy *ActionUpdateSpendingPolicy `json:"update_spending_policy,omitempty"`
 }
 
 // Validate validates the given action.
@@ -57,6 +71,7 @@ func (a *Action) Validate(params *ConsensusParameters) error {
 		a.ExecuteMessage != nil,
 		a.UpdateWithdrawPolicy != nil,
 		a.UpdateAuthority != nil,
+		a.UpdateSpendingPolicy != nil,
 	) {
 		return fmt.Errorf("exactly one action must be set")
 	}
@@ -70,6 +85,8 @@ func (a *Action) Validate(params *ConsensusParameters) error {
 		err = a.UpdateWithdrawPolicy.Validate()
 	case a.UpdateAuthority != nil:
 		err = a.UpdateAuthority.Validate(params)
+	case a.UpdateSpendingPolicy != nil:
+		err = a.UpdateSpendingPolicy.Validate()
 	}
 	return err
 }
@@ -92,11 +109,46 @@ func (a *Action) Authorities(vault *Vault) []*Authority {
 		return a.UpdateWithdrawPolicy.Authorities(vault)
 	case a.UpdateAuthority != nil:
 		return a.UpdateAuthority.Authorities(vault)
+	case a.UpdateSpendingPolicy != nil:
+		return a.UpdateSpendingPolicy.Authorities(vault)
 	default:
 		return nil
 	}
 }
 
+// ExecutionDelay returns the execution delay of the action in case the given addresses are
+// sufficient to authorize it. In case multiple authorities are satisfied, the shortest delay is
+// returned.
+//
+// The second return value is false iff the addresses are not sufficient to authorize the action.
+func (a *Action) ExecutionDelay(vault *Vault, addresses []staking.Address) (beacon.EpochTime, bool) {
+	var (
+		delay beacon.EpochTime
+		ok    bool
+	)
+	for _, auth := range a.Authorities(vault) {
+		if !auth.Verify(addresses) {
+			continue
+		}
+		if !ok || auth.Delay < delay {
+			delay = auth.Delay
+		}
+		ok = true
+	}
+	return delay, ok
+}
+
+// CanCancel returns true iff the given address is allowed to cancel the pending action.
+//
+// Any address that can authorize the action can cancel it. Additionally, queued actions can be
+// canceled by the vault's suspend authority.
+func (pa *PendingAction) CanCancel(vault *Vault, addr staking.Address) bool {
+	if pa.Action.IsAuthorized(vault, addr) {
+		return true
+	}
+	return pa.IsQueued() && vault.SuspendAuthority.Contains(addr)
+}
+
 // IsAuthorized returns true iff the given address is authorized to execute this action.
 func (a *Action) IsAuthorized(vault *Vault, addr staking.Address) bool {
 	for _, auth := range a.Authorities(vault) {
@@ -127,6 +179,10 @@ func (a Action) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
 		fmt.Fprintf(w, "%sUpdate authority:\n", prefix)
 		a.UpdateAuthority.PrettyPrint(ctx, prefix+"  ", w)
 	}
+	if a.UpdateSpendingPolicy != nil {
+		fmt.Fprintf(w, "%sUpdate spending policy:\n", prefix)
+		a.UpdateSpendingPolicy.PrettyPrint(ctx, prefix+"  ", w)
+	}
 }
 
 // PrettyType returns a representation of Action that can be used for pretty printing.
@@ -324,7 +380,15 @@ func (au *ActionUpdateAuthority) Validate(params *ConsensusParameters) error {
 }
 
 // Authorities returns the authorities of the given vault that can authorize this action.
+//
+// In case the vault has a spending policy, only the suspend authority can update the suspend
+// authority as otherwise the admin authority could bypass the spending policy.
 func (au *ActionUpdateAuthority) Authorities(vault *Vault) []*Authority {
+	if vault.SpendingPolicy != nil && au.SuspendAuthority != nil {
+		return []*Authority{
+			&vault.SuspendAuthority,
+		}
+	}
 	return []*Authority{
 		&vault.AdminAuthority,
 	}
@@ -358,3 +422,52 @@ func (au ActionUpdateAuthority) PrettyPrint(ctx context.Context, prefix string,
 func (au ActionUpdateAuthority) PrettyType() (interface{}, error) {
 	return au, nil
 }
+
+// ActionUpdateSpendingPolicy is the action to update the vault spending policy.
+type ActionUpdateSpendingPolicy struct {
+	// Policy is the new spending policy. If the field is nil the spending policy is removed.
+	Policy *SpendingPolicy `json:"policy,omitempty"`
+}
+
+// Validate validates the given action.
+func (au *ActionUpdateSpendingPolicy) Validate() error {
+	if au.Policy == nil {
+		return nil
+	}
+	if err := au.Policy.Validate(); err != nil {
+		return fmt.Errorf("malformed spending policy: %w", err)
+	}
+	return nil
+}
+
+// Authorities returns the authorities of the given vault that can authorize this action.
+//
+// The spending policy is meant to limit the admin authority so it can only be updated by the
+// suspend authority.
+func (au *ActionUpdateSpendingPolicy) Authorities(vault *Vault) []*Authority {
+	return []*Authority{
+		&vault.SuspendAuthority,
+	}
+}
+
+// Apply applies the spending policy update to the given vault.
+func (au *ActionUpdateSpendingPolicy) Apply(vault *Vault) {
+	vault.UpdateSpendingPolicy(au.Policy)
+}
+
+// PrettyPrint writes a pretty-printed representation of ActionUpdateSpendingPolicy to the given
+// writer.
+func (au ActionUpdateSpendingPolicy) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	if au.Policy == nil {
+		fmt.Fprintf(w, "%sRemove spending policy\n", prefix)
+		return
+	}
+	fmt.Fprintf(w, "%sNew spending policy:\n", prefix)
+	au.Policy.PrettyPrint(ctx, prefix+"  ", w)
+}
+
+// PrettyType returns a representation of ActionUpdateSpendingPolicy that can be used for pretty
+// printing.
+func (au ActionUpdateSpendingPolicy) PrettyType() (interface{}, error) {
+	return au, nil
+}
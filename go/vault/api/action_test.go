@@ -158,4 +158,81 @@ func TestActionUpdateAuthority(t *testing.T) {
 
 	action.UpdateAuthority.Apply(newVault)
 	require.EqualValues(newVault.SuspendAuthority, *action.UpdateAuthority.SuspendAuthority)
+
+	vault.SpendingPolicy = &SpendingPolicy{}
+	require.EqualValues(
+		action.Authorities(vault),
+		[]*Authority{&vault.SuspendAuthority},
+		"update suspend authority should require suspend authority when spending policy is set",
+	)
+}
+
+func TestActionUpdateSpendingPolicy(t *testing.T) {
+	require := require.New(t)
+
+	vault := createTestVault()
+	action := Action{
+		UpdateSpendingPolicy: &ActionUpdateSpendingPolicy{
+			Policy: &SpendingPolicy{
+				AllowedDestinations: []staking.Address{testAddrA},
+			},
+		},
+	}
+	err := action.Validate(&ConsensusParameters{})
+	require.NoError(err, "Validate")
+	require.EqualValues(
+		action.Authorities(vault),
+		[]*Authority{&vault.SuspendAuthority},
+		"update spending policy should require suspend authority",
+	)
+
+	action.UpdateSpendingPolicy.Apply(vault)
+	require.EqualValues(action.UpdateSpendingPolicy.Policy, vault.SpendingPolicy)
+
+	action.UpdateSpendingPolicy.Policy.AllowedDestinations = []staking.Address{staking.CommonPoolAddress}
+	err = action.Validate(&ConsensusParameters{})
+	require.Error(err, "Validate should fail on malformed spending policy")
+
+	action = Action{
+		UpdateSpendingPolicy: &ActionUpdateSpendingPolicy{},
+	}
+	err = action.Validate(&ConsensusParameters{})
+	require.NoError(err, "Validate")
+
+	action.UpdateSpendingPolicy.Apply(vault)
+	require.Nil(vault.SpendingPolicy, "spending policy should be removed")
+}
+
+func TestActionExecutionDelay(t *testing.T) {
+	require := require.New(t)
+
+	vault := createTestVault()
+	vault.AdminAuthority.Delay = 5
+	vault.SuspendAuthority.Delay = 2
+
+	suspend := Action{Suspend: &ActionSuspend{}}
+	_, ok := suspend.ExecutionDelay(vault, []staking.Address{testAddrA})
+	require.False(ok, "action should not be authorized")
+
+	delay, ok := suspend.ExecutionDelay(vault, []staking.Address{testAddrA, testAddrB})
+	require.True(ok, "action should be authorized")
+	require.EqualValues(2, delay, "shortest delay of satisfied authorities should be used")
+
+	delay, ok = suspend.ExecutionDelay(vault, []staking.Address{testAddrA, testAddrD})
+	require.True(ok, "action should be authorized")
+	require.EqualValues(2, delay, "delay of the suspend authority should be used")
+
+	executeMsg := Action{ExecuteMessage: &ActionExecuteMessage{Method: "foo"}}
+	delay, ok = executeMsg.ExecutionDelay(vault, []staking.Address{testAddrA, testAddrB})
+	require.True(ok, "action should be authorized")
+	require.EqualValues(5, delay, "delay of the admin authority should be used")
+
+	// Only queued actions can be canceled by the suspend authority.
+	pa := PendingAction{Action: executeMsg}
+	require.True(pa.CanCancel(vault, testAddrA), "admin authority should be able to cancel")
+	require.False(pa.CanCancel(vault, testAddrC), "suspend authority should not be able to cancel")
+	pa.ExecuteAt = 10
+	require.True(pa.IsQueued(), "action should be queued")
+	require.True(pa.CanCancel(vault, testAddrC), "suspend authority should be able to cancel queued actions")
+	require.False(pa.CanCancel(vault, staking.NewModuleAddress("test", "e")), "unrelated address should not be able to cancel")
 }
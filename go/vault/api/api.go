@@ -4,6 +4,7 @@ package api
 import (
 	"context"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -30,6 +31,12 @@ var (
 	ErrNoSuchAction = errors.New(ModuleName, 6, "vault: no such action")
 	// ErrUnsupportedAction is the error returned when an action is not supported.
 	ErrUnsupportedAction = errors.New(ModuleName, 7, "vault: action not supported")
+	// ErrActionQueued is the error returned when authorizing an action that is already queued for
+	// execution.
+	ErrActionQueued = errors.New(ModuleName, 8, "vault: action already queued")
+	// ErrSpendingPolicyViolation is the error returned when an action does not comply with the
+	// vault spending policy.
+	ErrSpendingPolicyViolation = errors.New(ModuleName, 9, "vault: spending policy violation")
 )
 
 // Backend is a vault implementation.
@@ -102,6 +109,10 @@ type ConsensusParameters struct {
 	// authority.
 	MaxAuthorityAddresses uint8 `json:"max_authority_addresses,omitempty"`
 
+	// MaxAuthorityDelay is the maximum execution delay (in epochs) that can be configured for
+	// each authority.
+	MaxAuthorityDelay beacon.EpochTime `json:"max_authority_delay,omitempty"`
+
 	// GasCosts are the vault transaction gas costs.
 	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
 }
@@ -110,6 +121,7 @@ type ConsensusParameters struct {
 var DefaultConsensusParameters = ConsensusParameters{
 	Enabled:               true,
 	MaxAuthorityAddresses: 32,
+	MaxAuthorityDelay:     168,
 	GasCosts:              DefaultGasCosts,
 }
 
@@ -119,6 +131,10 @@ type ConsensusParameterChanges struct {
 	// authority.
 	MaxAuthorityAddresses *uint8 `json:"max_authority_addresses,omitempty"`
 
+	// MaxAuthorityDelay is the new maximum execution delay (in epochs) that can be configured for
+	// each authority.
+	MaxAuthorityDelay *beacon.EpochTime `json:"max_authority_delay,omitempty"`
+
 	// GasCosts are the new gas costs.
 	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
 }
@@ -128,6 +144,9 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxAuthorityAddresses != nil {
 		params.MaxAuthorityAddresses = *c.MaxAuthorityAddresses
 	}
+	if c.MaxAuthorityDelay != nil {
+		params.MaxAuthorityDelay = *c.MaxAuthorityDelay
+	}
 	if c.GasCosts != nil {
 		params.GasCosts = c.GasCosts
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
@@ -10,12 +11,14 @@ type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
 
-	ActionSubmitted  *ActionSubmittedEvent  `json:"action_submitted,omitempty"`
-	ActionCanceled   *ActionCanceledEvent   `json:"action_canceled,omitempty"`
-	ActionExecuted   *ActionExecutedEvent   `json:"action_executed,omitempty"`
-	StateChanged     *StateChangedEvent     `json:"state_changed,omitempty"`
-	PolicyUpdated    *PolicyUpdatedEvent    `json:"policy_updated"`
-	AuthorityUpdated *AuthorityUpdatedEvent `json:"authority_updated"`
+	ActionSubmitted       *ActionSubmittedEvent       `json:"action_submitted,omitempty"`
+	ActionQueued          *ActionQueuedEvent          `json:"action_queued,omitempty"`
+	ActionCanceled        *ActionCanceledEvent        `json:"action_canceled,omitempty"`
+	ActionExecuted        *ActionExecutedEvent        `json:"action_executed,omitempty"`
+	StateChanged          *StateChangedEvent          `json:"state_changed,omitempty"`
+	PolicyUpdated         *PolicyUpdatedEvent         `json:"policy_updated"`
+	AuthorityUpdated      *AuthorityUpdatedEvent      `json:"authority_updated"`
+	SpendingPolicyUpdated *SpendingPolicyUpdatedEvent `json:"spending_policy_updated,omitempty"`
 }
 
 // ActionSubmittedEvent is the event emitted when a new vault action is submitted.
@@ -33,6 +36,22 @@ func (e *ActionSubmittedEvent) EventKind() string {
 	return "action_submitted"
 }
 
+// ActionQueuedEvent is the event emitted when a vault action has been authorized and is queued
+// for delayed execution.
+type ActionQueuedEvent struct {
+	// Vault is the vault address.
+	Vault staking.Address `json:"vault"`
+	// Nonce is the action nonce.
+	Nonce uint64 `json:"nonce"`
+	// ExecuteAt is the epoch at which the action will be executed.
+	ExecuteAt beacon.EpochTime `json:"execute_at"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *ActionQueuedEvent) EventKind() string {
+	return "action_queued"
+}
+
 // ActionCanceledEvent is the event emitted when a vault action is canceled.
 type ActionCanceledEvent struct {
 	// Vault is the vault address.
@@ -105,3 +124,14 @@ type AuthorityUpdatedEvent struct {
 func (e *AuthorityUpdatedEvent) EventKind() string {
 	return "authority_updated"
 }
+
+// SpendingPolicyUpdatedEvent is the event emitted when the vault spending policy is updated.
+type SpendingPolicyUpdatedEvent struct {
+	// Vault is the vault address.
+	Vault staking.Address `json:"vault"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *SpendingPolicyUpdatedEvent) EventKind() string {
+	return "spending_policy_updated"
+}
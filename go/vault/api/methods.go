@@ -36,6 +36,8 @@ type Create struct {
 	AdminAuthority Authority `json:"admin_authority"`
 	// SuspendAuthority specifies the vault's suspend authority.
 	SuspendAuthority Authority `json:"suspend_authority"`
+	// SpendingPolicy is the optional spending policy enforced for execute message actions.
+	SpendingPolicy *SpendingPolicy `json:"spending_policy,omitempty"`
 }
 
 // Validate validates the create call.
@@ -46,6 +48,11 @@ func (c *Create) Validate(params *ConsensusParameters) error {
 	if err := c.SuspendAuthority.Validate(params); err != nil {
 		return err
 	}
+	if c.SpendingPolicy != nil {
+		if err := c.SpendingPolicy.Validate(); err != nil {
+			return fmt.Errorf("invalid spending policy: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -55,6 +62,10 @@ func (c Create) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
 	c.AdminAuthority.PrettyPrint(ctx, prefix+"  ", w)
 	fmt.Fprintf(w, "%sSuspend authority:\n", prefix)
 	c.SuspendAuthority.PrettyPrint(ctx, prefix+"  ", w)
+	if c.SpendingPolicy != nil {
+		fmt.Fprintf(w, "%sSpending policy:\n", prefix)
+		c.SpendingPolicy.PrettyPrint(ctx, prefix+"  ", w)
+	}
 }
 
 // PrettyType returns a representation of Create that can be used for pretty printing.
@@ -18,7 +18,7 @@ func (p *ConsensusParameters) SanityCheck() error {
 
 // SanityCheck performs a sanity check on the consensus parameter changes.
 func (c *ConsensusParameterChanges) SanityCheck() error {
-	if c.MaxAuthorityAddresses == nil && c.GasCosts == nil {
+	if c.MaxAuthorityAddresses == nil && c.MaxAuthorityDelay == nil && c.GasCosts == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
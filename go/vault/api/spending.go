@@ -0,0 +1,386 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
+)
+
+var _ prettyprint.PrettyPrinter = (*SpendingPolicy)(nil)
+
+// SpendingPolicy is the vault spending policy that is enforced for all execute message actions.
+//
+// Actions that do not comply with the policy are rejected when they are authorized and are
+// re-checked before being executed.
+type SpendingPolicy struct {
+	// AllowedMethods is an optional allow-list of methods that can be called. If empty, all
+	// methods are allowed.
+	AllowedMethods []transaction.MethodName `json:"allowed_methods,omitempty"`
+	// AllowedDestinations is an optional allow-list of staking accounts that can receive funds.
+	// If empty, all destinations are allowed. When set, only methods with known spending effects
+	// can be called.
+	AllowedDestinations []staking.Address `json:"allowed_destinations,omitempty"`
+	// MethodLimits are the optional per-method limits on the amount of spent base units.
+	MethodLimits map[transaction.MethodName]*SpendingLimit `json:"method_limits,omitempty"`
+	// TotalLimit is the optional limit on the combined amount of base units spent by all methods.
+	TotalLimit *SpendingLimit `json:"total_limit,omitempty"`
+}
+
+// Validate validates the spending policy.
+func (sp *SpendingPolicy) Validate() error {
+	methods := make(map[transaction.MethodName]struct{}, len(sp.AllowedMethods))
+	for _, method := range sp.AllowedMethods {
+		if err := method.SanityCheck(); err != nil {
+			return fmt.Errorf("malformed allowed method: %w", err)
+		}
+		if _, ok := methods[method]; ok {
+			return fmt.Errorf("duplicate allowed method: %s", method)
+		}
+		methods[method] = struct{}{}
+	}
+
+	destinations := make(map[staking.Address]struct{}, len(sp.AllowedDestinations))
+	for _, addr := range sp.AllowedDestinations {
+		if !addr.IsValid() {
+			return fmt.Errorf("malformed allowed destination")
+		}
+		if _, ok := destinations[addr]; ok {
+			return fmt.Errorf("duplicate allowed destination: %s", addr)
+		}
+		destinations[addr] = struct{}{}
+	}
+
+	for method, limit := range sp.MethodLimits {
+		if !hasKnownSpending(method) {
+			return fmt.Errorf("spending limits are not supported for method: %s", method)
+		}
+		if limit == nil {
+			return fmt.Errorf("missing spending limit for method: %s", method)
+		}
+		if err := limit.Validate(); err != nil {
+			return fmt.Errorf("malformed spending limit for method %s: %w", method, err)
+		}
+	}
+
+	if sp.TotalLimit != nil {
+		if err := sp.TotalLimit.Validate(); err != nil {
+			return fmt.Errorf("malformed total spending limit: %w", err)
+		}
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of SpendingPolicy to the given writer.
+func (sp SpendingPolicy) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sAllowed methods:", prefix)
+	switch len(sp.AllowedMethods) {
+	case 0:
+		fmt.Fprintf(w, " (all)\n")
+	default:
+		fmt.Fprintln(w)
+		for _, method := range sp.AllowedMethods {
+			fmt.Fprintf(w, "%s  - %s\n", prefix, method)
+		}
+	}
+
+	fmt.Fprintf(w, "%sAllowed destinations:", prefix)
+	switch len(sp.AllowedDestinations) {
+	case 0:
+		fmt.Fprintf(w, " (all)\n")
+	default:
+		fmt.Fprintln(w)
+		for _, addr := range sp.AllowedDestinations {
+			fmt.Fprintf(w, "%s  - %s\n", prefix, addr)
+		}
+	}
+
+	if len(sp.MethodLimits) > 0 {
+		fmt.Fprintf(w, "%sMethod limits:\n", prefix)
+		for _, method := range sortedMethods(sp.MethodLimits) {
+			fmt.Fprintf(w, "%s  %s:\n", prefix, method)
+			sp.MethodLimits[method].PrettyPrint(ctx, prefix+"    ", w)
+		}
+	}
+
+	if sp.TotalLimit != nil {
+		fmt.Fprintf(w, "%sTotal limit:\n", prefix)
+		sp.TotalLimit.PrettyPrint(ctx, prefix+"  ", w)
+	}
+}
+
+// PrettyType returns a representation of SpendingPolicy that can be used for pretty printing.
+func (sp SpendingPolicy) PrettyType() (interface{}, error) {
+	return sp, nil
+}
+
+// SpendingLimit is the limit on the amount of base units that may be spent by a method.
+type SpendingLimit struct {
+	// LimitAmount is the maximum amount of base units that may be spent in the given interval.
+	LimitAmount quantity.Quantity `json:"limit_amount"`
+	// LimitInterval is the interval (in epochs) when the limit amount resets.
+	LimitInterval beacon.EpochTime `json:"limit_interval"`
+}
+
+// Validate validates the spending limit.
+func (sl *SpendingLimit) Validate() error {
+	if !sl.LimitAmount.IsValid() {
+		return fmt.Errorf("invalid limit amount")
+	}
+	if sl.LimitInterval == 0 {
+		return fmt.Errorf("limit interval must be greater than zero")
+	}
+	return nil
+}
+
+// spend returns the updated spending accounting state after spending the given amount and true
+// iff the amount can be spent without exceeding the limit.
+func (sl *SpendingLimit) spend(acct *SpendingAccount, amount *quantity.Quantity, epoch beacon.EpochTime) (*SpendingAccount, bool) {
+	// If current bucket is different than the last recorded bucket, reset current amount.
+	currentBucket := uint64(epoch / sl.LimitInterval)
+	currentAmount := quantity.NewQuantity()
+	if acct != nil && acct.CurrentBucket == currentBucket {
+		currentAmount = &acct.CurrentAmount
+	}
+
+	// Compute how much we can spend.
+	wanted := amount.Clone()
+	if err := wanted.Add(currentAmount); err != nil {
+		return nil, false
+	}
+	if wanted.Cmp(&sl.LimitAmount) > 0 {
+		return nil, false
+	}
+
+	return &SpendingAccount{
+		CurrentBucket: currentBucket,
+		CurrentAmount: *wanted,
+	}, true
+}
+
+// PrettyPrint writes a pretty-printed representation of SpendingLimit to the given writer.
+func (sl SpendingLimit) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sLimit: ", prefix)
+	token.PrettyPrintAmount(ctx, sl.LimitAmount, w)
+	fmt.Fprintf(w, " / %d epoch(s)\n", sl.LimitInterval)
+}
+
+// PrettyType returns a representation of SpendingLimit that can be used for pretty printing.
+func (sl SpendingLimit) PrettyType() (interface{}, error) {
+	return sl, nil
+}
+
+// SpendingAccount is the spending accounting state of a spending limit.
+type SpendingAccount struct {
+	// CurrentBucket specifies the interval we are currently doing accounting for.
+	CurrentBucket uint64 `json:"bucket"`
+	// CurrentAmount specifies the amount already spent in the current interval.
+	CurrentAmount quantity.Quantity `json:"amount"`
+}
+
+// Spending describes the effects of a method call relevant for spending policies.
+type Spending struct {
+	// Destinations are the staking accounts that receive funds.
+	Destinations []staking.Address
+	// Amount is the amount of base units spent.
+	Amount quantity.Quantity
+}
+
+// hasKnownSpending returns true iff the spending effects of the given method are known.
+func hasKnownSpending(method transaction.MethodName) bool {
+	switch method {
+	case staking.MethodTransfer,
+		staking.MethodTransferBatch,
+		staking.MethodBurn,
+		staking.MethodAddEscrow,
+		staking.MethodReclaimEscrow,
+		staking.MethodAllow,
+		staking.MethodWithdraw:
+		return true
+	default:
+		return false
+	}
+}
+
+// Spending decodes the message body and returns the spending effects of the message.
+func (am *ActionExecuteMessage) Spending() (*Spending, error) {
+	var sp Spending
+	switch am.Method {
+	case staking.MethodTransfer:
+		var xfer staking.Transfer
+		if err := cbor.Unmarshal(am.Body, &xfer); err != nil {
+			return nil, fmt.Errorf("malformed transfer: %w", err)
+		}
+		sp.Destinations = []staking.Address{xfer.To}
+		sp.Amount = xfer.Amount
+	case staking.MethodTransferBatch:
+		var batch staking.TransferBatch
+		if err := cbor.Unmarshal(am.Body, &batch); err != nil {
+			return nil, fmt.Errorf("malformed transfer batch: %w", err)
+		}
+		for _, xfer := range batch.Transfers {
+			sp.Destinations = append(sp.Destinations, xfer.To)
+			if err := sp.Amount.Add(&xfer.Amount); err != nil {
+				return nil, fmt.Errorf("malformed transfer batch: %w", err)
+			}
+		}
+	case staking.MethodBurn:
+		var burn staking.Burn
+		if err := cbor.Unmarshal(am.Body, &burn); err != nil {
+			return nil, fmt.Errorf("malformed burn: %w", err)
+		}
+		sp.Amount = burn.Amount
+	case staking.MethodAddEscrow:
+		var escrow staking.Escrow
+		if err := cbor.Unmarshal(am.Body, &escrow); err != nil {
+			return nil, fmt.Errorf("malformed escrow: %w", err)
+		}
+		sp.Destinations = []staking.Address{escrow.Account}
+		sp.Amount = escrow.Amount
+	case staking.MethodAllow:
+		var allow staking.Allow
+		if err := cbor.Unmarshal(am.Body, &allow); err != nil {
+			return nil, fmt.Errorf("malformed allow: %w", err)
+		}
+		sp.Destinations = []staking.Address{allow.Beneficiary}
+		if !allow.Negative {
+			sp.Amount = allow.AmountChange
+		}
+	case staking.MethodReclaimEscrow, staking.MethodWithdraw:
+		// Funds are only moved to the vault.
+	default:
+		return nil, fmt.Errorf("unknown spending effects of method: %s", am.Method)
+	}
+	return &sp, nil
+}
+
+// AuthorizeSpending checks whether the given execute message action complies with the vault
+// spending policy. In case the action is compliant and update is true, the vault's spending
+// accounting state is updated to reflect the additional spending.
+func (v *Vault) AuthorizeSpending(am *ActionExecuteMessage, epoch beacon.EpochTime, update bool) error {
+	sp := v.SpendingPolicy
+	if sp == nil {
+		return nil
+	}
+
+	if len(sp.AllowedMethods) > 0 && !slices.Contains(sp.AllowedMethods, am.Method) {
+		return fmt.Errorf("%w: method not allowed: %s", ErrSpendingPolicyViolation, am.Method)
+	}
+
+	limit := sp.MethodLimits[am.Method]
+	if len(sp.AllowedDestinations) == 0 && limit == nil && sp.TotalLimit == nil {
+		return nil
+	}
+
+	spending, err := am.Spending()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSpendingPolicyViolation, err)
+	}
+	if err = sp.authorizeDestinations(spending.Destinations); err != nil {
+		return err
+	}
+	if spending.Amount.IsZero() {
+		return nil
+	}
+
+	var methodAcct, totalAcct *SpendingAccount
+	if limit != nil {
+		var ok bool
+		if methodAcct, ok = limit.spend(v.SpendingAccounts[am.Method], &spending.Amount, epoch); !ok {
+			return fmt.Errorf("%w: spending limit exceeded for method: %s", ErrSpendingPolicyViolation, am.Method)
+		}
+	}
+	if sp.TotalLimit != nil {
+		var ok bool
+		if totalAcct, ok = sp.TotalLimit.spend(v.TotalSpendingAccount, &spending.Amount, epoch); !ok {
+			return fmt.Errorf("%w: total spending limit exceeded", ErrSpendingPolicyViolation)
+		}
+	}
+
+	if !update {
+		return nil
+	}
+	if methodAcct != nil {
+		if v.SpendingAccounts == nil {
+			v.SpendingAccounts = make(map[transaction.MethodName]*SpendingAccount)
+		}
+		v.SpendingAccounts[am.Method] = methodAcct
+	}
+	if totalAcct != nil {
+		v.TotalSpendingAccount = totalAcct
+	}
+	return nil
+}
+
+// AuthorizeWithdrawal checks whether a withdrawal from the vault to the given address complies
+// with the vault spending policy.
+//
+// Withdrawals are only subject to the allowed destinations. As the withdrawn amount is bounded by
+// an allowance, it is accounted for when the vault executes the allow method.
+func (v *Vault) AuthorizeWithdrawal(to staking.Address) error {
+	if v.SpendingPolicy == nil {
+		return nil
+	}
+	return v.SpendingPolicy.authorizeDestinations([]staking.Address{to})
+}
+
+func (sp *SpendingPolicy) authorizeDestinations(destinations []staking.Address) error {
+	if len(sp.AllowedDestinations) == 0 {
+		return nil
+	}
+	for _, dst := range destinations {
+		if !slices.Contains(sp.AllowedDestinations, dst) {
+			return fmt.Errorf("%w: destination not allowed: %s", ErrSpendingPolicyViolation, dst)
+		}
+	}
+	return nil
+}
+
+// UpdateSpendingPolicy updates the spending policy to a new policy together with any internal
+// accounting adjustments.
+func (v *Vault) UpdateSpendingPolicy(newPolicy *SpendingPolicy) {
+	// Reset accounting for methods without limits or with changed limit intervals.
+	for method := range v.SpendingAccounts {
+		if newPolicy == nil || v.SpendingPolicy == nil {
+			delete(v.SpendingAccounts, method)
+			continue
+		}
+		oldLimit, newLimit := v.SpendingPolicy.MethodLimits[method], newPolicy.MethodLimits[method]
+		if oldLimit == nil || newLimit == nil || oldLimit.LimitInterval != newLimit.LimitInterval {
+			delete(v.SpendingAccounts, method)
+		}
+	}
+	if len(v.SpendingAccounts) == 0 {
+		v.SpendingAccounts = nil
+	}
+
+	// Reset total accounting in case the total limit was removed or its interval has changed.
+	var oldTotal, newTotal *SpendingLimit
+	if v.SpendingPolicy != nil {
+		oldTotal = v.SpendingPolicy.TotalLimit
+	}
+	if newPolicy != nil {
+		newTotal = newPolicy.TotalLimit
+	}
+	if oldTotal == nil || newTotal == nil || oldTotal.LimitInterval != newTotal.LimitInterval {
+		v.TotalSpendingAccount = nil
+	}
+	v.SpendingPolicy = newPolicy
+}
+
+func sortedMethods[V any](m map[transaction.MethodName]V) []transaction.MethodName {
+	methods := make([]transaction.MethodName, 0, len(m))
+	for method := range m {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+	return methods
+}
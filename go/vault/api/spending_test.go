@@ -0,0 +1,260 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestSpendingPolicyValidate(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		policy SpendingPolicy
+		valid  bool
+		msg    string
+	}{
+		{SpendingPolicy{}, true, "empty policy should be valid"},
+		{SpendingPolicy{
+			AllowedMethods:      []transaction.MethodName{staking.MethodTransfer, staking.MethodAddEscrow},
+			AllowedDestinations: []staking.Address{testAddrA, testAddrB},
+			MethodLimits: map[transaction.MethodName]*SpendingLimit{
+				staking.MethodTransfer: {LimitAmount: *quantity.NewFromUint64(100), LimitInterval: 1},
+			},
+		}, true, "policy should be valid"},
+		{SpendingPolicy{
+			AllowedMethods: []transaction.MethodName{staking.MethodTransfer, staking.MethodTransfer},
+		}, false, "duplicate allowed methods should be invalid"},
+		{SpendingPolicy{
+			AllowedMethods: []transaction.MethodName{""},
+		}, false, "malformed allowed methods should be invalid"},
+		{SpendingPolicy{
+			AllowedDestinations: []staking.Address{testAddrA, testAddrA},
+		}, false, "duplicate allowed destinations should be invalid"},
+		{SpendingPolicy{
+			AllowedDestinations: []staking.Address{staking.CommonPoolAddress},
+		}, false, "malformed allowed destinations should be invalid"},
+		{SpendingPolicy{
+			MethodLimits: map[transaction.MethodName]*SpendingLimit{
+				staking.MethodTransfer: {LimitAmount: *quantity.NewFromUint64(100)},
+			},
+		}, false, "zero limit interval should be invalid"},
+		{SpendingPolicy{
+			MethodLimits: map[transaction.MethodName]*SpendingLimit{
+				staking.MethodTransfer: nil,
+			},
+		}, false, "missing limit should be invalid"},
+		{SpendingPolicy{
+			MethodLimits: map[transaction.MethodName]*SpendingLimit{
+				"registry.RegisterEntity": {LimitAmount: *quantity.NewFromUint64(100), LimitInterval: 1},
+			},
+		}, false, "limits for methods without known spending effects should be invalid"},
+		{SpendingPolicy{
+			TotalLimit: &SpendingLimit{LimitAmount: *quantity.NewFromUint64(100), LimitInterval: 1},
+		}, true, "total limit should be valid"},
+		{SpendingPolicy{
+			TotalLimit: &SpendingLimit{LimitAmount: *quantity.NewFromUint64(100)},
+		}, false, "zero total limit interval should be invalid"},
+	} {
+		err := tc.policy.Validate()
+		if tc.valid {
+			require.NoError(err, tc.msg)
+		} else {
+			require.Error(err, tc.msg)
+		}
+	}
+}
+
+func TestActionExecuteMessageSpending(t *testing.T) {
+	require := require.New(t)
+
+	am := ActionExecuteMessage{
+		Method: staking.MethodTransferBatch,
+		Body: cbor.Marshal(&staking.TransferBatch{
+			Transfers: []staking.Transfer{
+				{To: testAddrA, Amount: *quantity.NewFromUint64(10)},
+				{To: testAddrB, Amount: *quantity.NewFromUint64(20)},
+			},
+		}),
+	}
+	sp, err := am.Spending()
+	require.NoError(err, "Spending")
+	require.EqualValues([]staking.Address{testAddrA, testAddrB}, sp.Destinations)
+	require.EqualValues(*quantity.NewFromUint64(30), sp.Amount)
+
+	am = ActionExecuteMessage{
+		Method: staking.MethodAllow,
+		Body: cbor.Marshal(&staking.Allow{
+			Beneficiary:  testAddrC,
+			Negative:     true,
+			AmountChange: *quantity.NewFromUint64(10),
+		}),
+	}
+	sp, err = am.Spending()
+	require.NoError(err, "Spending")
+	require.EqualValues([]staking.Address{testAddrC}, sp.Destinations)
+	require.True(sp.Amount.IsZero(), "decreasing an allowance should not spend anything")
+
+	am = ActionExecuteMessage{
+		Method: staking.MethodTransfer,
+		Body:   []byte("malformed"),
+	}
+	_, err = am.Spending()
+	require.Error(err, "Spending should fail on malformed body")
+
+	am = ActionExecuteMessage{
+		Method: "registry.RegisterEntity",
+	}
+	_, err = am.Spending()
+	require.Error(err, "Spending should fail on methods without known spending effects")
+}
+
+func TestAuthorizeSpending(t *testing.T) {
+	require := require.New(t)
+
+	transfer := func(to staking.Address, amount uint64) *ActionExecuteMessage {
+		return &ActionExecuteMessage{
+			Method: staking.MethodTransfer,
+			Body: cbor.Marshal(&staking.Transfer{
+				To:     to,
+				Amount: *quantity.NewFromUint64(amount),
+			}),
+		}
+	}
+
+	vault := createTestVault()
+	err := vault.AuthorizeSpending(transfer(testAddrD, 1000), 1, true)
+	require.NoError(err, "AuthorizeSpending should succeed without a spending policy")
+
+	vault.UpdateSpendingPolicy(&SpendingPolicy{
+		AllowedMethods: []transaction.MethodName{
+			staking.MethodTransfer,
+			staking.MethodReclaimEscrow,
+		},
+		AllowedDestinations: []staking.Address{testAddrA, testAddrB},
+		MethodLimits: map[transaction.MethodName]*SpendingLimit{
+			// Limit transfers to 100 base units per 10 epochs.
+			staking.MethodTransfer: {LimitAmount: *quantity.NewFromUint64(100), LimitInterval: 10},
+		},
+	})
+
+	err = vault.AuthorizeSpending(&ActionExecuteMessage{Method: staking.MethodBurn}, 1, true)
+	require.ErrorIs(err, ErrSpendingPolicyViolation, "methods not in the allow-list should be rejected")
+
+	err = vault.AuthorizeSpending(&ActionExecuteMessage{
+		Method: staking.MethodReclaimEscrow,
+		Body:   cbor.Marshal(&staking.ReclaimEscrow{Account: testAddrD}),
+	}, 1, true)
+	require.NoError(err, "methods that do not spend should be allowed")
+
+	err = vault.AuthorizeSpending(transfer(testAddrD, 10), 1, true)
+	require.ErrorIs(err, ErrSpendingPolicyViolation, "destinations not in the allow-list should be rejected")
+
+	for _, tc := range []struct {
+		epoch    beacon.EpochTime
+		to       staking.Address
+		amount   uint64
+		expected bool
+	}{
+		// -- new bucket --
+		{1, testAddrA, 50, true},
+		{2, testAddrB, 50, true},
+		{3, testAddrA, 1, false},
+		{9, testAddrA, 0, true},
+		// -- new bucket --
+		{10, testAddrB, 101, false},
+		{10, testAddrB, 100, true},
+		{19, testAddrA, 1, false},
+		// -- new bucket --
+		{20, testAddrA, 1, true},
+	} {
+		err = vault.AuthorizeSpending(transfer(tc.to, tc.amount), tc.epoch, false)
+		if !tc.expected {
+			require.ErrorIs(err, ErrSpendingPolicyViolation, "AuthorizeSpending(%d, %d)", tc.epoch, tc.amount)
+			continue
+		}
+		require.NoError(err, "AuthorizeSpending(%d, %d)", tc.epoch, tc.amount)
+		err = vault.AuthorizeSpending(transfer(tc.to, tc.amount), tc.epoch, true)
+		require.NoError(err, "AuthorizeSpending(%d, %d)", tc.epoch, tc.amount)
+	}
+	require.EqualValues(*quantity.NewFromUint64(1), vault.SpendingAccounts[staking.MethodTransfer].CurrentAmount)
+
+	// Changing the limit interval should reset accounting.
+	vault.UpdateSpendingPolicy(&SpendingPolicy{
+		MethodLimits: map[transaction.MethodName]*SpendingLimit{
+			staking.MethodTransfer: {LimitAmount: *quantity.NewFromUint64(100), LimitInterval: 20},
+		},
+	})
+	require.Nil(vault.SpendingAccounts, "accounting should be reset")
+
+	// Removing the policy should allow everything.
+	vault.UpdateSpendingPolicy(nil)
+	err = vault.AuthorizeSpending(&ActionExecuteMessage{Method: staking.MethodBurn}, 1, true)
+	require.NoError(err, "AuthorizeSpending should succeed after the spending policy is removed")
+
+	// Method limits should only apply to spending by the given method while the total limit
+	// should apply to the combined spending of all methods.
+	vault.UpdateSpendingPolicy(&SpendingPolicy{
+		MethodLimits: map[transaction.MethodName]*SpendingLimit{
+			staking.MethodTransfer: {LimitAmount: *quantity.NewFromUint64(100), LimitInterval: 10},
+		},
+		TotalLimit: &SpendingLimit{LimitAmount: *quantity.NewFromUint64(200), LimitInterval: 10},
+	})
+	transferBatch := &ActionExecuteMessage{
+		Method: staking.MethodTransferBatch,
+		Body: cbor.Marshal(&staking.TransferBatch{Transfers: []staking.Transfer{
+			{To: testAddrA, Amount: *quantity.NewFromUint64(30)},
+			{To: testAddrB, Amount: *quantity.NewFromUint64(30)},
+		}}),
+	}
+	err = vault.AuthorizeSpending(transferBatch, 1, true)
+	require.NoError(err, "AuthorizeSpending should succeed within the limit")
+	err = vault.AuthorizeSpending(transfer(testAddrA, 100), 1, true)
+	require.NoError(err, "transfer batches should not count towards the transfer limit")
+	err = vault.AuthorizeSpending(transfer(testAddrA, 1), 1, false)
+	require.ErrorIs(err, ErrSpendingPolicyViolation, "transfers should be limited")
+	err = vault.AuthorizeSpending(&ActionExecuteMessage{
+		Method: staking.MethodAllow,
+		Body: cbor.Marshal(&staking.Allow{
+			Beneficiary:  testAddrD,
+			AmountChange: *quantity.NewFromUint64(40),
+		}),
+	}, 1, true)
+	require.NoError(err, "AuthorizeSpending should succeed within the total limit")
+	err = vault.AuthorizeSpending(transferBatch, 1, false)
+	require.ErrorIs(err, ErrSpendingPolicyViolation, "spending should be subject to the total limit")
+	require.Nil(vault.SpendingAccounts[staking.MethodTransferBatch], "methods without limits should not be accounted for")
+	require.EqualValues(*quantity.NewFromUint64(100), vault.SpendingAccounts[staking.MethodTransfer].CurrentAmount)
+	require.EqualValues(*quantity.NewFromUint64(200), vault.TotalSpendingAccount.CurrentAmount)
+
+	// Withdrawals are accounted for by the allowance so they should not count towards limits.
+	err = vault.AuthorizeWithdrawal(testAddrD)
+	require.NoError(err, "AuthorizeWithdrawal should succeed")
+	require.EqualValues(*quantity.NewFromUint64(200), vault.TotalSpendingAccount.CurrentAmount)
+
+	// Changing the total limit interval should reset total accounting.
+	vault.UpdateSpendingPolicy(&SpendingPolicy{
+		MethodLimits: map[transaction.MethodName]*SpendingLimit{
+			staking.MethodTransfer: {LimitAmount: *quantity.NewFromUint64(100), LimitInterval: 10},
+		},
+		TotalLimit: &SpendingLimit{LimitAmount: *quantity.NewFromUint64(200), LimitInterval: 5},
+	})
+	require.Nil(vault.TotalSpendingAccount, "total accounting should be reset")
+	require.NotNil(vault.SpendingAccounts[staking.MethodTransfer], "method accounting should be kept")
+
+	// Withdrawals should be limited to allowed destinations.
+	vault.UpdateSpendingPolicy(&SpendingPolicy{
+		AllowedMethods:      []transaction.MethodName{staking.MethodBurn},
+		AllowedDestinations: []staking.Address{testAddrA},
+	})
+	err = vault.AuthorizeWithdrawal(testAddrD)
+	require.ErrorIs(err, ErrSpendingPolicyViolation, "withdrawals to destinations not in the allow-list should be rejected")
+	err = vault.AuthorizeWithdrawal(testAddrA)
+	require.NoError(err, "AuthorizeWithdrawal should succeed for allowed destinations")
+}
@@ -6,7 +6,9 @@ import (
 	"io"
 	"slices"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
@@ -35,6 +37,13 @@ type Vault struct {
 	AdminAuthority Authority `json:"admin_authority"`
 	// SuspendAuthority specifies the vault's suspend authority.
 	SuspendAuthority Authority `json:"suspend_authority"`
+
+	// SpendingPolicy is the optional spending policy enforced for execute message actions.
+	SpendingPolicy *SpendingPolicy `json:"spending_policy,omitempty"`
+	// SpendingAccounts is the per-method spending accounting state.
+	SpendingAccounts map[transaction.MethodName]*SpendingAccount `json:"spending_accounts,omitempty"`
+	// TotalSpendingAccount is the total spending accounting state.
+	TotalSpendingAccount *SpendingAccount `json:"total_spending_account,omitempty"`
 }
 
 // NewVaultAddress returns the address for the vault.
@@ -76,6 +85,10 @@ type Authority struct {
 	Addresses []staking.Address `json:"addresses"`
 	// Threshold is the minimum number of addresses that must authorize an action.
 	Threshold uint8 `json:"threshold"`
+	// Delay is the number of epochs between an action reaching the authorization threshold and
+	// its execution. During this period the action can still be canceled. Zero means that
+	// actions are executed immediately.
+	Delay beacon.EpochTime `json:"delay,omitempty"`
 }
 
 // Validate validates the authority configuration.
@@ -93,6 +106,10 @@ func (a *Authority) Validate(params *ConsensusParameters) error {
 		return fmt.Errorf("too many addresses in authority (max: %d got: %d)",
 			params.MaxAuthorityAddresses, len(a.Addresses))
 	}
+	if a.Delay > params.MaxAuthorityDelay {
+		return fmt.Errorf("authority delay too large (max: %d got: %d)",
+			params.MaxAuthorityDelay, a.Delay)
+	}
 
 	// Ensure no duplicate addresses.
 	addressSet := make(map[staking.Address]struct{})
@@ -139,6 +156,9 @@ func (a Authority) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
 		fmt.Fprintf(w, "%s  - %s\n", prefix, addr)
 	}
 	fmt.Fprintf(w, "%sThreshold: %d\n", prefix, a.Threshold)
+	if a.Delay > 0 {
+		fmt.Fprintf(w, "%sDelay:     %d epochs\n", prefix, a.Delay)
+	}
 }
 
 // PrettyType returns a representation of Authority that can be used for pretty printing.
@@ -47,6 +47,15 @@ func TestAuthority(t *testing.T) {
 	err = auth.Validate(&DefaultConsensusParameters)
 	require.NoError(err, "Validate should succeed on valid authority configuration")
 
+	auth.Delay = DefaultConsensusParameters.MaxAuthorityDelay
+	err = auth.Validate(&DefaultConsensusParameters)
+	require.NoError(err, "Validate should succeed on maximum delay")
+
+	auth.Delay++
+	err = auth.Validate(&DefaultConsensusParameters)
+	require.Error(err, "Validate should fail on delay that exceeds the maximum")
+	auth.Delay = 0
+
 	ok := auth.Verify([]staking.Address{testAddrA})
 	require.False(ok, "Verify(testAddrA)")
 	ok = auth.Verify([]staking.Address{testAddrA, testAddrB})
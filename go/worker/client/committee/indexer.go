@@ -0,0 +1,152 @@
+package committee
+
+import (
+	"context"
+	"fmt"
+
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+)
+
+// roundIndexer is an index over runtime rounds maintained in the runtime history.
+type roundIndexer struct {
+	// name is the name of the index.
+	name string
+	// lastIndexedRound returns the last indexed round.
+	lastIndexedRound func() (uint64, error)
+	// indexRound indexes the given round given its I/O tree (which is nil for empty blocks).
+	indexRound func(ctx context.Context, round uint64, tree *transaction.Tree) error
+}
+
+func (n *Node) eventIndexer() *roundIndexer {
+	h := n.commonNode.Runtime.History()
+	return &roundIndexer{
+		name:             "event",
+		lastIndexedRound: h.LastIndexedRound,
+		indexRound: func(ctx context.Context, round uint64, tree *transaction.Tree) error {
+			var events []*history.Event
+			if tree != nil {
+				tags, err := tree.GetTags(ctx)
+				if err != nil {
+					return fmt.Errorf("error getting block I/O from storage: %w", err)
+				}
+
+				events = make([]*history.Event, 0, len(tags))
+				for _, tag := range tags {
+					events = append(events, &history.Event{
+						Key:    tag.Key,
+						Value:  tag.Value,
+						TxHash: tag.TxHash,
+					})
+				}
+			}
+			return h.IndexEvents(round, events)
+		},
+	}
+}
+
+func (n *Node) txIndexer() *roundIndexer {
+	h := n.commonNode.Runtime.History()
+	return &roundIndexer{
+		name:             "transaction",
+		lastIndexedRound: h.LastIndexedTransactionsRound,
+		indexRound: func(ctx context.Context, round uint64, tree *transaction.Tree) error {
+			var txs []*history.TransactionLocation
+			if tree != nil {
+				batch, err := tree.GetTransactions(ctx)
+				if err != nil {
+					return fmt.Errorf("error getting block I/O from storage: %w", err)
+				}
+
+				txs = make([]*history.TransactionLocation, 0, len(batch))
+				for _, tx := range batch {
+					txs = append(txs, &history.TransactionLocation{
+						TxHash: tx.Hash(),
+						Index:  tx.BatchOrder,
+					})
+				}
+			}
+			return h.IndexTransactions(round, txs)
+		},
+	}
+}
+
+// indexWorker maintains the runtime history indices as new blocks become available.
+func (n *Node) indexWorker(ctx context.Context, indexers []*roundIndexer) {
+	blkCh, blkSub, err := n.commonNode.Runtime.History().WatchBlocks()
+	if err != nil {
+		n.logger.Error("failed to watch blocks, indexing disabled",
+			"err", err,
+		)
+		return
+	}
+	defer blkSub.Close()
+
+	n.logger.Info("starting indexer")
+
+	for {
+		var annBlk *roothash.AnnotatedBlock
+		select {
+		case <-ctx.Done():
+			return
+		case annBlk = <-blkCh:
+		}
+
+		// Failed rounds are retried when the next block is received.
+		for _, idx := range indexers {
+			if err = n.indexRounds(ctx, idx, annBlk.Block.Header.Round); err != nil {
+				n.logger.Error("failed to index rounds",
+					"err", err,
+					"index", idx.name,
+					"round", annBlk.Block.Header.Round,
+				)
+			}
+		}
+	}
+}
+
+// indexRounds indexes all rounds up to and including the given round that have not yet been
+// indexed by the given indexer.
+func (n *Node) indexRounds(ctx context.Context, idx *roundIndexer, round uint64) error {
+	h := n.commonNode.Runtime.History()
+
+	earliestBlk, err := h.GetEarliestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get earliest block: %w", err)
+	}
+	nextRound := earliestBlk.Header.Round
+
+	lastRound, err := idx.lastIndexedRound()
+	switch err {
+	case nil:
+		nextRound = max(nextRound, lastRound+1)
+	case roothash.ErrNotFound:
+	default:
+		return fmt.Errorf("failed to get last indexed round: %w", err)
+	}
+
+	for ; nextRound <= round; nextRound++ {
+		var blk *block.Block
+		blk, err = h.GetBlock(ctx, nextRound)
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", nextRound, err)
+		}
+		if err = n.indexBlock(ctx, idx, blk); err != nil {
+			return fmt.Errorf("failed to index block %d: %w", nextRound, err)
+		}
+	}
+	return nil
+}
+
+func (n *Node) indexBlock(ctx context.Context, idx *roundIndexer, blk *block.Block) error {
+	if blk.Header.IORoot.IsEmpty() {
+		return idx.indexRound(ctx, blk.Header.Round, nil)
+	}
+
+	tree := transaction.NewTree(n.commonNode.Runtime.Storage(), blk.Header.StorageRootIO())
+	defer tree.Close()
+
+	return idx.indexRound(ctx, blk.Header.Round, tree)
+}
@@ -12,6 +12,7 @@ import (
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
@@ -300,6 +301,18 @@ func (n *Node) worker() {
 	// We are initialized.
 	close(n.initCh)
 
+	// Start the indexer if any of the indices is enabled.
+	var indexers []*roundIndexer
+	if config.GlobalConfig.Runtime.EventIndex.Enabled {
+		indexers = append(indexers, n.eventIndexer())
+	}
+	if config.GlobalConfig.Runtime.TxIndex.Enabled {
+		indexers = append(indexers, n.txIndexer())
+	}
+	if len(indexers) > 0 {
+		go n.indexWorker(ctx, indexers)
+	}
+
 	var (
 		recheckTicker *backoff.Ticker
 		blocks        []*block.Block
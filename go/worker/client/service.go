@@ -12,6 +12,7 @@ import (
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
@@ -243,6 +244,49 @@ func (s *service) GetTransactionsWithResults(ctx context.Context, request *api.G
 	return results, nil
 }
 
+// Implements api.RuntimeClient.
+func (s *service) GetTransactionByHash(ctx context.Context, request *api.GetTransactionByHashRequest) (*api.GetTransactionByHashResponse, error) {
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := rt.History().GetTransactionLocation(ctx, request.TxHash)
+	switch err {
+	case nil:
+	case history.ErrTransactionIndexDisabled:
+		return nil, api.ErrTxIndexDisabled
+	case roothash.ErrNotFound:
+		return nil, api.ErrNotFound
+	default:
+		return nil, err
+	}
+
+	blk, err := s.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: request.RuntimeID, Round: loc.Round})
+	if err != nil {
+		return nil, err
+	}
+
+	tree := s.getTxnTree(rt.Storage(), blk)
+	defer tree.Close()
+
+	tx, err := tree.GetTransaction(ctx, request.TxHash)
+	switch err {
+	case nil:
+	case transaction.ErrNotFound:
+		return nil, api.ErrNotFound
+	default:
+		return nil, err
+	}
+
+	return &api.GetTransactionByHashResponse{
+		Round:  loc.Round,
+		Index:  tx.BatchOrder,
+		Tx:     tx.Input,
+		Result: tx.Output,
+	}, nil
+}
+
 // Implements api.RuntimeClient.
 func (s *service) GetUnconfirmedTransactions(_ context.Context, runtimeID common.Namespace) ([][]byte, error) {
 	rt := s.w.commonWorker.GetRuntime(runtimeID)
@@ -292,6 +336,43 @@ func (s *service) GetEvents(ctx context.Context, request *api.GetEventsRequest)
 	return events, nil
 }
 
+// Implements api.RuntimeClient.
+func (s *service) QueryEvents(ctx context.Context, request *api.QueryEventsRequest) (*api.QueryEventsResponse, error) {
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := rt.History().QueryEvents(ctx, &history.EventQuery{
+		KeyPrefix: request.KeyPrefix,
+		FromRound: request.FromRound,
+		ToRound:   request.ToRound,
+		Limit:     request.Limit,
+		Cursor:    request.Cursor,
+	})
+	switch err {
+	case nil:
+	case history.ErrEventIndexDisabled:
+		return nil, api.ErrEventIndexDisabled
+	default:
+		return nil, err
+	}
+
+	events := make([]*api.IndexedEvent, 0, len(result.Events))
+	for _, ev := range result.Events {
+		events = append(events, &api.IndexedEvent{
+			Round:  ev.Round,
+			Key:    ev.Key,
+			Value:  ev.Value,
+			TxHash: ev.TxHash,
+		})
+	}
+	return &api.QueryEventsResponse{
+		Events:     events,
+		NextCursor: result.NextCursor,
+	}, nil
+}
+
 // Implements api.RuntimeClient.
 func (s *service) Query(ctx context.Context, request *api.QueryRequest) (*api.QueryResponse, error) {
 	rt := s.w.runtimes[request.RuntimeID]
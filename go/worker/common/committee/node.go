@@ -920,7 +920,10 @@ func NewNode(
 	n.notifier = runtimeRegistry.NewRuntimeHostNotifier(runtime, rhn.GetHostedRuntime(), consensus)
 
 	// Prepare transaction pool.
-	n.TxPool = txpool.New(runtime.ID(), txPoolCfg, rhn.GetHostedRuntime(), runtime.History(), n)
+	n.TxPool, err = txpool.New(runtime.ID(), txPoolCfg, runtime.DataDir(), rhn.GetHostedRuntime(), runtime.History(), n)
+	if err != nil {
+		return nil, err
+	}
 
 	// Register transaction message handler as that is something that all workers must handle.
 	p2pHost.RegisterHandler(txTopic, &txMsgHandler{n})
@@ -222,6 +222,7 @@ func (n *Node) handleCheckpoint(check *storageSync.Checkpoint, maxParallelReques
 				Index:   uint64(i),
 				Digest:  c,
 				Root:    check.Root,
+				Codec:   check.Codec,
 			},
 			checkpoint: check,
 		})
@@ -279,14 +280,19 @@ func (n *Node) getCheckpointList() ([]*storageSync.Checkpoint, error) {
 	ctx, cancel := context.WithTimeout(n.ctx, cpListsTimeout)
 	defer cancel()
 
-	list, err := n.storageSync.GetCheckpoints(ctx, &storageSync.GetCheckpointsRequest{
-		Version: 1,
-	})
-	if err != nil {
-		n.logger.Error("failed to retrieve any checkpoints",
-			"err", err,
-		)
-		return nil, err
+	var list []*storageSync.Checkpoint
+	for version := uint16(checkpoint.MinimumVersion); version <= checkpoint.LatestVersion; version++ {
+		cps, err := n.storageSync.GetCheckpoints(ctx, &storageSync.GetCheckpointsRequest{
+			Version: version,
+		})
+		if err != nil {
+			n.logger.Error("failed to retrieve any checkpoints",
+				"err", err,
+				"version", version,
+			)
+			return nil, err
+		}
+		list = append(list, cps...)
 	}
 
 	// Sort checkpoints by version, descending.
@@ -310,6 +316,10 @@ func (n *Node) checkCheckpointUsable(cp *storageSync.Checkpoint, remainingMask o
 		// Never fetch i/o root for genesis round.
 		return false
 	}
+	if err := cp.ValidateFormat(); err != nil {
+		// Unsupported checkpoint format.
+		return false
+	}
 
 	blk, err := n.commonNode.Runtime.History().GetCommittedBlock(n.ctx, cp.Root.Version)
 	if err != nil {
@@ -209,11 +209,17 @@ func NewNode(
 	if config.GlobalConfig.Storage.Checkpointer.Enabled {
 		checkInterval = config.GlobalConfig.Storage.Checkpointer.CheckInterval
 	}
+	chunkCodec, err := checkpoint.ParseChunkCodec(config.GlobalConfig.Storage.Checkpointer.ChunkCodec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint chunk codec: %w", err)
+	}
 	checkpointerCfg := checkpoint.CheckpointerConfig{
-		Name:            "runtime",
-		Namespace:       commonNode.Runtime.ID(),
-		CheckInterval:   checkInterval,
-		RootsPerVersion: 2, // State root and I/O root.
+		Name:                "runtime",
+		Namespace:           commonNode.Runtime.ID(),
+		CheckInterval:       checkInterval,
+		RootsPerVersion:     2, // State root and I/O root.
+		ChunkCodec:          chunkCodec,
+		MaxDeltaCheckpoints: config.GlobalConfig.Storage.Checkpointer.MaxDeltaCheckpoints,
 		GetParameters: func(ctx context.Context) (*checkpoint.CreationParameters, error) {
 			rt, rerr := commonNode.Runtime.ActiveDescriptor(ctx)
 			if rerr != nil {
@@ -244,7 +250,6 @@ func NewNode(
 			return blk.Header.StorageRoots(), nil
 		},
 	}
-	var err error
 	n.checkpointer, err = checkpoint.NewCheckpointer(
 		n.ctx,
 		localStorage.NodeDB(),
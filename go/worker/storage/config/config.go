@@ -2,8 +2,10 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
 )
 
@@ -31,13 +33,22 @@ type CheckpointerConfig struct {
 	Enabled bool `yaml:"enabled"`
 	// Storage checkpointer check interval.
 	CheckInterval time.Duration `yaml:"check_interval"`
+	// Maximum number of consecutive delta checkpoints between full checkpoints (zero disables
+	// delta checkpoints).
+	MaxDeltaCheckpoints uint64 `yaml:"max_delta_checkpoints,omitempty"`
+	// Codec used to compress checkpoint chunks (snappy or zstd, defaults to snappy).
+	ChunkCodec string `yaml:"chunk_codec,omitempty"`
 }
 
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
 	if c.Backend != "auto" {
-		_, err := db.GetBackendByName(c.Backend)
-		return err
+		if _, err := db.GetBackendByName(c.Backend); err != nil {
+			return err
+		}
+	}
+	if _, err := checkpoint.ParseChunkCodec(c.Checkpointer.ChunkCodec); err != nil {
+		return fmt.Errorf("checkpointer.chunk_codec: %w", err)
 	}
 	return nil
 }
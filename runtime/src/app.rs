@@ -6,9 +6,10 @@ use async_trait::async_trait;
 
 use crate::{
     common::sgx,
-    consensus::roothash,
+    consensus::{self, roothash, state::keymanager::Status as KeyManagerStatus},
     dispatcher::{Initializer, PostInitState, PreInitState},
     host::Host,
+    types,
 };
 
 /// An Oasis runtime app.
@@ -60,6 +61,27 @@ pub trait App: Send + Sync {
         Ok(())
     }
 
+    /// Called on new subscribed consensus event being detected.
+    async fn on_consensus_event(&self, ev: &consensus::Event) -> Result<()> {
+        // Default implementation does nothing.
+        Ok(())
+    }
+
+    /// Called on key manager status update.
+    async fn on_key_manager_status(&self, status: &KeyManagerStatus) -> Result<()> {
+        // Default implementation does nothing.
+        Ok(())
+    }
+
+    /// Called when a registered scheduled trigger fires.
+    async fn on_scheduled_trigger(
+        &self,
+        trigger: &types::RuntimeNotifyScheduledTrigger,
+    ) -> Result<()> {
+        // Default implementation does nothing.
+        Ok(())
+    }
+
     /// Called for runtime queries.
     async fn query(&self, method: &str, args: Vec<u8>) -> Result<Vec<u8>> {
         // Default implementation rejects all requests.
@@ -7,8 +7,8 @@
 use std::collections::BTreeMap;
 
 use crate::{
-    common::{quantity::Quantity, version::ProtocolVersions},
-    consensus::beacon::EpochTime,
+    common::{crypto::hash::Hash, quantity::Quantity, version::ProtocolVersions},
+    consensus::{address::Address, beacon::EpochTime},
 };
 
 /// A governance vote.
@@ -57,7 +57,10 @@ pub struct CancelUpgradeProposal {
 #[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
 pub struct ChangeParametersProposal {
     pub module: String,
+    #[cbor(optional)]
     pub changes: Option<cbor::Value>,
+    #[cbor(optional)]
+    pub patch: Option<cbor::Value>,
 }
 
 /// Consensus layer governance proposal content.
@@ -88,6 +91,72 @@ pub struct ConsensusParameterChanges {
     pub upgrade_cancel_min_epoch_diff: Option<EpochTime>,
     #[cbor(optional)]
     pub enable_change_parameters_proposal: Option<bool>,
+    #[cbor(optional)]
+    pub allow_parameters_patch: Option<bool>,
+    #[cbor(optional)]
+    pub allow_vote_delegation: Option<bool>,
+}
+
+/// A governance proposal state.
+#[derive(
+    Clone, Copy, Debug, Default, PartialEq, Eq, Hash, PartialOrd, Ord, cbor::Encode, cbor::Decode,
+)]
+#[repr(u8)]
+pub enum ProposalState {
+    /// Invalid state that should never be explicitly set.
+    #[default]
+    Invalid = 0,
+    /// Proposal is active and can be voted on.
+    Active = 1,
+    /// Proposal has passed.
+    Passed = 2,
+    /// Proposal has been rejected.
+    Rejected = 3,
+    /// Proposal has passed but its execution has failed.
+    Failed = 4,
+}
+
+/// Event emitted when a new proposal is submitted.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ProposalSubmittedEvent {
+    /// Unique identifier of a proposal.
+    pub id: u64,
+    /// Staking account address of the submitter.
+    pub submitter: Address,
+}
+
+/// Event emitted when a proposal is executed.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ProposalExecutedEvent {
+    /// Unique identifier of a proposal.
+    pub id: u64,
+}
+
+/// Event emitted when a proposal is finalized.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ProposalFinalizedEvent {
+    /// Unique identifier of a proposal.
+    pub id: u64,
+    /// New proposal state.
+    pub state: ProposalState,
+}
+
+/// A governance-related event.
+///
+/// Only proposal state change events are currently supported.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct Event {
+    #[cbor(optional)]
+    pub height: i64,
+    #[cbor(optional)]
+    pub tx_hash: Hash,
+
+    #[cbor(optional)]
+    pub proposal_submitted: Option<ProposalSubmittedEvent>,
+    #[cbor(optional)]
+    pub proposal_executed: Option<ProposalExecutedEvent>,
+    #[cbor(optional)]
+    pub proposal_finalized: Option<ProposalFinalizedEvent>,
 }
 
 #[cfg(test)]
@@ -171,6 +240,49 @@ mod tests {
                             voting_period: Some(123),
                             ..Default::default()
                         })),
+                        ..Default::default()
+                     }),
+                    ..Default::default()
+                }
+            ),
+            (
+                "oXFjaGFuZ2VfcGFyYW1ldGVyc6JmbW9kdWxla3Rlc3QtbW9kdWxlZ2NoYW5nZXOhdmFsbG93X3BhcmFtZXRlcnNfcGF0Y2j1",
+                ProposalContent {
+                    change_parameters: Some(ChangeParametersProposal {
+                        module: "test-module".into(),
+                        changes: Some(cbor::to_value(ConsensusParameterChanges{
+                            allow_parameters_patch: Some(true),
+                            ..Default::default()
+                        })),
+                        ..Default::default()
+                     }),
+                    ..Default::default()
+                }
+            ),
+            (
+                "oXFjaGFuZ2VfcGFyYW1ldGVyc6JmbW9kdWxla3Rlc3QtbW9kdWxlZ2NoYW5nZXOhdWFsbG93X3ZvdGVfZGVsZWdhdGlvbvU=",
+                ProposalContent {
+                    change_parameters: Some(ChangeParametersProposal {
+                        module: "test-module".into(),
+                        changes: Some(cbor::to_value(ConsensusParameterChanges{
+                            allow_vote_delegation: Some(true),
+                            ..Default::default()
+                        })),
+                        ..Default::default()
+                     }),
+                    ..Default::default()
+                }
+            ),
+            (
+                "oXFjaGFuZ2VfcGFyYW1ldGVyc6JlcGF0Y2ihbXZvdGluZ19wZXJpb2QYe2Ztb2R1bGVrdGVzdC1tb2R1bGU=",
+                ProposalContent {
+                    change_parameters: Some(ChangeParametersProposal {
+                        module: "test-module".into(),
+                        patch: Some(cbor::to_value(ConsensusParameterChanges{
+                            voting_period: Some(123),
+                            ..Default::default()
+                        })),
+                        ..Default::default()
                      }),
                     ..Default::default()
                 }
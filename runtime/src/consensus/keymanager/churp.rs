@@ -119,6 +119,65 @@ pub struct Status {
     pub applications: HashMap<PublicKey, Application>,
 }
 
+/// CreateRequest contains the initial configuration.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Decode, cbor::Encode)]
+pub struct CreateRequest {
+    /// A unique identifier within the key manager runtime.
+    pub id: u8,
+
+    /// The identifier of the key manager runtime.
+    pub runtime_id: Namespace,
+
+    /// The identifier of a cipher suite used for verifiable secret sharing
+    /// and key derivation.
+    #[cbor(optional)]
+    pub suite_id: SuiteId,
+
+    /// The minimum number of distinct shares required to reconstruct a key.
+    #[cbor(optional)]
+    pub threshold: u8,
+
+    /// The minimum number of shares that can be lost to render the secret
+    /// unrecoverable.
+    #[cbor(optional)]
+    pub extra_shares: u8,
+
+    /// The time interval in epochs between handoffs.
+    ///
+    /// A zero value disables handoffs.
+    #[cbor(optional)]
+    pub handoff_interval: EpochTime,
+
+    /// A signed SGX access control policy.
+    #[cbor(optional)]
+    pub policy: SignedPolicySGX,
+}
+
+/// UpdateRequest contains the updated configuration.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Decode, cbor::Encode)]
+pub struct UpdateRequest {
+    /// A unique identifier within the key manager runtime.
+    pub id: u8,
+
+    /// The identifier of the key manager runtime.
+    pub runtime_id: Namespace,
+
+    /// The minimum number of shares that can be lost to render the secret
+    /// unrecoverable.
+    #[cbor(optional)]
+    pub extra_shares: Option<u8>,
+
+    /// The time interval in epochs between handoffs.
+    ///
+    /// Zero value disables handoffs.
+    #[cbor(optional)]
+    pub handoff_interval: Option<EpochTime>,
+
+    /// A signed SGX access control policy.
+    #[cbor(optional)]
+    pub policy: Option<SignedPolicySGX>,
+}
+
 /// Application represents a node's application to form a new committee.
 #[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Decode, cbor::Encode)]
 pub struct Application {
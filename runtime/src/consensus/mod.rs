@@ -13,6 +13,7 @@ pub mod staking;
 pub mod state;
 pub mod tendermint;
 pub mod transaction;
+pub mod vault;
 pub mod verifier;
 
 /// A unique module name for the consensus module.
@@ -36,6 +37,10 @@ pub struct LightBlock {
 pub enum Event {
     #[cbor(rename = "staking")]
     Staking(staking::Event),
+    #[cbor(rename = "registry")]
+    Registry(registry::Event),
+    #[cbor(rename = "governance")]
+    Governance(governance::Event),
     // TODO: Add support for other kind of events.
 }
 
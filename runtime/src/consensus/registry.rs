@@ -938,6 +938,27 @@ pub struct RuntimeGenesis {
     pub round: u64,
 }
 
+/// Event emitted when a node is registered or deregistered.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct NodeEvent {
+    pub node: Node,
+    pub is_registration: bool,
+}
+
+/// A registry-related event.
+///
+/// Only node events are currently supported.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct Event {
+    #[cbor(optional)]
+    pub height: i64,
+    #[cbor(optional)]
+    pub tx_hash: Hash,
+
+    #[cbor(optional)]
+    pub node: Option<NodeEvent>,
+}
+
 #[cfg(test)]
 mod tests {
     use std::{convert::TryInto, net::Ipv4Addr};
@@ -2,7 +2,7 @@ use anyhow::Result;
 
 use crate::{
     common::{crypto::hash::Hash, quantity::Quantity, versioned::Versioned},
-    consensus::{address::Address, governance, registry, staking},
+    consensus::{address::Address, governance, keymanager::churp, registry, staking, vault},
 };
 
 /// A message that can be emitted by the runtime to be processed by the consensus layer.
@@ -16,6 +16,12 @@ pub enum Message {
 
     #[cbor(rename = "governance")]
     Governance(Versioned<GovernanceMessage>),
+
+    #[cbor(rename = "vault")]
+    Vault(Versioned<VaultMessage>),
+
+    #[cbor(rename = "keymanager")]
+    KeyManager(Versioned<KeyManagerMessage>),
 }
 
 impl Message {
@@ -43,6 +49,8 @@ impl Message {
             Message::Staking(msg) => msg.inner.validate_basic(),
             Message::Registry(msg) => msg.inner.validate_basic(),
             Message::Governance(msg) => msg.inner.validate_basic(),
+            Message::Vault(msg) => msg.inner.validate_basic(),
+            Message::KeyManager(msg) => msg.inner.validate_basic(),
         }
     }
 }
@@ -130,6 +138,64 @@ impl GovernanceMessage {
     }
 }
 
+#[derive(Clone, Debug, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub enum VaultMessage {
+    #[cbor(rename = "create")]
+    Create(vault::Create),
+    #[cbor(rename = "authorize_action")]
+    AuthorizeAction(vault::AuthorizeAction),
+    #[cbor(rename = "cancel_action")]
+    CancelAction(vault::CancelAction),
+}
+
+impl VaultMessage {
+    /// Performs basic validation of the vault message.
+    pub fn validate_basic(&self) -> Result<()> {
+        match self {
+            VaultMessage::Create(_) => {
+                // The vault authorities depend on the consensus parameters so they will be
+                // validated in the vault app when it processes the message.
+                Ok(())
+            }
+            VaultMessage::AuthorizeAction(_) => {
+                // The action depends on the consensus parameters so it will be validated in
+                // the vault app when it processes the message.
+                Ok(())
+            }
+            VaultMessage::CancelAction(_) => {
+                // No validation at this time.
+                Ok(())
+            }
+        }
+    }
+}
+
+#[derive(Clone, Debug, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub enum KeyManagerMessage {
+    #[cbor(rename = "create_churp")]
+    CreateChurp(churp::CreateRequest),
+    #[cbor(rename = "update_churp")]
+    UpdateChurp(churp::UpdateRequest),
+}
+
+impl KeyManagerMessage {
+    /// Performs basic validation of the key manager message.
+    pub fn validate_basic(&self) -> Result<()> {
+        match self {
+            KeyManagerMessage::CreateChurp(_) => {
+                // The request will already be validated in the key manager app when it
+                // processes the message, so we don't have to do any validation here.
+                Ok(())
+            }
+            KeyManagerMessage::UpdateChurp(_) => {
+                // The request will already be validated in the key manager app when it
+                // processes the message, so we don't have to do any validation here.
+                Ok(())
+            }
+        }
+    }
+}
+
 /// An incoming message emitted by the consensus layer to be processed by the runtime.
 #[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
 pub struct IncomingMessage {
@@ -340,6 +406,28 @@ mod tests {
                 ))],
                 "03312ddb5c41a30fbd29fb91cf6bf26d58073996f89657ca4f3b3a43a98bfd0b",
             ),
+            (
+                vec![Message::Vault(Versioned::new(
+                    0,
+                    VaultMessage::CancelAction(vault::CancelAction {
+                        vault: Address::default(),
+                        nonce: 1,
+                    }),
+                ))],
+                "d79488bd38722bb37e879e062c60d23d281412bf3d35e6ed226e2e2b242eb970",
+            ),
+            (
+                vec![Message::KeyManager(Versioned::new(
+                    0,
+                    KeyManagerMessage::UpdateChurp(churp::UpdateRequest {
+                        id: 1,
+                        runtime_id: Namespace::default(),
+                        extra_shares: Some(2),
+                        ..Default::default()
+                    }),
+                ))],
+                "38e078ef19928f9d8c482af42c6eacf2ea4593fb90c12802b143dfb4940a7cff",
+            ),
         ];
         for (msgs, expected_hash) in tcs {
             println!("{:?}", cbor::to_vec(msgs.clone()));
@@ -1,5 +1,5 @@
 use crate::common::{
-    crypto::signature::{signature_context_with_chain_separation, Signed},
+    crypto::signature::{signature_context_with_chain_separation, PublicKey, Signed},
     quantity::Quantity,
 };
 
@@ -18,6 +18,12 @@ pub struct Transaction {
     pub nonce: u64,
     /// Optional fee that the sender commits to pay to execute this transaction.
     pub fee: Option<Fee>,
+    /// Optional validity bound after which the transaction can no longer be executed.
+    #[cbor(optional)]
+    pub valid_until: Option<ValidUntil>,
+    /// Optional fee payer that pays the fee instead of the signer.
+    #[cbor(optional)]
+    pub fee_payer: Option<FeePayer>,
 
     /// Method that should be called.
     pub method: MethodName,
@@ -46,6 +52,26 @@ pub struct Fee {
     pub gas: Gas,
 }
 
+/// Account that pays the transaction fee on behalf of the signer.
+#[derive(Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct FeePayer {
+    /// Public key of the fee payer.
+    pub public_key: PublicKey,
+    /// Current fee payer's nonce to prevent replays.
+    pub nonce: u64,
+}
+
+/// Consensus transaction validity bound.
+#[derive(Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct ValidUntil {
+    /// Last consensus height at which the transaction can be executed.
+    #[cbor(optional)]
+    pub height: i64,
+    /// Last epoch in which the transaction can be executed.
+    #[cbor(optional)]
+    pub epoch: u64,
+}
+
 /// Consensus gas representation.
 pub type Gas = u64;
 
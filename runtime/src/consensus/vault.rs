@@ -0,0 +1,154 @@
+//! Vault structures.
+//!
+//! # Note
+//!
+//! This **MUST** be kept in sync with go/vault/api.
+//!
+use std::collections::BTreeMap;
+
+use crate::{
+    common::quantity::Quantity,
+    consensus::{address::Address, beacon::EpochTime},
+};
+
+/// Vault multisig authority.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct Authority {
+    /// Addresses that can authorize an action.
+    pub addresses: Vec<Address>,
+    /// Minimum number of addresses that must authorize an action.
+    pub threshold: u8,
+    /// Number of epochs between an action reaching the authorization threshold and its execution.
+    #[cbor(optional)]
+    pub delay: EpochTime,
+}
+
+/// Vault withdraw policy for a given address.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct WithdrawPolicy {
+    /// Maximum amount of tokens that may be withdrawn in the given interval.
+    pub limit_amount: Quantity,
+    /// Interval (in blocks) when the limit amount resets.
+    pub limit_interval: u64,
+}
+
+/// Limit on the amount of base units that may be spent.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct SpendingLimit {
+    /// Maximum amount of base units that may be spent in the given interval.
+    pub limit_amount: Quantity,
+    /// Interval (in epochs) when the limit amount resets.
+    pub limit_interval: EpochTime,
+}
+
+/// Vault spending policy that is enforced for all execute message actions and withdrawals.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct SpendingPolicy {
+    /// Optional allow-list of methods that can be called.
+    #[cbor(optional)]
+    pub allowed_methods: Vec<String>,
+    /// Optional allow-list of staking accounts that can receive funds.
+    #[cbor(optional)]
+    pub allowed_destinations: Vec<Address>,
+    /// Optional per-method limits on the amount of spent base units.
+    #[cbor(optional)]
+    pub method_limits: BTreeMap<String, SpendingLimit>,
+    /// Optional limit on the combined amount of base units spent by all methods.
+    #[cbor(optional)]
+    pub total_limit: Option<SpendingLimit>,
+}
+
+/// Action that suspends the vault.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ActionSuspend {}
+
+/// Action that resumes the vault.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ActionResume {}
+
+/// Action that executes a message on behalf of the vault.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct ActionExecuteMessage {
+    /// Method that should be called.
+    pub method: String,
+    /// Method call body.
+    #[cbor(optional)]
+    pub body: Option<cbor::Value>,
+}
+
+/// Action that updates the withdraw policy of the given address.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ActionUpdateWithdrawPolicy {
+    /// Address for which the policy should be updated.
+    pub address: Address,
+    /// New withdraw policy.
+    pub policy: WithdrawPolicy,
+}
+
+/// Action that updates the vault authorities.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ActionUpdateAuthority {
+    /// New admin authority.
+    #[cbor(optional)]
+    pub admin_authority: Option<Authority>,
+    /// New suspend authority.
+    #[cbor(optional)]
+    pub suspend_authority: Option<Authority>,
+}
+
+/// Action that updates the vault spending policy.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ActionUpdateSpendingPolicy {
+    /// New spending policy. If not set, the spending policy is removed.
+    #[cbor(optional)]
+    pub policy: Option<SpendingPolicy>,
+}
+
+/// Vault action. Exactly one of the fields must be set.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct Action {
+    #[cbor(optional)]
+    pub suspend: Option<ActionSuspend>,
+    #[cbor(optional)]
+    pub resume: Option<ActionResume>,
+    #[cbor(optional, rename = "execute_msg")]
+    pub execute_message: Option<ActionExecuteMessage>,
+    #[cbor(optional)]
+    pub update_withdraw_policy: Option<ActionUpdateWithdrawPolicy>,
+    #[cbor(optional)]
+    pub update_authority: Option<ActionUpdateAuthority>,
+    #[cbor(optional)]
+    pub update_spending_policy: Option<ActionUpdateSpendingPolicy>,
+}
+
+/// Vault creation call.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct Create {
+    /// Admin authority of the vault.
+    pub admin_authority: Authority,
+    /// Suspend authority of the vault.
+    pub suspend_authority: Authority,
+    /// Optional spending policy of the vault.
+    #[cbor(optional)]
+    pub spending_policy: Option<SpendingPolicy>,
+}
+
+/// Vault action authorization call.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct AuthorizeAction {
+    /// Address of the target vault.
+    pub vault: Address,
+    /// Action nonce.
+    pub nonce: u64,
+    /// Action that should be authorized.
+    pub action: Action,
+}
+
+/// Vault action cancellation call.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct CancelAction {
+    /// Address of the target vault.
+    pub vault: Address,
+    /// Action nonce.
+    pub nonce: u64,
+}
@@ -438,6 +438,9 @@ impl Dispatcher {
             Body::RuntimeNotifyRequest {
                 runtime_block,
                 runtime_event,
+                consensus_event,
+                key_manager_status,
+                scheduled_trigger,
             } => {
                 if let Some(runtime_block) = runtime_block {
                     if let Err(err) = state.app.on_runtime_block(&runtime_block).await {
@@ -453,6 +456,21 @@ impl Dispatcher {
                         error!(self.logger, "Application event notification failed"; "err" => ?err);
                     }
                 }
+                if let Some(consensus_event) = consensus_event {
+                    if let Err(err) = state.app.on_consensus_event(&consensus_event).await {
+                        error!(self.logger, "Application consensus event notification failed"; "err" => ?err);
+                    }
+                }
+                if let Some(status) = key_manager_status {
+                    if let Err(err) = state.app.on_key_manager_status(&status).await {
+                        error!(self.logger, "Application key manager status notification failed"; "err" => ?err);
+                    }
+                }
+                if let Some(trigger) = scheduled_trigger {
+                    if let Err(err) = state.app.on_scheduled_trigger(&trigger).await {
+                        error!(self.logger, "Application scheduled trigger notification failed"; "err" => ?err; "trigger_id" => &trigger.id);
+                    }
+                }
 
                 Ok(Body::Empty {})
             }
@@ -49,6 +49,12 @@ pub struct RegisterNotifyOpts {
     pub runtime_block: bool,
     /// Subscribe to runtime event notifications.
     pub runtime_event: Vec<Vec<u8>>,
+    /// Subscribe to consensus event notifications.
+    pub consensus_event: types::RegisterNotifyConsensusEvent,
+    /// Subscribe to status update notifications of the runtime's key manager.
+    pub key_manager_status: bool,
+    /// Replace the scheduled triggers. If not set, existing scheduled triggers are kept.
+    pub schedule: Option<types::RegisterNotifySchedule>,
 }
 
 /// Interface to the (untrusted) host node.
@@ -118,6 +124,12 @@ impl Host for Protocol {
                     tags if tags.is_empty() => None,
                     tags => Some(types::RegisterNotifyRuntimeEvent { tags }),
                 },
+                consensus_event: match opts.consensus_event {
+                    ev if ev.is_empty() => None,
+                    ev => Some(ev),
+                },
+                key_manager_status: opts.key_manager_status,
+                schedule: opts.schedule,
             })
             .await?
         {
@@ -22,7 +22,10 @@ use crate::{
     future::block_on,
     identity::Identity,
     storage::KeyValue,
-    types::{Body, Error, Message, MessageType, RuntimeInfoRequest, RuntimeInfoResponse},
+    types::{
+        Body, Error, HostLocalStorageKeyValue, Message, MessageType, RuntimeInfoRequest,
+        RuntimeInfoResponse,
+    },
     TeeType, BUILD_INFO,
 };
 
@@ -553,6 +556,39 @@ impl ProtocolUntrustedLocalStorage {
     pub fn new(protocol: Arc<Protocol>) -> Self {
         Self { protocol }
     }
+
+    /// Remove the given key from storage.
+    pub fn remove(&self, key: Vec<u8>) -> Result<(), Error> {
+        match self
+            .protocol
+            .call_host(Body::HostLocalStorageDeleteRequest { key })?
+        {
+            Body::HostLocalStorageDeleteResponse {} => Ok(()),
+            _ => Err(ProtocolError::InvalidResponse.into()),
+        }
+    }
+
+    /// Fetch up to `limit` key/value pairs with the given key prefix in key order, starting
+    /// after the given key (if non-empty).
+    ///
+    /// The host may return fewer pairs than requested in case the limit exceeds the host limit.
+    pub fn iterate(
+        &self,
+        prefix: Vec<u8>,
+        after: Vec<u8>,
+        limit: u64,
+    ) -> Result<Vec<HostLocalStorageKeyValue>, Error> {
+        match self
+            .protocol
+            .call_host(Body::HostLocalStorageIterateRequest {
+                prefix,
+                after,
+                limit,
+            })? {
+            Body::HostLocalStorageIterateResponse { items } => Ok(items),
+            _ => Err(ProtocolError::InvalidResponse.into()),
+        }
+    }
 }
 
 impl KeyValue for ProtocolUntrustedLocalStorage {
@@ -16,6 +16,7 @@ use crate::{
     },
     consensus::{
         self,
+        address::Address,
         beacon::EpochTime,
         registry::EndorsedCapabilityTEE,
         roothash::{self, Block, ComputeResultsHeader, Header},
@@ -223,6 +224,12 @@ pub enum Body {
         runtime_block: Option<roothash::AnnotatedBlock>,
         #[cbor(optional)]
         runtime_event: Option<RuntimeNotifyEvent>,
+        #[cbor(optional)]
+        consensus_event: Option<consensus::Event>,
+        #[cbor(optional)]
+        key_manager_status: Option<KeyManagerStatus>,
+        #[cbor(optional)]
+        scheduled_trigger: Option<RuntimeNotifyScheduledTrigger>,
     },
     RuntimeNotifyResponse {},
 
@@ -257,6 +264,22 @@ pub enum Body {
         value: Vec<u8>,
     },
     HostLocalStorageSetResponse {},
+    HostLocalStorageDeleteRequest {
+        key: Vec<u8>,
+    },
+    HostLocalStorageDeleteResponse {},
+    HostLocalStorageIterateRequest {
+        #[cbor(optional)]
+        prefix: Vec<u8>,
+        #[cbor(optional)]
+        after: Vec<u8>,
+        #[cbor(optional)]
+        limit: u64,
+    },
+    HostLocalStorageIterateResponse {
+        #[cbor(optional)]
+        items: Vec<HostLocalStorageKeyValue>,
+    },
     HostFetchConsensusBlockRequest {
         height: u64,
     },
@@ -313,6 +336,12 @@ pub enum Body {
         runtime_block: bool,
         #[cbor(optional)]
         runtime_event: Option<RegisterNotifyRuntimeEvent>,
+        #[cbor(optional)]
+        consensus_event: Option<RegisterNotifyConsensusEvent>,
+        #[cbor(optional)]
+        key_manager_status: bool,
+        #[cbor(optional)]
+        schedule: Option<RegisterNotifySchedule>,
     },
     HostRegisterNotifyResponse {},
 }
@@ -487,6 +516,13 @@ pub struct HostFetchConsensusEventsResponse {
     pub events: Vec<consensus::Event>,
 }
 
+/// Host local storage key/value pair.
+#[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct HostLocalStorageKeyValue {
+    pub key: Vec<u8>,
+    pub value: Vec<u8>,
+}
+
 /// Registration for runtime event notifications.
 #[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
 pub struct RegisterNotifyRuntimeEvent {
@@ -494,6 +530,70 @@ pub struct RegisterNotifyRuntimeEvent {
     pub tags: Vec<Vec<u8>>,
 }
 
+/// Registration for consensus event notifications.
+#[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct RegisterNotifyConsensusEvent {
+    /// Accounts for which to notify about incoming transfers.
+    #[cbor(optional)]
+    pub staking_transfers_to: Vec<Address>,
+    /// Whether to notify about registrations and deregistrations of nodes that host the runtime.
+    #[cbor(optional)]
+    pub registry_nodes: bool,
+    /// Whether to notify about governance proposal state changes.
+    #[cbor(optional)]
+    pub governance_proposals: bool,
+}
+
+impl RegisterNotifyConsensusEvent {
+    /// Whether no consensus events are subscribed to.
+    pub fn is_empty(&self) -> bool {
+        self.staking_transfers_to.is_empty() && !self.registry_nodes && !self.governance_proposals
+    }
+}
+
+/// Registration for scheduled triggers.
+#[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct RegisterNotifySchedule {
+    /// Scheduled triggers, replacing any previously registered ones.
+    #[cbor(optional)]
+    pub triggers: Vec<ScheduledTrigger>,
+}
+
+/// A scheduled trigger. Exactly one of the schedule fields must be set.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct ScheduledTrigger {
+    /// Unique trigger identifier.
+    pub id: String,
+    /// Trigger every given number of runtime rounds.
+    #[cbor(optional)]
+    pub every_rounds: u64,
+    /// Trigger every given number of epochs.
+    #[cbor(optional)]
+    pub every_epochs: u64,
+    /// Trigger once at the transition into the given epoch.
+    #[cbor(optional)]
+    pub at_epoch: EpochTime,
+    /// Trigger once at the given consensus height.
+    #[cbor(optional)]
+    pub at_height: i64,
+}
+
+/// A scheduled trigger notification.
+#[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct RuntimeNotifyScheduledTrigger {
+    /// Identifier of the trigger.
+    pub id: String,
+    /// Runtime round at which the trigger was scheduled (for round-based triggers).
+    #[cbor(optional)]
+    pub round: u64,
+    /// Epoch at which the trigger was scheduled (for epoch-based triggers).
+    #[cbor(optional)]
+    pub epoch: EpochTime,
+    /// Consensus height at which the trigger was scheduled (for height-based triggers).
+    #[cbor(optional)]
+    pub height: i64,
+}
+
 /// An event notification.
 #[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
 pub struct RuntimeNotifyEvent {
@@ -39,6 +39,7 @@ impl rofl::App for App {
                 .register_notify(host::RegisterNotifyOpts {
                     runtime_block: true,
                     runtime_event: vec![],
+                    ..Default::default()
                 })
                 .await;
 
@@ -157,6 +157,7 @@ impl app::App for App {
                 .register_notify(host::RegisterNotifyOpts {
                     runtime_block: true,
                     runtime_event: vec![b"kv_insertion.rofl_http".to_vec()],
+                    ..Default::default()
                 })
                 .await;
 